@@ -15,11 +15,23 @@ type Access interface {
 	IsSystem() bool
 	TeamNames() []string
 	CSRFToken() string
+	UserName() string
 }
 
+// Roles which may be granted to users and groups within a team, in order of
+// decreasing privilege. Each role is permitted any action permitted for the
+// roles below it.
+const (
+	OwnerRole            = "owner"
+	MemberRole           = "member"
+	PipelineOperatorRole = "pipeline-operator"
+	ViewerRole           = "viewer"
+)
+
 type access struct {
 	*jwt.Token
-	action string
+	action         string
+	rolesActionMap map[string]string
 }
 
 func (a *access) IsAuthenticated() bool {
@@ -40,13 +52,15 @@ func (a *access) IsAuthorized(team string) bool {
 }
 
 func (a *access) HasPermission(role string) bool {
-	switch requiredRoles[a.action] {
-	case "owner":
-		return role == "owner"
-	case "member":
-		return role == "owner" || role == "member"
-	case "viewer":
-		return role == "owner" || role == "member" || role == "viewer"
+	switch a.rolesActionMap[a.action] {
+	case OwnerRole:
+		return role == OwnerRole
+	case MemberRole:
+		return role == OwnerRole || role == MemberRole
+	case PipelineOperatorRole:
+		return role == OwnerRole || role == MemberRole || role == PipelineOperatorRole
+	case ViewerRole:
+		return role == OwnerRole || role == MemberRole || role == PipelineOperatorRole || role == ViewerRole
 	default:
 		return false
 	}
@@ -104,6 +118,17 @@ func (a *access) TeamNames() []string {
 	return teams
 }
 
+func (a *access) UserName() string {
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if userNameClaim, ok := claims["user_name"]; ok {
+			if userName, ok := userNameClaim.(string); ok {
+				return userName
+			}
+		}
+	}
+	return ""
+}
+
 func (a *access) CSRFToken() string {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if csrfTokenClaim, ok := claims["csrf"]; ok {
@@ -115,89 +140,96 @@ func (a *access) CSRFToken() string {
 	return ""
 }
 
+// requiredRoles maps each action to the least privileged role permitted to
+// perform it. It may be customized with AccessFactory.CustomizeActionRoleMap.
 var requiredRoles = map[string]string{
-	atc.SaveConfig:                    "member",
-	atc.GetConfig:                     "viewer",
-	atc.GetCC:                         "viewer",
-	atc.GetBuild:                      "viewer",
-	atc.GetBuildPlan:                  "viewer",
-	atc.CreateBuild:                   "member",
-	atc.ListBuilds:                    "viewer",
-	atc.BuildEvents:                   "viewer",
-	atc.BuildResources:                "viewer",
-	atc.AbortBuild:                    "member",
-	atc.GetBuildPreparation:           "viewer",
-	atc.GetJob:                        "viewer",
-	atc.CreateJobBuild:                "member",
-	atc.ListAllJobs:                   "viewer",
-	atc.ListJobs:                      "viewer",
-	atc.ListJobBuilds:                 "viewer",
-	atc.ListJobInputs:                 "viewer",
-	atc.GetJobBuild:                   "viewer",
-	atc.PauseJob:                      "member",
-	atc.UnpauseJob:                    "member",
-	atc.GetVersionsDB:                 "viewer",
-	atc.JobBadge:                      "viewer",
-	atc.MainJobBadge:                  "viewer",
-	atc.ClearTaskCache:                "member",
-	atc.ListAllResources:              "viewer",
-	atc.ListResources:                 "viewer",
-	atc.ListResourceTypes:             "viewer",
-	atc.GetResource:                   "viewer",
-	atc.PauseResource:                 "member",
-	atc.UnpauseResource:               "member",
-	atc.UnpinResource:                 "member",
-	atc.SetPinCommentOnResource:       "member",
-	atc.CheckResource:                 "member",
-	atc.CheckResourceWebHook:          "member",
-	atc.CheckResourceType:             "member",
-	atc.ListResourceVersions:          "viewer",
-	atc.GetResourceVersion:            "viewer",
-	atc.EnableResourceVersion:         "member",
-	atc.DisableResourceVersion:        "member",
-	atc.PinResourceVersion:            "member",
-	atc.ListBuildsWithVersionAsInput:  "viewer",
-	atc.ListBuildsWithVersionAsOutput: "viewer",
-	atc.GetResourceCausality:          "viewer",
-	atc.ListAllPipelines:              "viewer",
-	atc.ListPipelines:                 "viewer",
-	atc.GetPipeline:                   "viewer",
-	atc.DeletePipeline:                "member",
-	atc.OrderPipelines:                "member",
-	atc.PausePipeline:                 "member",
-	atc.UnpausePipeline:               "member",
-	atc.ExposePipeline:                "member",
-	atc.HidePipeline:                  "member",
-	atc.RenamePipeline:                "member",
-	atc.ListPipelineBuilds:            "viewer",
-	atc.CreatePipelineBuild:           "member",
-	atc.PipelineBadge:                 "viewer",
-	atc.RegisterWorker:                "member",
-	atc.LandWorker:                    "member",
-	atc.RetireWorker:                  "member",
-	atc.PruneWorker:                   "member",
-	atc.HeartbeatWorker:               "member",
-	atc.ListWorkers:                   "viewer",
-	atc.DeleteWorker:                  "member",
-	atc.SetLogLevel:                   "member",
-	atc.GetLogLevel:                   "viewer",
-	atc.DownloadCLI:                   "viewer",
-	atc.GetInfo:                       "viewer",
-	atc.GetInfoCreds:                  "viewer",
-	atc.ListContainers:                "viewer",
-	atc.GetContainer:                  "viewer",
-	atc.HijackContainer:               "member",
-	atc.ListDestroyingContainers:      "viewer",
-	atc.ReportWorkerContainers:        "member",
-	atc.ListVolumes:                   "viewer",
-	atc.ListDestroyingVolumes:         "viewer",
-	atc.ReportWorkerVolumes:           "member",
-	atc.ListTeams:                     "viewer",
-	atc.SetTeam:                       "owner",
-	atc.RenameTeam:                    "owner",
-	atc.DestroyTeam:                   "owner",
-	atc.ListTeamBuilds:                "viewer",
-	atc.CreateArtifact:                "member",
-	atc.GetArtifact:                   "member",
-	atc.ListBuildArtifacts:            "viewer",
+	atc.SaveConfig:                    MemberRole,
+	atc.GetConfig:                     ViewerRole,
+	atc.CheckPipelineCreds:            MemberRole,
+	atc.GetCC:                         ViewerRole,
+	atc.GetBuild:                      ViewerRole,
+	atc.GetBuildPlan:                  ViewerRole,
+	atc.CreateBuild:                   MemberRole,
+	atc.ListBuilds:                    ViewerRole,
+	atc.BuildEvents:                   ViewerRole,
+	atc.BuildResources:                ViewerRole,
+	atc.AbortBuild:                    PipelineOperatorRole,
+	atc.GetBuildPreparation:           ViewerRole,
+	atc.GetJob:                        ViewerRole,
+	atc.CreateJobBuild:                PipelineOperatorRole,
+	atc.ListAllJobs:                   ViewerRole,
+	atc.ListJobs:                      ViewerRole,
+	atc.ListJobBuilds:                 ViewerRole,
+	atc.ListJobInputs:                 ViewerRole,
+	atc.ExplainJob:                    ViewerRole,
+	atc.GetJobBuild:                   ViewerRole,
+	atc.PauseJob:                      PipelineOperatorRole,
+	atc.UnpauseJob:                    PipelineOperatorRole,
+	atc.GetVersionsDB:                 ViewerRole,
+	atc.JobBadge:                      ViewerRole,
+	atc.MainJobBadge:                  ViewerRole,
+	atc.ClearTaskCache:                PipelineOperatorRole,
+	atc.ListAllResources:              ViewerRole,
+	atc.ListResources:                 ViewerRole,
+	atc.ListResourceTypes:             ViewerRole,
+	atc.GetResource:                   ViewerRole,
+	atc.PauseResource:                 PipelineOperatorRole,
+	atc.UnpauseResource:               PipelineOperatorRole,
+	atc.UnpinResource:                 PipelineOperatorRole,
+	atc.SetPinCommentOnResource:       PipelineOperatorRole,
+	atc.CheckResource:                 PipelineOperatorRole,
+	atc.CheckResourceWebHook:          MemberRole,
+	atc.CheckResourceType:             PipelineOperatorRole,
+	atc.ListResourceChecks:            ViewerRole,
+	atc.CreateResourceCheck:           PipelineOperatorRole,
+	atc.ListResourceVersions:          ViewerRole,
+	atc.GetResourceVersion:            ViewerRole,
+	atc.EnableResourceVersion:         PipelineOperatorRole,
+	atc.DisableResourceVersion:        PipelineOperatorRole,
+	atc.PinResourceVersion:            PipelineOperatorRole,
+	atc.ListBuildsWithVersionAsInput:  ViewerRole,
+	atc.ListBuildsWithVersionAsOutput: ViewerRole,
+	atc.GetResourceCausality:          ViewerRole,
+	atc.ListAllPipelines:              ViewerRole,
+	atc.ListPipelines:                 ViewerRole,
+	atc.GetPipeline:                   ViewerRole,
+	atc.DeletePipeline:                MemberRole,
+	atc.OrderPipelines:                MemberRole,
+	atc.PausePipeline:                 PipelineOperatorRole,
+	atc.UnpausePipeline:               PipelineOperatorRole,
+	atc.ExposePipeline:                MemberRole,
+	atc.HidePipeline:                  MemberRole,
+	atc.RenamePipeline:                MemberRole,
+	atc.ListPipelineBuilds:            ViewerRole,
+	atc.CreatePipelineBuild:           MemberRole,
+	atc.PipelineBadge:                 ViewerRole,
+	atc.RegisterWorker:                MemberRole,
+	atc.LandWorker:                    MemberRole,
+	atc.RetireWorker:                  MemberRole,
+	atc.PruneWorker:                   MemberRole,
+	atc.HeartbeatWorker:               MemberRole,
+	atc.ListWorkers:                   ViewerRole,
+	atc.DeleteWorker:                  MemberRole,
+	atc.SetLogLevel:                   MemberRole,
+	atc.GetLogLevel:                   ViewerRole,
+	atc.DownloadCLI:                   ViewerRole,
+	atc.GetInfo:                       ViewerRole,
+	atc.GetInfoCreds:                  ViewerRole,
+	atc.ListAuditEvents:               ViewerRole,
+	atc.ListContainers:                ViewerRole,
+	atc.GetContainer:                  ViewerRole,
+	atc.HijackContainer:               MemberRole,
+	atc.ListDestroyingContainers:      ViewerRole,
+	atc.ReportWorkerContainers:        MemberRole,
+	atc.ListVolumes:                   ViewerRole,
+	atc.ListDestroyingVolumes:         ViewerRole,
+	atc.ReportWorkerVolumes:           MemberRole,
+	atc.ListTeams:                     ViewerRole,
+	atc.SetTeam:                       OwnerRole,
+	atc.RenameTeam:                    OwnerRole,
+	atc.DestroyTeam:                   OwnerRole,
+	atc.ListTeamBuilds:                ViewerRole,
+	atc.CreateArtifact:                MemberRole,
+	atc.GetArtifact:                   MemberRole,
+	atc.ListBuildArtifacts:            ViewerRole,
 }
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -14,15 +17,32 @@ import (
 
 type AccessFactory interface {
 	Create(*http.Request, string) Access
+	CustomizeActionRoleMap(lager.Logger, CustomActionRoleMap) error
 }
 
+// CustomActionRoleMap maps roles to the actions which they should be the
+// least privileged role permitted to perform, overriding the defaults, e.g.
+//
+//	pipeline-operator:
+//	- CreatePipelineBuild
+//
+// Actions are named after their routes in atc.Routes.
+type CustomActionRoleMap map[string][]string
+
 type accessFactory struct {
-	publicKey *rsa.PublicKey
+	publicKey      *rsa.PublicKey
+	rolesActionMap map[string]string
 }
 
 func NewAccessFactory(key *rsa.PublicKey) AccessFactory {
+	rolesActionMap := map[string]string{}
+	for action, role := range requiredRoles {
+		rolesActionMap[action] = role
+	}
+
 	return &accessFactory{
-		publicKey: key,
+		publicKey:      key,
+		rolesActionMap: rolesActionMap,
 	}
 }
 
@@ -33,7 +53,66 @@ func (a *accessFactory) Create(r *http.Request, action string) Access {
 		token = &jwt.Token{}
 	}
 
-	return &access{token, action}
+	return &access{token, action, a.rolesActionMap}
+}
+
+// CustomizeActionRoleMap validates the given mapping against the known roles
+// and the routes in atc.Routes, and then applies it over the defaults. If the
+// mapping is invalid, none of it is applied.
+func (a *accessFactory) CustomizeActionRoleMap(logger lager.Logger, customMapping CustomActionRoleMap) error {
+	routes := map[string]bool{}
+	for _, route := range atc.Routes {
+		routes[route.Name] = true
+	}
+
+	customized := map[string]string{}
+	errs := []string{}
+
+	roles := []string{}
+	for role := range customMapping {
+		roles = append(roles, role)
+	}
+
+	sort.Strings(roles)
+
+	for _, role := range roles {
+		switch role {
+		case OwnerRole, MemberRole, PipelineOperatorRole, ViewerRole:
+		default:
+			errs = append(errs, fmt.Sprintf("unknown role '%s'", role))
+			continue
+		}
+
+		for _, action := range customMapping[role] {
+			if !routes[action] {
+				errs = append(errs, fmt.Sprintf("unknown action '%s' for role '%s'", action, role))
+				continue
+			}
+
+			if existing, found := customized[action]; found {
+				errs = append(errs, fmt.Sprintf("action '%s' is mapped to both '%s' and '%s'", action, existing, role))
+				continue
+			}
+
+			customized[action] = role
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid role-action mapping:\n%s", strings.Join(errs, "\n"))
+	}
+
+	for action, role := range customized {
+		logger.Info("customize-action-role", lager.Data{
+			"action":   action,
+			"role":     role,
+			"previous": a.rolesActionMap[action],
+		})
+
+		a.rolesActionMap[action] = role
+	}
+
+	return nil
 }
 
 func (a *accessFactory) parseToken(r *http.Request) (*jwt.Token, error) {
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	jwt "github.com/dgrijalva/jwt-go"
 
@@ -85,4 +87,89 @@ var _ = Describe("AccessorFactory", func() {
 			})
 		})
 	})
+
+	Describe("CustomizeActionRoleMap", func() {
+		var (
+			customMapping accessor.CustomActionRoleMap
+			customizeErr  error
+		)
+
+		BeforeEach(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			accessorFactory = accessor.NewAccessFactory(&key.PublicKey)
+
+			customMapping = accessor.CustomActionRoleMap{
+				accessor.ViewerRole: []string{atc.PauseJob},
+				accessor.OwnerRole:  []string{atc.GetConfig},
+			}
+		})
+
+		JustBeforeEach(func() {
+			customizeErr = accessorFactory.CustomizeActionRoleMap(lagertest.NewTestLogger("test"), customMapping)
+		})
+
+		isAuthorized := func(action string, role string) bool {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, &jwt.MapClaims{
+				"teams": map[string][]string{"some-team": {role}},
+			})
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("GET", "localhost:8080", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+
+			return accessorFactory.Create(req, action).IsAuthorized("some-team")
+		}
+
+		It("applies the mapping over the defaults", func() {
+			Expect(customizeErr).NotTo(HaveOccurred())
+
+			Expect(isAuthorized(atc.PauseJob, accessor.ViewerRole)).To(BeTrue())
+			Expect(isAuthorized(atc.GetConfig, accessor.MemberRole)).To(BeFalse())
+			Expect(isAuthorized(atc.GetConfig, accessor.OwnerRole)).To(BeTrue())
+		})
+
+		It("leaves other actions untouched", func() {
+			Expect(isAuthorized(atc.SaveConfig, accessor.MemberRole)).To(BeTrue())
+			Expect(isAuthorized(atc.SaveConfig, accessor.PipelineOperatorRole)).To(BeFalse())
+		})
+
+		Context("when the mapping has an unknown role", func() {
+			BeforeEach(func() {
+				customMapping["bogus-role"] = []string{atc.SaveConfig}
+			})
+
+			It("returns an error", func() {
+				Expect(customizeErr).To(MatchError(ContainSubstring("unknown role 'bogus-role'")))
+			})
+
+			It("does not apply any of the mapping", func() {
+				Expect(isAuthorized(atc.PauseJob, accessor.ViewerRole)).To(BeFalse())
+			})
+		})
+
+		Context("when the mapping has an unknown action", func() {
+			BeforeEach(func() {
+				customMapping[accessor.MemberRole] = []string{"BogusAction"}
+			})
+
+			It("returns an error", func() {
+				Expect(customizeErr).To(MatchError(ContainSubstring("unknown action 'BogusAction' for role 'member'")))
+			})
+		})
+
+		Context("when an action is mapped to more than one role", func() {
+			BeforeEach(func() {
+				customMapping[accessor.MemberRole] = []string{atc.PauseJob}
+			})
+
+			It("returns an error", func() {
+				Expect(customizeErr).To(MatchError(ContainSubstring("action 'PauseJob' is mapped to both 'member' and 'viewer'")))
+			})
+		})
+	})
 })
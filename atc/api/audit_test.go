@@ -0,0 +1,159 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	Describe("GET /api/v1/audit", func() {
+		var (
+			fakeaccess *accessorfakes.FakeAccess
+			query      string
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeaccess = new(accessorfakes.FakeAccess)
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			fakeAccessor.CreateReturns(fakeaccess)
+
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/audit"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+
+				dbAuditRepository.EventsReturns([]db.AuditEvent{
+					{
+						ID:       2,
+						Time:     time.Unix(123, 0),
+						UserName: "some-user",
+						TeamName: "some-team",
+						Action:   "PausePipeline",
+						Method:   "PUT",
+						Target:   "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+						Status:   200,
+					},
+					{
+						ID:       1,
+						Time:     time.Unix(100, 0),
+						UserName: "other-user",
+						Action:   "SetLogLevel",
+						Method:   "PUT",
+						Target:   "/api/v1/log-level",
+						Status:   403,
+					},
+				}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the events", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"time": 123,
+						"user_name": "some-user",
+						"team_name": "some-team",
+						"action": "PausePipeline",
+						"method": "PUT",
+						"target": "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+						"status": 200
+					},
+					{
+						"id": 1,
+						"time": 100,
+						"user_name": "other-user",
+						"action": "SetLogLevel",
+						"method": "PUT",
+						"target": "/api/v1/log-level",
+						"status": 403
+					}
+				]`))
+			})
+
+			It("does not filter by default", func() {
+				Expect(dbAuditRepository.EventsArgsForCall(0)).To(Equal(db.AuditFilter{}))
+			})
+
+			Context("when filters are given", func() {
+				BeforeEach(func() {
+					query = "?user=some-user&team=some-team&action=PausePipeline&since=100&limit=5"
+				})
+
+				It("filters the events", func() {
+					Expect(dbAuditRepository.EventsArgsForCall(0)).To(Equal(db.AuditFilter{
+						UserName: "some-user",
+						TeamName: "some-team",
+						Action:   "PausePipeline",
+						Since:    time.Unix(100, 0),
+						Limit:    5,
+					}))
+				})
+			})
+
+			Context("when the limit is not a number", func() {
+				BeforeEach(func() {
+					query = "?limit=lots"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					dbAuditRepository.EventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
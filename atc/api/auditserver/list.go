@@ -0,0 +1,65 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListAuditEvents returns the recorded audit events, most recent first. The
+// events may be narrowed down by the user, team, action, since, and limit
+// query params.
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	filter := db.AuditFilter{
+		UserName: r.FormValue("user"),
+		TeamName: r.FormValue("team"),
+		Action:   r.FormValue("action"),
+	}
+
+	var err error
+
+	if since := r.FormValue("since"); since != "" {
+		unix, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		filter.Since = time.Unix(unix, 0)
+	}
+
+	if limit := r.FormValue("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	events, err := s.repository.Events(filter)
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedEvents := []atc.AuditEvent{}
+	for _, event := range events {
+		presentedEvents = append(presentedEvents, present.AuditEvent(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presentedEvents)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,19 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	repository db.AuditRepository
+}
+
+func NewServer(logger lager.Logger, repository db.AuditRepository) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
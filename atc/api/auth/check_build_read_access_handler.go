@@ -101,6 +101,18 @@ func (h checkBuildReadAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		}
 
 		if !h.allowPrivateJob {
+			// a check build's output is whatever the resource's check script
+			// printed, which isn't safe to show outside of the team
+			if build.ResourceID() != 0 {
+				if acc.IsAuthenticated() {
+					h.rejector.Forbidden(w, r)
+					return
+				}
+
+				h.rejector.Unauthorized(w, r)
+				return
+			}
+
 			job, found, err := pipeline.Job(build.JobName())
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
@@ -263,6 +263,20 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 					})
 				})
+
+				Context("when the build is a resource's check", func() {
+					BeforeEach(func() {
+						build.ResourceIDReturns(7)
+					})
+
+					It("rejects the request", func() {
+						Expect(response.StatusCode).To(Equal(status))
+					})
+
+					It("does not look up a job", func() {
+						Expect(pipeline.JobCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Context("when pipeline is private", func() {
@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/eventstore"
 	"github.com/vito/go-sse/sse"
 )
 
@@ -17,6 +18,21 @@ const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+	return newEventHandler(logger, build, build.Events)
+}
+
+// NewEventHandlerFactory returns an EventHandlerFactory which reads builds'
+// events through the given BuildEvents, so that events which have been
+// archived can still be served.
+func NewEventHandlerFactory(buildEvents eventstore.BuildEvents) EventHandlerFactory {
+	return func(logger lager.Logger, build db.Build) http.Handler {
+		return newEventHandler(logger, build, func(from uint) (db.EventSource, error) {
+			return buildEvents.Events(build, from)
+		})
+	}
+}
+
+func newEventHandler(logger lager.Logger, build db.Build, buildEvents func(uint) (db.EventSource, error)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
 
@@ -55,7 +71,7 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
-		events, err := build.Events(eventID)
+		events, err := buildEvents(eventID)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
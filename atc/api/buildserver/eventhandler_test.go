@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore/eventstorefakes"
 	"github.com/vito/go-sse/sse"
 
 	. "github.com/onsi/ginkgo"
@@ -276,3 +277,57 @@ var _ = Describe("Handler", func() {
 		})
 	})
 })
+
+var _ = Describe("NewEventHandlerFactory", func() {
+	var (
+		build           *dbfakes.FakeBuild
+		fakeBuildEvents *eventstorefakes.FakeBuildEvents
+		fakeEventSource *dbfakes.FakeEventSource
+
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		build = new(dbfakes.FakeBuild)
+
+		fakeEventSource = new(dbfakes.FakeEventSource)
+		fakeEventSource.NextReturnsOnCall(0, fakeEvent(`{"event":1}`), nil)
+		fakeEventSource.NextReturnsOnCall(1, event.Envelope{}, db.ErrEndOfBuildEventStream)
+
+		fakeBuildEvents = new(eventstorefakes.FakeBuildEvents)
+		fakeBuildEvents.EventsReturns(fakeEventSource, nil)
+
+		factory := NewEventHandlerFactory(fakeBuildEvents)
+		server = httptest.NewServer(factory(lagertest.NewTestLogger("test"), build))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads the build's events through the BuildEvents", func() {
+		request, err := http.NewRequest("GET", server.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.Header.Set("Last-Event-ID", "1")
+
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		reader := sse.NewReadCloser(response.Body)
+		Expect(reader.Next()).To(Equal(sse.Event{
+			ID:   "2",
+			Name: "event",
+			Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+		}))
+
+		_ = response.Body.Close()
+
+		Expect(fakeBuildEvents.EventsCallCount()).To(Equal(1))
+		actualBuild, from := fakeBuildEvents.EventsArgsForCall(0)
+		Expect(actualBuild).To(Equal(build))
+		Expect(from).To(Equal(uint(2)))
+
+		Expect(build.EventsCallCount()).To(BeZero())
+	})
+})
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/textproto"
 
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
@@ -372,6 +373,182 @@ var _ = Describe("Config API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/check-creds", func() {
+		var (
+			fakeVariables *credsfakes.FakeVariables
+			response      *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeVariables = new(credsfakes.FakeVariables)
+			fakeVariables.GetStub = func(varDef template.VariableDefinition) (interface{}, bool, error) {
+				switch varDef.Name {
+				case "some-secret", "vault:some/path":
+					return "shh", true, nil
+				case "broken":
+					return nil, false, errors.New("oh no")
+				default:
+					return nil, false, nil
+				}
+			}
+
+			fakeVariablesFactory.NewVariablesReturns(fakeVariables)
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.CheckPipelineCreds, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "some-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			var fakeTeam *dbfakes.FakeTeam
+			var fakePipeline *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakeTeam = new(dbfakes.FakeTeam)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakePipeline = new(dbfakes.FakePipeline)
+				fakeTeam.PipelineReturns(fakePipeline, true, nil)
+
+				fakeResourceType := new(dbfakes.FakeResourceType)
+				fakeResourceType.NameReturns("custom-resource")
+				fakeResourceType.SourceReturns(atc.Source{"token": "((some-secret))"})
+				fakePipeline.ResourceTypesReturns(db.ResourceTypes{fakeResourceType}, nil)
+
+				fakeResource := new(dbfakes.FakeResource)
+				fakeResource.NameReturns("some-resource")
+				fakeResource.SourceReturns(atc.Source{
+					"password": "((vault:some/path))",
+					"key":      "((missing-key))",
+				})
+				fakePipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
+
+				fakeJob := new(dbfakes.FakeJob)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:    "some-resource",
+							Params: atc.Params{"secret": "((some-secret))"},
+						},
+						{
+							InParallel: &atc.InParallelConfig{
+								Steps: atc.PlanSequence{
+									{
+										Task:           "some-task",
+										TaskConfigPath: "some/task.yml",
+										TaskVars:       atc.Params{"key": "((missing-key))"},
+										Failure: &atc.PlanConfig{
+											Put:    "some-resource",
+											Params: atc.Params{"broken": "((broken.field))"},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+				fakePipeline.JobsReturns(db.Jobs{fakeJob}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("looks up the vars using the team and pipeline's variables", func() {
+				Expect(fakeVariablesFactory.NewVariablesCallCount()).To(Equal(1))
+				teamName, pipelineName := fakeVariablesFactory.NewVariablesArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(pipelineName).To(Equal("some-pipeline"))
+			})
+
+			It("reports each var which is missing or fails to be looked up, by location", func() {
+				var checkResponse atc.CheckCredsResponse
+				err := json.NewDecoder(response.Body).Decode(&checkResponse)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(checkResponse).To(Equal(atc.CheckCredsResponse{
+					Checked: 6,
+					Failures: []atc.CredentialCheckFailure{
+						{
+							Location: "resources.some-resource",
+							Var:      "missing-key",
+							Missing:  true,
+						},
+						{
+							Location: "jobs.some-job.plan[1].in_parallel.steps[0].task.some-task",
+							Var:      "missing-key",
+							Missing:  true,
+						},
+						{
+							Location: "jobs.some-job.plan[1].in_parallel.steps[0].task.some-task.on_failure.put.some-resource",
+							Var:      "broken.field",
+							Error:    "oh no",
+						},
+					},
+				}))
+			})
+
+			It("looks up each var only once", func() {
+				Expect(fakeVariables.GetCallCount()).To(Equal(4))
+			})
+
+			It("does not include any values", func() {
+				Expect(ioutil.ReadAll(response.Body)).ToNot(ContainSubstring("shh"))
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when finding the jobs fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobsReturns(nil, errors.New("failed"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config", func() {
 		var (
 			request  *http.Request
@@ -516,6 +693,25 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when it has escapes which YAML does not support", func() {
+							BeforeEach(func() {
+								pipelineConfig.Resources[0].Source["path"] = "some/path"
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								payload = bytes.Replace(payload, []byte(`some/path`), []byte(`some\/path`), 1)
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("decodes it as JSON", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+								_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedConfig).To(Equal(pipelineConfig))
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
@@ -792,6 +988,101 @@ jobs:
 
 						})
 
+						Context("when it contains vars from a named credential manager", func() {
+							BeforeEach(func() {
+								request.Header.Set("Content-Type", "application/x-yaml")
+								request.Body = ioutil.NopCloser(bytes.NewBufferString(`---
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    password: ((vault:some/path.password))
+    token: ((ssm:/prod/token))
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`))
+							})
+
+							Context("when the credential managers are configured", func() {
+								BeforeEach(func() {
+									vaultManager := new(credsfakes.FakeManager)
+									vaultManager.IsConfiguredReturns(true)
+									credsManagers["vault"] = vaultManager
+
+									ssmManager := new(credsfakes.FakeManager)
+									ssmManager.IsConfiguredReturns(true)
+									credsManagers["ssm"] = ssmManager
+								})
+
+								It("returns 200", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+
+								It("saves it un-interpolated", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+									_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+									Expect(savedConfig.Resources[0].Source).To(Equal(atc.Source{
+										"password": "((vault:some/path.password))",
+										"token":    "((ssm:/prod/token))",
+									}))
+								})
+							})
+
+							Context("when a credential manager is not configured", func() {
+								BeforeEach(func() {
+									vaultManager := new(credsfakes.FakeManager)
+									vaultManager.IsConfiguredReturns(true)
+									credsManagers["vault"] = vaultManager
+
+									credsManagers["ssm"] = new(credsfakes.FakeManager)
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns the unknown credential manager", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors":["unknown credential manager 'ssm'"]}`))
+								})
+
+								It("does not save it", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the team has its own credential manager", func() {
+								BeforeEach(func() {
+									vaultManager := new(credsfakes.FakeManager)
+									vaultManager.IsConfiguredReturns(true)
+									credsManagers["vault"] = vaultManager
+
+									ssmManager := new(credsfakes.FakeManager)
+									ssmManager.IsConfiguredReturns(true)
+									credsManagers["ssm"] = ssmManager
+
+									dbTeam.CredentialManagerReturns(&atc.TeamCredentialManager{
+										Type:   "vault",
+										Config: map[string]interface{}{"url": "https://vault.example.com"},
+									})
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns the managers the team's credentials are not looked up from", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors":["unknown credential manager 'ssm'"]}`))
+								})
+
+								It("does not save it", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
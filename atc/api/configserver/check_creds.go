@@ -0,0 +1,256 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/template"
+	"github.com/tedsuo/rata"
+)
+
+// CheckCreds resolves every var referred to by a pipeline's saved config
+// against the team's credential managers, reporting those which are missing
+// or fail to be looked up. Values are never included in the response.
+func (s *Server) CheckCreds(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("check-creds")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	config, err := pipelineConfig(logger, pipeline)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response, err := checkCreds(s.variablesFactory.NewVariables(teamName, pipelineName), config)
+	if err != nil {
+		logger.Error("failed-to-check-creds", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(response.Failures) > 0 {
+		logger.Info("config-has-invalid-creds", lager.Data{"failures": len(response.Failures)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logger.Error("failed-to-encode-response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+type credsChecker struct {
+	variables creds.Variables
+
+	// each var is only looked up once, however many places refer to it
+	failures map[string]*atc.CredentialCheckFailure
+
+	response atc.CheckCredsResponse
+}
+
+func checkCreds(variables creds.Variables, config atc.Config) (atc.CheckCredsResponse, error) {
+	checker := &credsChecker{
+		variables: variables,
+		failures:  map[string]*atc.CredentialCheckFailure{},
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		err := checker.check("resource_types."+resourceType.Name, resourceType)
+		if err != nil {
+			return atc.CheckCredsResponse{}, err
+		}
+	}
+
+	for _, resource := range config.Resources {
+		err := checker.check("resources."+resource.Name, resource)
+		if err != nil {
+			return atc.CheckCredsResponse{}, err
+		}
+	}
+
+	for _, job := range config.Jobs {
+		location := "jobs." + job.Name
+
+		err := checker.checkPlan(location+".plan", atc.PlanConfig{Do: &job.Plan})
+		if err != nil {
+			return atc.CheckCredsResponse{}, err
+		}
+
+		err = checker.checkHooks(location, atc.Hooks{
+			Abort:   job.Abort,
+			Error:   job.Error,
+			Failure: job.Failure,
+			Ensure:  job.Ensure,
+			Success: job.Success,
+		})
+		if err != nil {
+			return atc.CheckCredsResponse{}, err
+		}
+	}
+
+	return checker.response, nil
+}
+
+func (checker *credsChecker) checkPlan(location string, plan atc.PlanConfig) error {
+	switch {
+	case plan.Do != nil:
+		for i, step := range *plan.Do {
+			err := checker.checkPlan(fmt.Sprintf("%s[%d]", location, i), step)
+			if err != nil {
+				return err
+			}
+		}
+
+	case plan.Aggregate != nil:
+		for i, step := range *plan.Aggregate {
+			err := checker.checkPlan(fmt.Sprintf("%s.aggregate[%d]", location, i), step)
+			if err != nil {
+				return err
+			}
+		}
+
+	case plan.InParallel != nil:
+		for i, step := range plan.InParallel.Steps {
+			err := checker.checkPlan(fmt.Sprintf("%s.in_parallel.steps[%d]", location, i), step)
+			if err != nil {
+				return err
+			}
+		}
+
+	case plan.Try != nil:
+		err := checker.checkPlan(location+".try", *plan.Try)
+		if err != nil {
+			return err
+		}
+
+	default:
+		switch {
+		case plan.Get != "":
+			location = fmt.Sprintf("%s.get.%s", location, plan.Get)
+		case plan.Put != "":
+			location = fmt.Sprintf("%s.put.%s", location, plan.Put)
+		case plan.Task != "":
+			location = fmt.Sprintf("%s.task.%s", location, plan.Task)
+		case plan.SetPipeline != "":
+			location = fmt.Sprintf("%s.set_pipeline.%s", location, plan.SetPipeline)
+		case plan.LoadVar != "":
+			location = fmt.Sprintf("%s.load_var.%s", location, plan.LoadVar)
+		}
+
+		// hooks are checked separately so that their vars are reported
+		// against them
+		step := plan
+		step.Abort = nil
+		step.Error = nil
+		step.Failure = nil
+		step.Ensure = nil
+		step.Success = nil
+
+		err := checker.check(location, step)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checker.checkHooks(location, plan.Hooks())
+}
+
+func (checker *credsChecker) checkHooks(location string, hooks atc.Hooks) error {
+	for _, hook := range []struct {
+		name string
+		plan *atc.PlanConfig
+	}{
+		{"on_abort", hooks.Abort},
+		{"on_error", hooks.Error},
+		{"on_failure", hooks.Failure},
+		{"ensure", hooks.Ensure},
+		{"on_success", hooks.Success},
+	} {
+		if hook.plan == nil {
+			continue
+		}
+
+		err := checker.checkPlan(location+"."+hook.name, *hook.plan)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (checker *credsChecker) check(location string, config interface{}) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range template.VarRefs(payload) {
+		checker.response.Checked++
+
+		failure, checked := checker.failures[ref]
+		if !checked {
+			failure = checker.lookup(ref)
+			checker.failures[ref] = failure
+		}
+
+		if failure == nil {
+			continue
+		}
+
+		checker.response.Failures = append(checker.response.Failures, atc.CredentialCheckFailure{
+			Location: location,
+			Var:      ref,
+			Missing:  failure.Missing,
+			Error:    failure.Error,
+		})
+	}
+
+	return nil
+}
+
+func (checker *credsChecker) lookup(ref string) *atc.CredentialCheckFailure {
+	_, found, err := template.LookupVarRef(checker.variables, ref)
+	if err != nil {
+		return &atc.CredentialCheckFailure{Error: err.Error()}
+	}
+
+	if !found {
+		return &atc.CredentialCheckFailure{Missing: true}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
 
@@ -42,42 +43,47 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobs, err := pipeline.Jobs()
+	config, err := pipelineConfig(logger, pipeline)
 	if err != nil {
-		logger.Error("failed-to-get-jobs", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(atc.ConfigResponse{
+		Config: config,
+	})
+	if err != nil {
+		logger.Error("failed-to-encode-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func pipelineConfig(logger lager.Logger, pipeline db.Pipeline) (atc.Config, error) {
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		logger.Error("failed-to-get-jobs", err)
+		return atc.Config{}, err
+	}
+
 	resources, err := pipeline.Resources()
 	if err != nil {
 		logger.Error("failed-to-get-resources", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return atc.Config{}, err
 	}
 
 	resourceTypes, err := pipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resourceTypes", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return atc.Config{}, err
 	}
 
-	config := atc.Config{
+	return atc.Config{
 		Groups:        pipeline.Groups(),
 		Resources:     resources.Configs(),
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobs.Configs(),
-	}
-
-	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
-	w.Header().Set("Content-Type", "application/json")
-
-	err = json.NewEncoder(w).Encode(atc.ConfigResponse{
-		Config: config,
-	})
-	if err != nil {
-		logger.Error("failed-to-encode-config", err)
-		w.WriteHeader(http.StatusInternalServerError)
-	}
+	}, nil
 }
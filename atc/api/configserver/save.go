@@ -1,7 +1,6 @@
 package configserver
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +9,6 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
-	"strings"
 
 	"github.com/concourse/concourse/atc/exec"
 
@@ -21,7 +19,6 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/hashicorp/go-multierror"
-	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
 )
@@ -30,26 +27,10 @@ var (
 	ErrStatusUnsupportedMediaType = errors.New("content-type is not supported")
 	ErrCannotParseContentType     = errors.New("content-type header could not be parsed")
 	ErrMalformedRequestPayload    = errors.New("data in body could not be decoded")
-	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
 )
 
-type ExtraKeysError struct {
-	extraKeys []string
-}
-
-func (eke ExtraKeysError) Error() string {
-	msg := &bytes.Buffer{}
-
-	fmt.Fprintln(msg, "unknown/extra keys:")
-	for _, unusedKey := range eke.extraKeys {
-		fmt.Fprintf(msg, "  - %s\n", unusedKey)
-	}
-
-	return msg.String()
-}
-
 func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	session := s.logger.Session("set-config")
 
@@ -82,10 +63,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
 		return
-	case ErrFailedToConstructDecoder:
-		session.Error("failed-to-construct-decoder", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
 	case ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
@@ -96,7 +73,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	default:
 		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
+			if eke, ok := err.(atc.ExtraKeysError); ok {
 				s.handleBadRequest(w, []string{eke.Error()}, session)
 			} else {
 				session.Error("unexpected-error", err)
@@ -117,18 +94,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	pipelineName := rata.Param(r, "pipeline_name")
 	teamName := rata.Param(r, "team_name")
 
-	if checkCredentials {
-		variables := s.variablesFactory.NewVariables(teamName, pipelineName)
-
-		errs := validateCredParams(variables, config, session)
-		if errs != nil {
-			s.handleBadRequest(w, []string{errs.Error()}, session)
-			return
-		}
-	}
-
-	session.Info("saving")
-
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		session.Error("failed-to-find-team", err)
@@ -142,6 +107,30 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	errorMessages, err = s.credsManagers.ValidateVarSources(config, team.CredentialManager())
+	if err != nil {
+		session.Error("failed-to-validate-var-sources", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	if checkCredentials {
+		variables := s.variablesFactory.NewVariables(teamName, pipelineName)
+
+		errs := validateCredParams(variables, config, session)
+		if errs != nil {
+			s.handleBadRequest(w, []string{errs.Error()}, session)
+			return
+		}
+	}
+
+	session.Info("saving")
+
 	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
@@ -255,7 +244,7 @@ func (s *Server) writeSaveConfigResponse(w http.ResponseWriter, saveConfigRespon
 	}
 }
 
-func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}) (db.PipelinePausedState, error) {
+func requestToConfig(contentType string, requestBody io.Reader, configStructure interface{}) (db.PipelinePausedState, error) {
 	pausedState := db.PipelineNoChange
 
 	mediaType, params, err := mime.ParseMediaType(contentType)
@@ -329,38 +318,13 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 		return atc.Config{}, db.PipelineNoChange, err
 	}
 
-	var config atc.Config
-	var md mapstructure.Metadata
-	msConfig := &mapstructure.DecoderConfig{
-		Metadata:         &md,
-		Result:           &config,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			atc.SanitizeDecodeHook,
-			atc.VersionConfigDecodeHook,
-			atc.InputsConfigDecodeHook,
-			atc.ContainerLimitsDecodeHook,
-		),
-	}
-
-	decoder, err := mapstructure.NewDecoder(msConfig)
+	config, err := atc.DecodeConfig(configStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
-	}
-
-	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
-	}
-
-	nestedUnused := []string{}
-	for _, unused := range md.Unused {
-		if strings.Contains(unused, ".") {
-			nestedUnused = append(nestedUnused, unused)
+		if _, ok := err.(atc.ExtraKeysError); ok {
+			return atc.Config{}, db.PipelineNoChange, err
 		}
-	}
 
-	if len(nestedUnused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: nestedUnused}
+		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
 	return config, pausedState, nil
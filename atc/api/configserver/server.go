@@ -10,16 +10,19 @@ type Server struct {
 	logger           lager.Logger
 	teamFactory      db.TeamFactory
 	variablesFactory creds.VariablesFactory
+	credsManagers    creds.Managers
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	variablesFactory creds.VariablesFactory,
+	credsManagers creds.Managers,
 ) *Server {
 	return &Server{
 		logger:           logger,
 		teamFactory:      teamFactory,
 		variablesFactory: variablesFactory,
+		credsManagers:    credsManagers,
 	}
 }
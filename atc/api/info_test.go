@@ -13,6 +13,7 @@ import (
 	awsssm "github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credhub"
 	"github.com/concourse/concourse/atc/creds/secretsmanager"
 	"github.com/concourse/concourse/atc/creds/ssm"
@@ -469,5 +470,56 @@ var _ = Describe("Pipelines API", func() {
 			})
 
 		})
+
+		Context("when multiple managers are configured", func() {
+			var mockService MockSsmService
+
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+
+				mockService.stubGetParameter = func(input *awsssm.GetParameterInput) (*awsssm.GetParameterOutput, error) {
+					return nil, awserr.New(awsssm.ErrCodeParameterNotFound, "dontcare", nil)
+				}
+
+				credsManagers["ssm"] = &ssm.SsmManager{
+					AwsRegion:              "blah",
+					PipelineSecretTemplate: "pipeline-secret-template",
+					TeamSecretTemplate:     "team-secret-template",
+					Ssm:                    ssm.NewSsm(lager.NewLogger("ssm_test"), &mockService, "alpha", "bogus", nil),
+				}
+
+				credsManagers["broken"] = brokenManager{}
+			})
+
+			It("reports the health of each of them separately", func() {
+				var parsedResponse struct {
+					SSM struct {
+						Health creds.HealthResponse `json:"health"`
+					} `json:"ssm"`
+					Broken struct {
+						Health creds.HealthResponse `json:"health"`
+					} `json:"broken"`
+				}
+
+				err := json.Unmarshal(body, &parsedResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(parsedResponse.SSM.Health.Error).To(BeEmpty())
+				Expect(parsedResponse.SSM.Health.Method).To(Equal("GetParameter"))
+
+				Expect(parsedResponse.Broken.Health.Error).To(ContainSubstring("health check exploded"))
+			})
+		})
 	})
 })
+
+type brokenManager struct {
+	creds.Manager
+}
+
+func (brokenManager) IsConfigured() bool { return true }
+
+func (brokenManager) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("health check exploded")
+}
@@ -4,23 +4,38 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/creds"
 )
 
-// Creds returns information on the credential manager attached to this instance of concourse.
-// If no credential manager is configured the response will be empty.
+// Creds returns information on the credential managers attached to this
+// instance of concourse, including the health of each of them. If no
+// credential manager is configured the response will be empty.
 // No actual credentials are shown in the response.
 func (s *Server) Creds(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("creds")
 
 	w.Header().Set("Content-Type", "application/json")
 
-	configuredManagers := make(creds.Managers)
+	configuredManagers := map[string]json.RawMessage{}
 
 	for name, manager := range s.credsManagers {
-		if manager.IsConfigured() {
-			configuredManagers[name] = manager
+		if !manager.IsConfigured() {
+			continue
 		}
+
+		// encode each manager on its own so that one failing to report its
+		// health does not prevent the others from being reported
+		payload, err := json.Marshal(manager)
+		if err != nil {
+			logger.Error("failed-to-encode-manager", err, lager.Data{"manager": name})
+
+			payload, _ = json.Marshal(map[string]creds.HealthResponse{
+				"health": {Error: err.Error()},
+			})
+		}
+
+		configuredManagers[name] = payload
 	}
 
 	err := json.NewEncoder(w).Encode(configuredManagers)
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -1744,6 +1746,201 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/explanation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/explanation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakeJob.NameReturns("some-job")
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name:           "some-job",
+						RawMaxInFlight: 1,
+						Plan: atc.PlanSequence{
+							{
+								Get:      "some-input",
+								Resource: "some-resource",
+								Passed:   []string{"upstream"},
+								Trigger:  true,
+							},
+							{
+								Get:      "some-other-input",
+								Resource: "some-other-resource",
+							},
+						},
+					})
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					fakePipeline.LoadVersionsDBReturns(&algorithm.VersionsDB{
+						JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+						ResourceIDs: map[string]int{"some-resource": 11, "some-other-resource": 12},
+						ResourceVersions: []algorithm.ResourceVersion{
+							{VersionID: 101, ResourceID: 11, CheckOrder: 1},
+							{VersionID: 102, ResourceID: 11, CheckOrder: 2},
+							{VersionID: 201, ResourceID: 12, CheckOrder: 1},
+						},
+						BuildOutputs: []algorithm.BuildOutput{
+							{
+								ResourceVersion: algorithm.ResourceVersion{VersionID: 101, ResourceID: 11, CheckOrder: 1},
+								BuildID:         5,
+								JobID:           2,
+							},
+						},
+					}, nil)
+
+					fakePipeline.ResourceVersionStub = func(versionID int) (atc.ResourceVersion, bool, error) {
+						return atc.ResourceVersion{
+							ID:      versionID,
+							Version: atc.Version{"v": strconv.Itoa(versionID)},
+						}, true, nil
+					}
+
+					fakeJob.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+					fakeJob.GetPendingBuildsReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("explains the job without saving its input mapping", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"job": "some-job",
+						"max_in_flight": 1,
+						"running_builds": 1,
+						"max_in_flight_reached": true,
+						"pending_builds": 1,
+						"inputs_satisfied": true,
+						"would_trigger": true,
+						"inputs": [
+							{
+								"name": "some-input",
+								"resource": "some-resource",
+								"trigger": true,
+								"passed": ["upstream"],
+								"version": {"v": "101"},
+								"new": true,
+								"candidates": [
+									{"version": {"v": "102"}, "not_passed": ["upstream"]},
+									{"version": {"v": "101"}}
+								],
+								"total_candidates": 2
+							},
+							{
+								"name": "some-other-input",
+								"resource": "some-other-resource",
+								"trigger": false,
+								"version": {"v": "201"},
+								"new": true,
+								"candidates": [
+									{"version": {"v": "201"}}
+								],
+								"total_candidates": 1
+							}
+						]
+					}`))
+
+					Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+					Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+					Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
+				})
+
+				Context("when the pipeline and job are paused", func() {
+					BeforeEach(func() {
+						fakePipeline.CheckPausedReturns(true, nil)
+						fakeJob.PausedReturns(true)
+					})
+
+					It("says so", func() {
+						var explanation atc.JobExplanation
+						err := json.NewDecoder(response.Body).Decode(&explanation)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(explanation.PausedPipeline).To(BeTrue())
+						Expect(explanation.PausedJob).To(BeTrue())
+					})
+				})
+
+				Context("when no version has passed the upstream job", func() {
+					BeforeEach(func() {
+						versionsDB, _ := fakePipeline.LoadVersionsDB()
+						versionsDB.BuildOutputs = nil
+					})
+
+					It("explains that the inputs are not satisfied", func() {
+						var explanation atc.JobExplanation
+						err := json.NewDecoder(response.Body).Decode(&explanation)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(explanation.InputsSatisfied).To(BeFalse())
+						Expect(explanation.WouldTrigger).To(BeFalse())
+						Expect(explanation.Inputs[0].Version).To(BeNil())
+						Expect(explanation.Inputs[0].Candidates).To(Equal([]atc.InputCandidateExplanation{
+							{Version: atc.Version{"v": "102"}, NotPassed: []string{"upstream"}},
+							{Version: atc.Version{"v": "101"}, NotPassed: []string{"upstream"}},
+						}))
+					})
+				})
+
+				Context("when loading the versions fails", func() {
+					BeforeEach(func() {
+						fakePipeline.LoadVersionsDBReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when getting the running builds fails", func() {
+					BeforeEach(func() {
+						fakeJob.GetRunningBuildsBySerialGroupReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -0,0 +1,194 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputconfig"
+)
+
+// only the newest candidates of each input are explained, as inputs with
+// passed constraints consider every version of their resource
+const explainedCandidatesLimit = 10
+
+// ExplainJob determines the next inputs of a job the same way as the
+// scheduler, without saving them, and reports why a build would or would
+// not be scheduled.
+func (s *Server) ExplainJob(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("explain-job")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versions, err := pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		inputMapper := inputmapper.NewInputMapper(pipeline, inputconfig.NewTransformer(pipeline))
+
+		mappingExplanation, err := inputMapper.ExplainNextInputMapping(logger, versions, job, resources)
+		if err != nil {
+			logger.Error("failed-to-explain-next-input-mapping", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		explanation, err := explainJob(pipeline, job, resources, versions, mappingExplanation)
+		if err != nil {
+			logger.Error("failed-to-explain-job", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(explanation)
+		if err != nil {
+			logger.Error("failed-to-encode-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func explainJob(
+	pipeline db.Pipeline,
+	job db.Job,
+	resources db.Resources,
+	versions *algorithm.VersionsDB,
+	mappingExplanation inputmapper.Explanation,
+) (atc.JobExplanation, error) {
+	config := job.Config()
+
+	explanation := atc.JobExplanation{
+		Job:             job.Name(),
+		PausedJob:       job.Paused(),
+		MaxInFlight:     config.MaxInFlight(),
+		InputsSatisfied: mappingExplanation.Mapping != nil,
+		Inputs:          []atc.JobInputExplanation{},
+	}
+
+	pipelinePaused, err := pipeline.CheckPaused()
+	if err != nil {
+		return atc.JobExplanation{}, err
+	}
+
+	explanation.PausedPipeline = pipelinePaused
+
+	runningBuilds, err := job.GetRunningBuildsBySerialGroup(config.GetSerialGroups())
+	if err != nil {
+		return atc.JobExplanation{}, err
+	}
+
+	explanation.RunningBuilds = len(runningBuilds)
+	explanation.MaxInFlightReached = explanation.MaxInFlight != 0 && len(runningBuilds) >= explanation.MaxInFlight
+
+	pendingBuilds, err := job.GetPendingBuilds()
+	if err != nil {
+		return atc.JobExplanation{}, err
+	}
+
+	explanation.PendingBuilds = len(pendingBuilds)
+
+	jobNames := map[int]string{}
+	for name, id := range versions.JobIDs {
+		jobNames[id] = name
+	}
+
+	for _, input := range config.Inputs() {
+		inputExplanation := atc.JobInputExplanation{
+			Name:     input.Name,
+			Resource: input.Resource,
+			Trigger:  input.Trigger,
+			Passed:   input.Passed,
+		}
+
+		if input.Version != nil {
+			inputExplanation.Every = input.Version.Every
+			inputExplanation.Pinned = input.Version.Pinned
+		}
+
+		resource, found := resources.Lookup(input.Resource)
+		if found && resource.CurrentPinnedVersion() != nil {
+			inputExplanation.Pinned = resource.CurrentPinnedVersion()
+		}
+
+		inputVersion, found := mappingExplanation.Mapping[input.Name]
+		if found {
+			resourceVersion, found, err := pipeline.ResourceVersion(inputVersion.VersionID)
+			if err != nil {
+				return atc.JobExplanation{}, err
+			}
+
+			if found {
+				inputExplanation.Version = resourceVersion.Version
+			}
+
+			inputExplanation.New = inputVersion.FirstOccurrence
+
+			if input.Trigger && inputVersion.FirstOccurrence {
+				explanation.WouldTrigger = true
+			}
+		}
+
+		candidates := mappingExplanation.Inputs[input.Name].Candidates
+
+		inputExplanation.TotalCandidates = len(candidates)
+		inputExplanation.Candidates = []atc.InputCandidateExplanation{}
+
+		if len(candidates) > explainedCandidatesLimit {
+			candidates = candidates[:explainedCandidatesLimit]
+		}
+
+		for _, candidate := range candidates {
+			resourceVersion, found, err := pipeline.ResourceVersion(candidate.VersionID)
+			if err != nil {
+				return atc.JobExplanation{}, err
+			}
+
+			if !found {
+				continue
+			}
+
+			candidateExplanation := atc.InputCandidateExplanation{
+				Version: resourceVersion.Version,
+			}
+
+			for _, jobID := range candidate.NotPassed {
+				candidateExplanation.NotPassed = append(candidateExplanation.NotPassed, jobNames[jobID])
+			}
+
+			inputExplanation.Candidates = append(inputExplanation.Candidates, candidateExplanation)
+		}
+
+		explanation.Inputs = append(explanation.Inputs, inputExplanation)
+	}
+
+	return explanation, nil
+}
@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:       event.ID,
+		Time:     event.Time.Unix(),
+		UserName: event.UserName,
+		TeamName: event.TeamName,
+		Action:   event.Action,
+		Method:   event.Method,
+		Target:   event.Target,
+		Status:   event.Status,
+	}
+}
@@ -37,6 +37,14 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 		atcResource.LastChecked = resource.LastCheckEndTime().Unix()
 	}
 
+	if resource.CheckFailures() > 0 {
+		atcResource.CheckFailures = resource.CheckFailures()
+
+		if !resource.NextCheckTime().IsZero() {
+			atcResource.NextCheck = resource.NextCheckTime().Unix()
+		}
+	}
+
 	if resource.ConfigPinnedVersion() != nil {
 		atcResource.PinnedVersion = resource.ConfigPinnedVersion()
 		atcResource.PinnedInConfig = true
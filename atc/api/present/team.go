@@ -5,10 +5,20 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
+// Team presents a team. Only the type of the team's credential manager is
+// presented, as its config may contain credentials for the manager itself.
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presented := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	if credentialManager := team.CredentialManager(); credentialManager != nil {
+		presented.CredentialManager = &atc.TeamCredentialManager{
+			Type: credentialManager.Type,
+		}
+	}
+
+	return presented
 }
@@ -2,9 +2,14 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -19,6 +24,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/radar/radarfakes"
 	"github.com/concourse/concourse/atc/resource"
 )
@@ -551,12 +557,12 @@ var _ = Describe("Resources API", func() {
 	})
 
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
-		var fakeScanner *radarfakes.FakeScanner
+		var fakeScanner *radarfakes.FakeResourceScanner
 		var checkRequestBody atc.CheckRequestBody
 		var response *http.Response
 
 		BeforeEach(func() {
-			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScanner = new(radarfakes.FakeResourceScanner)
 			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
 
 			checkRequestBody = atc.CheckRequestBody{}
@@ -717,6 +723,278 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var fakeScanner *radarfakes.FakeResourceScanner
+		var checkRequestBody atc.CheckRequestBody
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeResourceScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			checkRequestBody = atc.CheckRequestBody{}
+		})
+
+		JustBeforeEach(func() {
+			reqPayload, err := json.Marshal(checkRequestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks", bytes.NewBuffer(reqPayload))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when looking up the resource fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, errors.New("nope"))
+				})
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when it finds the resource", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakeResource.IDReturns(1)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				Context("when creating the check build succeeds", func() {
+					var fakeCheckBuild *dbfakes.FakeBuild
+					var fakeLock *lockfakes.FakeLock
+
+					BeforeEach(func() {
+						fakeCheckBuild = new(dbfakes.FakeBuild)
+						fakeCheckBuild.IDReturns(42)
+						fakeCheckBuild.NameReturns("check")
+						fakeCheckBuild.ResourceNameReturns("resource-name")
+						fakeCheckBuild.PipelineNameReturns("a-pipeline")
+						fakeCheckBuild.TeamNameReturns("a-team")
+						fakeCheckBuild.StatusReturns(db.BuildStatusStarted)
+						fakeCheckBuild.StartTimeReturns(time.Unix(1, 0))
+						fakeLock = new(lockfakes.FakeLock)
+						fakeResource.CreateCheckBuildReturns(fakeCheckBuild, fakeLock, nil)
+					})
+
+					It("injects the proper pipelineDB", func() {
+						Expect(dbTeam.PipelineCallCount()).To(Equal(1))
+						pipelineName := dbTeam.PipelineArgsForCall(0)
+						Expect(pipelineName).To(Equal("a-pipeline"))
+					})
+
+					It("scans in the check build with no version specified", func() {
+						Eventually(fakeScanner.ScanFromVersionInBuildCallCount).Should(Equal(1))
+						_, actualBuild, actualResourceID, actualFromVersion := fakeScanner.ScanFromVersionInBuildArgsForCall(0)
+						Expect(actualBuild).To(Equal(fakeCheckBuild))
+						Expect(actualResourceID).To(Equal(1))
+						Expect(actualFromVersion).To(BeNil())
+					})
+
+					It("releases the check build's tracking lock once the scan is done", func() {
+						Eventually(fakeLock.ReleaseCallCount).Should(Equal(1))
+					})
+
+					It("returns 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+
+					It("returns application/json", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+
+					It("returns the check build", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 42,
+							"name": "check",
+							"resource_name": "resource-name",
+							"pipeline_name": "a-pipeline",
+							"team_name": "a-team",
+							"status": "started",
+							"start_time": 1,
+							"api_url": "/api/v1/builds/42"
+						}`))
+					})
+
+					Context("when checking with a version specified", func() {
+						BeforeEach(func() {
+							checkRequestBody = atc.CheckRequestBody{
+								From: atc.Version{
+									"some-version-key": "some-version-value",
+								},
+							}
+						})
+
+						It("scans with the version specified", func() {
+							Eventually(fakeScanner.ScanFromVersionInBuildCallCount).Should(Equal(1))
+							_, _, actualResourceID, actualFromVersion := fakeScanner.ScanFromVersionInBuildArgsForCall(0)
+							Expect(actualResourceID).To(Equal(1))
+							Expect(actualFromVersion).To(Equal(checkRequestBody.From))
+						})
+					})
+				})
+
+				Context("when creating the check build fails", func() {
+					BeforeEach(func() {
+						fakeResource.CreateCheckBuildReturns(nil, nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not scan", func() {
+						Consistently(fakeScanner.ScanFromVersionInBuildCallCount).Should(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks?since=10&limit=2")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakePipeline.NameReturns("a-pipeline")
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when it finds the resource", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				Context("when getting the check builds succeeds", func() {
+					BeforeEach(func() {
+						checkBuild := new(dbfakes.FakeBuild)
+						checkBuild.IDReturns(9)
+						checkBuild.NameReturns("check")
+						checkBuild.ResourceNameReturns("resource-name")
+						checkBuild.PipelineNameReturns("a-pipeline")
+						checkBuild.TeamNameReturns("a-team")
+						checkBuild.StatusReturns(db.BuildStatusFailed)
+						checkBuild.StartTimeReturns(time.Unix(100, 0))
+						checkBuild.EndTimeReturns(time.Unix(105, 0))
+
+						fakeResource.CheckBuildsReturns([]db.Build{checkBuild}, db.Pagination{
+							Previous: &db.Page{Until: 9, Limit: 2},
+							Next:     &db.Page{Since: 9, Limit: 2},
+						}, nil)
+					})
+
+					It("gets the requested page", func() {
+						Expect(fakeResource.CheckBuildsArgsForCall(0)).To(Equal(db.Page{Since: 10, Limit: 2}))
+					})
+
+					It("returns the check builds", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"id": 9,
+								"name": "check",
+								"resource_name": "resource-name",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"status": "failed",
+								"start_time": 100,
+								"end_time": 105,
+								"api_url": "/api/v1/builds/9"
+							}
+						]`))
+					})
+
+					It("returns Link headers per rfc5988", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks?until=9&limit=2>; rel="previous"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks?since=9&limit=2>; rel="next"`, externalURL),
+						}))
+					})
+				})
+
+				Context("when getting the check builds fails", func() {
+					BeforeEach(func() {
+						fakeResource.CheckBuildsReturns(nil, db.Pagination{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", func() {
 		var response *http.Response
 
@@ -1055,6 +1333,39 @@ var _ = Describe("Resources API", func() {
 							}`))
 					})
 				})
+				Context("when checks of the resource are being backed off", func() {
+					BeforeEach(func() {
+						resource1 := new(dbfakes.FakeResource)
+						resource1.CheckErrorReturns(errors.New("sup"))
+						resource1.CheckFailuresReturns(3)
+						resource1.NextCheckTimeReturns(time.Unix(1513365361, 0))
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.NameReturns("resource-1")
+						resource1.TypeReturns("type-1")
+						resource1.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+
+						fakePipeline.ResourceReturns(resource1, true, nil)
+					})
+
+					It("returns the resource json with when the next check is due", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"last_checked": 1513364881,
+								"failing_to_check": true,
+								"check_error": "sup",
+								"check_failures": 3,
+								"next_check": 1513365361
+							}`))
+					})
+				})
+
 				Context("when the resource version is pinned via the API", func() {
 					BeforeEach(func() {
 						resource1 := new(dbfakes.FakeResource)
@@ -1307,17 +1618,19 @@ var _ = Describe("Resources API", func() {
 
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
 		var (
-			fakeScanner               *radarfakes.FakeScanner
+			fakeScanner               *radarfakes.FakeResourceScanner
 			checkRequestBody          atc.CheckRequestBody
 			response                  *http.Response
 			fakeResource              *dbfakes.FakeResource
 			fakeResourceConfig        *dbfakes.FakeResourceConfig
 			fakeResourceConfigVersion *dbfakes.FakeResourceConfigVersion
 			fakeResourceConfigScope   *dbfakes.FakeResourceConfigScope
+			webhookPayload            []byte
+			webhookHeaders            map[string]string
 		)
 
 		BeforeEach(func() {
-			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScanner = new(radarfakes.FakeResourceScanner)
 			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
 			checkRequestBody = atc.CheckRequestBody{}
 
@@ -1327,15 +1640,24 @@ var _ = Describe("Resources API", func() {
 			fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 			fakeResourceConfigVersion = new(dbfakes.FakeResourceConfigVersion)
 			fakeResourceConfigScope = new(dbfakes.FakeResourceConfigScope)
+			webhookPayload = nil
+			webhookHeaders = map[string]string{}
 		})
 
 		JustBeforeEach(func() {
-			reqPayload, err := json.Marshal(checkRequestBody)
-			Expect(err).NotTo(HaveOccurred())
+			reqPayload := webhookPayload
+			if reqPayload == nil {
+				var err error
+				reqPayload, err = json.Marshal(checkRequestBody)
+				Expect(err).NotTo(HaveOccurred())
+			}
 
 			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
+			for name, value := range webhookHeaders {
+				request.Header.Set(name, value)
+			}
 
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
@@ -1469,6 +1791,195 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when the resource's webhook is verified", func() {
+				BeforeEach(func() {
+					variables = template.StaticVariables{
+						"webhook-token":  "fake-token",
+						"webhook-secret": "some-secret",
+					}
+					fakeVariablesFactory.NewVariablesReturns(variables)
+
+					webhookPayload = []byte(`{"ref":"refs/heads/master","after":"abc123","commits":[{"id":"abc123","distinct":true}]}`)
+
+					dbResourceConfigFactory.FindResourceConfigByIDReturns(fakeResourceConfig, true, nil)
+					fakeResourceConfig.FindResourceConfigScopeByIDReturns(fakeResourceConfigScope, true, nil)
+					fakeResourceConfigVersion.VersionReturns(db.Version{"ref": "latest"})
+					fakeResourceConfigScope.LatestVersionReturns(fakeResourceConfigVersion, true, nil)
+				})
+
+				sign := func(newHash func() hash.Hash, secret string) string {
+					mac := hmac.New(newHash, []byte(secret))
+					mac.Write(webhookPayload)
+					return hex.EncodeToString(mac.Sum(nil))
+				}
+
+				Context("by GitHub", func() {
+					BeforeEach(func() {
+						fakeResource.WebhookConfigReturns(&atc.WebhookConfig{
+							Provider: atc.WebhookProviderGitHub,
+							Secret:   "((webhook-secret))",
+						})
+					})
+
+					Context("when the payload is signed with sha256", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Hub-Signature-256"] = "sha256=" + sign(sha256.New, "some-secret")
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("scans with the latest version", func() {
+							Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+							_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+							Expect(actualFromVersion).To(Equal(atc.Version{"ref": "latest"}))
+						})
+					})
+
+					Context("when the payload is only signed with sha1", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Hub-Signature"] = "sha1=" + sign(sha1.New, "some-secret")
+						})
+
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+
+						It("does not scan", func() {
+							Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+						})
+					})
+
+					Context("when the payload is signed with the wrong secret", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Hub-Signature-256"] = "sha256=" + sign(sha256.New, "wrong-secret")
+						})
+
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+
+						It("does not scan", func() {
+							Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+						})
+					})
+
+					Context("when the payload is not signed", func() {
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+					})
+				})
+
+				Context("by Bitbucket", func() {
+					BeforeEach(func() {
+						fakeResource.WebhookConfigReturns(&atc.WebhookConfig{
+							Provider: atc.WebhookProviderBitbucket,
+							Secret:   "((webhook-secret))",
+						})
+					})
+
+					Context("when the payload is signed", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Hub-Signature"] = "sha256=" + sign(sha256.New, "some-secret")
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the payload is signed with sha1", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Hub-Signature"] = "sha1=" + sign(sha1.New, "some-secret")
+						})
+
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+					})
+				})
+
+				Context("by GitLab", func() {
+					BeforeEach(func() {
+						fakeResource.WebhookConfigReturns(&atc.WebhookConfig{
+							Provider: atc.WebhookProviderGitLab,
+							Secret:   "((webhook-secret))",
+						})
+					})
+
+					Context("when the token matches the secret", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Gitlab-Token"] = "some-secret"
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the token does not match the secret", func() {
+						BeforeEach(func() {
+							webhookHeaders["X-Gitlab-Token"] = "wrong-secret"
+						})
+
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+					})
+				})
+
+				Context("when the version is read from the payload", func() {
+					BeforeEach(func() {
+						fakeResource.WebhookConfigReturns(&atc.WebhookConfig{
+							Provider: atc.WebhookProviderGitLab,
+							Secret:   "((webhook-secret))",
+							Version: map[string]string{
+								"ref":    "/after",
+								"commit": "/commits/0/id",
+							},
+						})
+
+						webhookHeaders["X-Gitlab-Token"] = "some-secret"
+					})
+
+					It("scans from the version, leaving the check to save it", func() {
+						Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+						_, actualResourceID, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+						Expect(actualResourceID).To(Equal(10))
+						Expect(actualFromVersion).To(Equal(atc.Version{"ref": "abc123", "commit": "abc123"}))
+						Expect(fakeResourceConfigScope.LatestVersionCallCount()).To(BeZero())
+						Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(BeZero())
+					})
+
+					Context("when a field is missing from the payload", func() {
+						BeforeEach(func() {
+							webhookPayload = []byte(`{"ref":"refs/tags/v1.0.0","commits":[]}`)
+						})
+
+						It("scans from the latest version instead", func() {
+							Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+							_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+							Expect(actualFromVersion).To(Equal(atc.Version{"ref": "latest"}))
+							Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the payload is not JSON", func() {
+						BeforeEach(func() {
+							webhookPayload = []byte(`ref=abc123`)
+						})
+
+						It("scans from the latest version instead", func() {
+							Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+							_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+							Expect(actualFromVersion).To(Equal(atc.Version{"ref": "latest"}))
+						})
+					})
+				})
+			})
+
 			Context("when finding the resource fails", func() {
 				BeforeEach(func() {
 					fakePipeline.ResourceReturns(nil, false, errors.New("oops"))
@@ -2,6 +2,8 @@ package resourceserver
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -46,31 +48,49 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		go func() {
-			var fromVersion atc.Version
-			resourceConfigID := pipelineResource.ResourceConfigID()
-			resourceConfig, found, err := s.resourceConfigFactory.FindResourceConfigByID(resourceConfigID)
+		var payloadVersion atc.Version
+		if webhook := pipelineResource.WebhookConfig(); webhook != nil {
+			payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxWebhookPayloadSize))
 			if err != nil {
-				logger.Error("failed-to-get-resource-config", err, lager.Data{"resource-config-id": resourceConfigID})
+				logger.Error("failed-to-read-payload", err)
+				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 
-			if found {
-				resourceConfigScope, found, err := resourceConfig.FindResourceConfigScopeByID(pipelineResource.ResourceConfigScopeID(), pipelineResource)
+			if webhook.Provider != "" {
+				secret, err := creds.NewString(variables, webhook.Secret).Evaluate()
+				if err != nil {
+					logger.Error("failed-to-evaluate-webhook-secret", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				err = verifyWebhook(webhook.Provider, secret, r, payload)
 				if err != nil {
-					logger.Error("failed-to-get-resource-config-scope", err, lager.Data{"resource-config-scope-id": pipelineResource.ResourceConfigScopeID()})
+					logger.Info("invalid-signature", lager.Data{"provider": webhook.Provider, "error": err.Error()})
+					w.WriteHeader(http.StatusUnauthorized)
 					return
 				}
+			}
+
+			if len(webhook.Version) > 0 {
+				var found bool
+				payloadVersion, found = webhookVersion(webhook.Version, payload)
+				if !found {
+					logger.Debug("no-version-in-payload")
+				}
+			}
+		}
 
-				if found {
-					latestVersion, found, err := resourceConfigScope.LatestVersion()
-					if err != nil {
-						logger.Error("failed-to-get-latest-resource-version", err, lager.Data{"resource-config-id": resourceConfigID})
-						return
-					}
-					if found {
-						fromVersion = atc.Version(latestVersion.Version())
-					}
+		go func() {
+			// a version read from the payload is checked from, and so saved,
+			// like any other version the check finds
+			fromVersion := payloadVersion
+			if fromVersion == nil {
+				var ok bool
+				fromVersion, ok = s.latestVersion(logger, pipelineResource)
+				if !ok {
+					return
 				}
 			}
 
@@ -81,3 +101,38 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 		w.WriteHeader(http.StatusOK)
 	})
 }
+
+func (s *Server) latestVersion(logger lager.Logger, pipelineResource db.Resource) (atc.Version, bool) {
+	resourceConfigID := pipelineResource.ResourceConfigID()
+	resourceConfig, found, err := s.resourceConfigFactory.FindResourceConfigByID(resourceConfigID)
+	if err != nil {
+		logger.Error("failed-to-get-resource-config", err, lager.Data{"resource-config-id": resourceConfigID})
+		return nil, false
+	}
+
+	if !found {
+		return nil, true
+	}
+
+	resourceConfigScope, found, err := resourceConfig.FindResourceConfigScopeByID(pipelineResource.ResourceConfigScopeID(), pipelineResource)
+	if err != nil {
+		logger.Error("failed-to-get-resource-config-scope", err, lager.Data{"resource-config-scope-id": pipelineResource.ResourceConfigScopeID()})
+		return nil, false
+	}
+
+	if !found {
+		return nil, true
+	}
+
+	latestVersion, found, err := resourceConfigScope.LatestVersion()
+	if err != nil {
+		logger.Error("failed-to-get-latest-resource-version", err, lager.Data{"resource-config-id": resourceConfigID})
+		return nil, false
+	}
+
+	if !found {
+		return nil, true
+	}
+
+	return atc.Version(latestVersion.Version()), true
+}
@@ -0,0 +1,73 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// CreateResourceCheck records a check of the resource as a build and responds
+// with it straight away, so that the check's output can be streamed from the
+// build's events while it runs.
+//
+// Unlike CheckResource, it does not wait for the check to finish.
+func (s *Server) CreateResourceCheck(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("create-resource-check")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		var reqBody atc.CheckRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dbResource, found, err := dbPipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		checkBuild, trackingLock, err := dbResource.CreateCheckBuild(logger)
+		if err != nil {
+			logger.Error("failed-to-create-check-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
+
+		go func() {
+			defer trackingLock.Release()
+
+			err := scanner.ScanFromVersionInBuild(logger, checkBuild, dbResource.ID(), reqBody.From)
+			if err != nil {
+				logger.Info("check-failed", lager.Data{"build": checkBuild.ID(), "error": err.Error()})
+			}
+		}()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(present.Build(checkBuild))
+		if err != nil {
+			logger.Error("failed-to-encode-check-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-checks")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+		teamName := r.FormValue(":team_name")
+
+		until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+		since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		builds, pagination, err := resource.CheckBuilds(db.Page{
+			Since: since,
+			Until: until,
+			Limit: limit,
+		})
+		if err != nil {
+			logger.Error("failed-to-get-check-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if pagination.Next != nil {
+			s.addChecksLink(w, teamName, pipeline.Name(), resourceName, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+		}
+
+		if pagination.Previous != nil {
+			s.addChecksLink(w, teamName, pipeline.Name(), resourceName, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+		}
+
+		checks := make([]atc.Build, len(builds))
+		for i, build := range builds {
+			checks[i] = present.Build(build)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(checks)
+		if err != nil {
+			logger.Error("failed-to-encode-check-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) addChecksLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, query string, id int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/checks?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		pipelineName,
+		resourceName,
+		query,
+		id,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
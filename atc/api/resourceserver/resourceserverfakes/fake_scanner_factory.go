@@ -10,16 +10,16 @@ import (
 )
 
 type FakeScannerFactory struct {
-	NewResourceScannerStub        func(db.Pipeline) radar.Scanner
+	NewResourceScannerStub        func(db.Pipeline) radar.ResourceScanner
 	newResourceScannerMutex       sync.RWMutex
 	newResourceScannerArgsForCall []struct {
 		arg1 db.Pipeline
 	}
 	newResourceScannerReturns struct {
-		result1 radar.Scanner
+		result1 radar.ResourceScanner
 	}
 	newResourceScannerReturnsOnCall map[int]struct {
-		result1 radar.Scanner
+		result1 radar.ResourceScanner
 	}
 	NewResourceTypeScannerStub        func(db.Pipeline) radar.Scanner
 	newResourceTypeScannerMutex       sync.RWMutex
@@ -36,7 +36,7 @@ type FakeScannerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeScannerFactory) NewResourceScanner(arg1 db.Pipeline) radar.Scanner {
+func (fake *FakeScannerFactory) NewResourceScanner(arg1 db.Pipeline) radar.ResourceScanner {
 	fake.newResourceScannerMutex.Lock()
 	ret, specificReturn := fake.newResourceScannerReturnsOnCall[len(fake.newResourceScannerArgsForCall)]
 	fake.newResourceScannerArgsForCall = append(fake.newResourceScannerArgsForCall, struct {
@@ -60,7 +60,7 @@ func (fake *FakeScannerFactory) NewResourceScannerCallCount() int {
 	return len(fake.newResourceScannerArgsForCall)
 }
 
-func (fake *FakeScannerFactory) NewResourceScannerCalls(stub func(db.Pipeline) radar.Scanner) {
+func (fake *FakeScannerFactory) NewResourceScannerCalls(stub func(db.Pipeline) radar.ResourceScanner) {
 	fake.newResourceScannerMutex.Lock()
 	defer fake.newResourceScannerMutex.Unlock()
 	fake.NewResourceScannerStub = stub
@@ -73,26 +73,26 @@ func (fake *FakeScannerFactory) NewResourceScannerArgsForCall(i int) db.Pipeline
 	return argsForCall.arg1
 }
 
-func (fake *FakeScannerFactory) NewResourceScannerReturns(result1 radar.Scanner) {
+func (fake *FakeScannerFactory) NewResourceScannerReturns(result1 radar.ResourceScanner) {
 	fake.newResourceScannerMutex.Lock()
 	defer fake.newResourceScannerMutex.Unlock()
 	fake.NewResourceScannerStub = nil
 	fake.newResourceScannerReturns = struct {
-		result1 radar.Scanner
+		result1 radar.ResourceScanner
 	}{result1}
 }
 
-func (fake *FakeScannerFactory) NewResourceScannerReturnsOnCall(i int, result1 radar.Scanner) {
+func (fake *FakeScannerFactory) NewResourceScannerReturnsOnCall(i int, result1 radar.ResourceScanner) {
 	fake.newResourceScannerMutex.Lock()
 	defer fake.newResourceScannerMutex.Unlock()
 	fake.NewResourceScannerStub = nil
 	if fake.newResourceScannerReturnsOnCall == nil {
 		fake.newResourceScannerReturnsOnCall = make(map[int]struct {
-			result1 radar.Scanner
+			result1 radar.ResourceScanner
 		})
 	}
 	fake.newResourceScannerReturnsOnCall[i] = struct {
-		result1 radar.Scanner
+		result1 radar.ResourceScanner
 	}{result1}
 }
 
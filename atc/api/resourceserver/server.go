@@ -10,7 +10,7 @@ import (
 //go:generate counterfeiter . ScannerFactory
 
 type ScannerFactory interface {
-	NewResourceScanner(pipeline db.Pipeline) radar.Scanner
+	NewResourceScanner(pipeline db.Pipeline) radar.ResourceScanner
 	NewResourceTypeScanner(dbPipeline db.Pipeline) radar.Scanner
 }
 
@@ -20,6 +20,7 @@ type Server struct {
 	variablesFactory      creds.VariablesFactory
 	resourceFactory       db.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	externalURL           string
 }
 
 func NewServer(
@@ -28,6 +29,7 @@ func NewServer(
 	variablesFactory creds.VariablesFactory,
 	resourceFactory db.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	externalURL string,
 ) *Server {
 	return &Server{
 		logger:                logger,
@@ -35,5 +37,6 @@ func NewServer(
 		variablesFactory:      variablesFactory,
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		externalURL:           externalURL,
 	}
 }
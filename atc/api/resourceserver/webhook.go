@@ -0,0 +1,156 @@
+package resourceserver
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// GitHub caps its payloads at 25MB, which is as large as any provider sends.
+const maxWebhookPayloadSize = 25 * 1024 * 1024
+
+var errWebhookSignatureMissing = errors.New("request is not signed")
+var errWebhookSignatureMismatch = errors.New("signature does not match payload")
+
+// verifyWebhook checks that a request was sent by the resource's webhook
+// provider using the given secret.
+func verifyWebhook(provider string, secret string, r *http.Request, payload []byte) error {
+	switch provider {
+	case atc.WebhookProviderGitHub:
+		// GitHub also sends a SHA-1 signature in X-Hub-Signature, which is
+		// ignored so that requests can't be verified by the weaker hash alone
+		signature := r.Header.Get("X-Hub-Signature-256")
+		if signature == "" {
+			return errWebhookSignatureMissing
+		}
+
+		return verifyHMAC(sha256.New, "sha256=", secret, signature, payload)
+
+	case atc.WebhookProviderBitbucket:
+		signature := r.Header.Get("X-Hub-Signature")
+		if signature == "" {
+			return errWebhookSignatureMissing
+		}
+
+		return verifyHMAC(sha256.New, "sha256=", secret, signature, payload)
+
+	case atc.WebhookProviderGitLab:
+		token := r.Header.Get("X-Gitlab-Token")
+		if token == "" {
+			return errWebhookSignatureMissing
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return errWebhookSignatureMismatch
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown webhook provider '%s'", provider)
+	}
+}
+
+func verifyHMAC(newHash func() hash.Hash, prefix string, secret string, signature string, payload []byte) error {
+	if !strings.HasPrefix(signature, prefix) {
+		return errWebhookSignatureMismatch
+	}
+
+	given, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return errWebhookSignatureMismatch
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+
+	if !hmac.Equal(given, mac.Sum(nil)) {
+		return errWebhookSignatureMismatch
+	}
+
+	return nil
+}
+
+// webhookVersion reads a version out of a payload using the JSON pointers
+// configured for each of its fields. The version is only returned if every
+// field is present, so that payloads for other events, e.g. a tag being
+// pushed rather than a branch, are ignored.
+func webhookVersion(pointers map[string]string, payload []byte) (atc.Version, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var document interface{}
+	err := decoder.Decode(&document)
+	if err != nil {
+		return nil, false
+	}
+
+	version := atc.Version{}
+	for field, pointer := range pointers {
+		value, found := resolveJSONPointer(document, pointer)
+		if !found {
+			return nil, false
+		}
+
+		version[field] = value
+	}
+
+	return version, true
+}
+
+// resolveJSONPointer follows an RFC 6901 pointer, returning the scalar value
+// it refers to as a string.
+func resolveJSONPointer(document interface{}, pointer string) (string, bool) {
+	if !strings.HasPrefix(pointer, "/") {
+		return "", false
+	}
+
+	value := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.Replace(token, "~1", "/", -1)
+		token = strings.Replace(token, "~0", "~", -1)
+
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, found := node[token]
+			if !found {
+				return "", false
+			}
+
+			value = child
+
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+
+			value = node[index]
+
+		default:
+			return "", false
+		}
+	}
+
+	switch scalar := value.(type) {
+	case string:
+		return scalar, true
+	case json.Number:
+		return scalar.String(), true
+	case bool:
+		return strconv.FormatBool(scalar), true
+	default:
+		return "", false
+	}
+}
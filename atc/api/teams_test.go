@@ -11,6 +11,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	_ "github.com/concourse/concourse/atc/creds/vault"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
@@ -72,6 +73,10 @@ var _ = Describe("Teams API", func() {
 					"groups": []string{}, "users": []string{"local:username"},
 				},
 			})
+			fakeTeamOne.CredentialManagerReturns(&atc.TeamCredentialManager{
+				Type:   "vault",
+				Config: map[string]interface{}{"client-token": "some-token"},
+			})
 
 			fakeTeamTwo.IDReturns(9)
 			fakeTeamTwo.NameReturns(teamNames[1])
@@ -106,7 +111,8 @@ var _ = Describe("Teams API", func() {
  					{
  						"id": 5,
  						"name": "avengers",
-						"auth": { "owner":{"users":["local:username"],"groups":[]}}
+						"auth": { "owner":{"users":["local:username"],"groups":[]}},
+						"credential_manager": {"type": "vault"}
  					},
  					{
  						"id": 9,
@@ -141,7 +147,8 @@ var _ = Describe("Teams API", func() {
  					{
  						"id": 5,
  						"name": "avengers",
-						"auth": { "owner":{"users":["local:username"],"groups":[]}}
+						"auth": { "owner":{"users":["local:username"],"groups":[]}},
+						"credential_manager": {"type": "vault"}
  					},
  					{
  						"id": 22,
@@ -220,6 +227,121 @@ var _ = Describe("Teams API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				It("leaves the credential manager as-is", func() {
+					Expect(fakeTeam.UpdateCredentialManagerCallCount()).To(BeZero())
+				})
+
+				Context("when the credential manager is to be removed", func() {
+					BeforeEach(func() {
+						atcTeam.RemoveCredentialManager = true
+					})
+
+					It("removes the credential manager", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateCredentialManagerCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateCredentialManagerArgsForCall(0)).To(BeNil())
+					})
+				})
+
+				Context("when the team has a credential manager", func() {
+					BeforeEach(func() {
+						atcTeam.CredentialManager = &atc.TeamCredentialManager{
+							Type: "vault",
+							Config: map[string]interface{}{
+								"url":          "https://vault.example.com",
+								"client-token": "some-token",
+							},
+						}
+					})
+
+					It("updates the credential manager", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateCredentialManagerCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateCredentialManagerArgsForCall(0)).To(Equal(atcTeam.CredentialManager))
+					})
+
+					Context("when the credential manager is also to be removed", func() {
+						BeforeEach(func() {
+							atcTeam.RemoveCredentialManager = true
+						})
+
+						It("returns 400 Bad Request without updating the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(Equal("invalid credential manager: cannot both configure and remove it"))
+
+							Expect(fakeTeam.UpdateCredentialManagerCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the credential manager is misconfigured", func() {
+						BeforeEach(func() {
+							delete(atcTeam.CredentialManager.Config, "client-token")
+						})
+
+						It("returns 400 Bad Request without updating the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(HavePrefix("invalid credential manager: "))
+
+							Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+							Expect(fakeTeam.UpdateCredentialManagerCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the credential manager reads files on the web node", func() {
+						BeforeEach(func() {
+							atcTeam.CredentialManager.Config["ca-cert"] = "/etc/concourse/web-key"
+						})
+
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(Equal("invalid credential manager: option 'ca-cert' of credential manager 'vault' cannot be configured by teams"))
+						})
+					})
+
+					Context("when the credential manager type cannot be configured by teams", func() {
+						BeforeEach(func() {
+							atcTeam.CredentialManager.Type = "secrets-dir"
+						})
+
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the credential manager type is unknown", func() {
+						BeforeEach(func() {
+							atcTeam.CredentialManager.Type = "bogus"
+						})
+
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(Equal("invalid credential manager: unknown credential manager type 'bogus'"))
+						})
+					})
+
+					Context("when updating the credential manager fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateCredentialManagerReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
 			})
 		}
 
@@ -2,6 +2,7 @@ package teamserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -9,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/creds"
 )
 
 func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
@@ -34,6 +36,23 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if atcTeam.CredentialManager != nil && atcTeam.RemoveCredentialManager {
+		hLog.Info("conflicting-credential-manager")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid credential manager: cannot both configure and remove it")
+		return
+	}
+
+	if atcTeam.CredentialManager != nil {
+		err = validateCredentialManager(*atcTeam.CredentialManager)
+		if err != nil {
+			hLog.Info("invalid-credential-manager", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid credential manager: %s", err)
+			return
+		}
+	}
+
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		hLog.Error("failed-to-lookup-team", err, lager.Data{"teamName": teamName})
@@ -50,6 +69,15 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if atcTeam.CredentialManager != nil || atcTeam.RemoveCredentialManager {
+			err = team.UpdateCredentialManager(atcTeam.CredentialManager)
+			if err != nil {
+				hLog.Error("failed-to-update-credential-manager", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
@@ -74,3 +102,12 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+func validateCredentialManager(credentialManager atc.TeamCredentialManager) error {
+	manager, err := creds.ConfigureManager(credentialManager)
+	if err != nil {
+		return err
+	}
+
+	return manager.Validate()
+}
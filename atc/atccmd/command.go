@@ -1,11 +1,13 @@
 package atccmd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -23,6 +25,7 @@ import (
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/noop"
@@ -31,6 +34,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/eventstore"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lockrunner"
@@ -40,6 +44,7 @@ import (
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/syslog"
+	"github.com/concourse/concourse/atc/tracing"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
 	"github.com/concourse/concourse/atc/wrappa"
@@ -52,10 +57,14 @@ import (
 	"github.com/cppforlife/go-semi-semantic/version"
 	multierror "github.com/hashicorp/go-multierror"
 	flags "github.com/jessevdk/go-flags"
+	uuid "github.com/nu7hatch/gouuid"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/ifrit/sigmon"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/time/rate"
+	"gopkg.in/yaml.v2"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/concourse/atc/metric/emitter"
@@ -63,6 +72,7 @@ import (
 	// dynamically registered credential managers
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
+	_ "github.com/concourse/concourse/atc/creds/secretsdir"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
 	_ "github.com/concourse/concourse/atc/creds/vault"
@@ -90,6 +100,8 @@ type RunCommand struct {
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
+	Auditor auditor.AuditConfig `group:"Audit Logging"`
+
 	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
@@ -103,15 +115,23 @@ type RunCommand struct {
 
 	EnableGlobalResources bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
 
+	EnableRedactSecrets bool `long:"enable-redact-secrets" description:"Redact credentials resolved for a build from its logs, replacing them with ((redacted))."`
+
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
+	MaxChecksPerSecond           float64       `long:"max-checks-per-second" description:"Maximum number of periodic checks that may be started per second across the cluster, shared between the ATCs which are checking resources. If not specified, this is derived from the number of resources and resource types across the cluster divided by the resource checking interval. A negative value removes the limit."`
+	ResourceCheckJitter          time.Duration `long:"resource-check-jitter" default:"10s" description:"Maximum random delay added to each resource's checking interval, spreading out checks which would otherwise happen at the same time."`
+	ResourceCheckMaxBackoff      time.Duration `long:"resource-check-max-backoff" default:"1h" description:"Maximum interval between checks of a resource which keeps failing to check. The interval doubles with each consecutive failure until this is reached. Set to 0 to disable backoff."`
 
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" description:"Method by which a worker is selected during container placement."`
+	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement."`
+	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ConfigRBAC flag.File `long:"config-rbac" description:"Customize RBAC role-action mapping."`
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
 	} `group:"Developer Options"`
@@ -128,6 +148,10 @@ type RunCommand struct {
 		CaptureErrorMetrics bool              `long:"capture-error-metrics" description:"Enable capturing of error log metrics"`
 	} `group:"Metrics & Diagnostics"`
 
+	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
+
+	BuildEvents eventstore.Config `group:"Build Event Storage" namespace:"build-events"`
+
 	Server struct {
 		XFrameOptions string `long:"x-frame-options" description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
 	} `group:"Web Server"`
@@ -345,6 +369,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnableGlobalResources = cmd.EnableGlobalResources
 
 	radar.GlobalResourceCheckTimeout = cmd.GlobalResourceCheckTimeout
+	radar.ResourceCheckMaxBackoff = cmd.ResourceCheckMaxBackoff
 	//FIXME: These only need to run once for the entire binary. At the moment,
 	//they rely on state of the command.
 	db.SetupConnectionRetryingDriver(
@@ -369,6 +394,11 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	traceProvider, err := cmd.configureTracing()
+	if err != nil {
+		return nil, err
+	}
+
 	lockConn, err := cmd.constructLockConn(retryingDriverName)
 	if err != nil {
 		return nil, err
@@ -396,6 +426,8 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	variablesFactory = cmd.teamVariablesFactory(logger, variablesFactory, db.NewTeamFactory(backendConn, lockFactory))
+
 	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, storage, lockFactory, variablesFactory)
 	if err != nil {
 		return nil, err
@@ -426,6 +458,18 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		for _, closer := range []Closer{lockConn, apiConn, backendConn, storage} {
 			closer.Close()
 		}
+
+		for name, manager := range cmd.CredentialManagers {
+			manager.Close(logger.Session("credential-manager", lager.Data{"name": name}))
+		}
+
+		if traceProvider != nil {
+			// flush any spans which are still buffered
+			err := traceProvider.Shutdown(context.Background())
+			if err != nil {
+				logger.Error("failed-to-shut-down-tracing", err)
+			}
+		}
 	}
 
 	return run(grouper.NewParallel(os.Interrupt, members), onReady, onExit), nil
@@ -559,8 +603,21 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
+	dbAuditRepository := db.NewAuditRepository(dbConn)
 	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey())
 
+	buildEventStore, err := cmd.constructBuildEventStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.ConfigRBAC != "" {
+		err = cmd.configureRBAC(logger, accessFactory)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
 		reconfigurableSink,
@@ -580,6 +637,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		variablesFactory,
 		credsManagers,
 		accessFactory,
+		dbAuditRepository,
+		buildEventStore,
 	)
 
 	if err != nil {
@@ -736,6 +795,21 @@ func (cmd *RunCommand) constructBackendMembers(
 		defaultLimits,
 		buildContainerStrategy,
 		resourceFactory,
+		teamFactory,
+	)
+
+	atcID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	checkRateLimiter := radar.NewCheckRateLimiter(
+		atcID.String(),
+		rate.Limit(cmd.MaxChecksPerSecond),
+		cmd.ResourceCheckingInterval,
+		db.NewResourceFactory(dbConn, lockFactory),
+		time.Minute,
+		clock.NewClock(),
 	)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
@@ -746,6 +820,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		cmd.ResourceCheckingInterval,
 		engine,
 		checkContainerStrategy,
+		checkRateLimiter,
+		cmd.ResourceCheckJitter,
 	)
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn)
@@ -859,6 +935,31 @@ func (cmd *RunCommand) constructBackendMembers(
 			)},
 		)
 	}
+
+	if cmd.BuildEvents.IsConfigured() {
+		buildEventStore, err := cmd.constructBuildEventStore()
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, grouper.Member{
+			Name: "build-event-archiver", Runner: lockrunner.NewRunner(
+				logger.Session("build-event-archiver"),
+				eventstore.NewArchiver(
+					dbBuildFactory,
+					buildEventStore,
+					cmd.BuildEvents.ArchiveBatchSize,
+					cmd.BuildEvents.ArchiveGracePeriod,
+					syslogDrainConfigured,
+				),
+				"build-event-archiver",
+				lockFactory,
+				clock.NewClock(),
+				cmd.BuildEvents.ArchiveInterval,
+			)},
+		)
+	}
+
 	if cmd.Worker.GardenURL.URL != nil {
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
@@ -870,11 +971,22 @@ func workerVersion() (version.Version, error) {
 }
 
 func (cmd *RunCommand) variablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
-	var variablesFactory creds.VariablesFactory = noop.NewNoopFactory()
-	for name, manager := range cmd.CredentialManagers {
-		if !manager.IsConfigured() {
-			continue
-		}
+	lookupOrder, err := cmd.CredentialManagers.LookupOrder(logger, cmd.CredentialManagement.LookupOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lookupOrder) == 0 {
+		return creds.NewNamedVariablesFactory(
+			creds.NewRetryableVariablesFactory(noop.NewNoopFactory(), cmd.CredentialManagement.RetryConfig),
+			map[string]creds.VariablesFactory{},
+		), nil
+	}
+
+	factories := []creds.VariablesFactory{}
+	named := map[string]creds.VariablesFactory{}
+	for _, name := range lookupOrder {
+		manager := cmd.CredentialManagers[name]
 
 		credsLogger := logger.Session("credential-manager", lager.Data{
 			"name": name,
@@ -892,14 +1004,74 @@ func (cmd *RunCommand) variablesFactory(logger lager.Logger) (creds.VariablesFac
 			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
 		}
 
-		variablesFactory, err = manager.NewVariablesFactory(credsLogger)
+		variablesFactory, err := cmd.managerVariablesFactory(credsLogger, manager)
 		if err != nil {
 			return nil, err
 		}
 
-		break
+		factories = append(factories, variablesFactory)
+		named[name] = variablesFactory
 	}
-	return creds.NewRetryableVariablesFactory(variablesFactory, cmd.CredentialManagement.RetryConfig), nil
+
+	if len(factories) == 1 {
+		return creds.NewNamedVariablesFactory(factories[0], named), nil
+	}
+
+	return creds.NewNamedVariablesFactory(creds.NewChainedVariablesFactory(factories), named), nil
+}
+
+// managerVariablesFactory constructs the VariablesFactory for a configured
+// credential manager, with retries and, if enabled, caching.
+func (cmd *RunCommand) managerVariablesFactory(logger lager.Logger, manager creds.Manager) (creds.VariablesFactory, error) {
+	variablesFactory, err := manager.NewVariablesFactory(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	variablesFactory = creds.NewRetryableVariablesFactory(variablesFactory, cmd.CredentialManagement.RetryConfig)
+
+	if cmd.CredentialManagement.CacheConfig.Enabled {
+		variablesFactory = creds.NewCachedVariablesFactory(
+			variablesFactory,
+			cmd.CredentialManagement.CacheConfig,
+			clock.NewClock(),
+			&metric.CredentialCacheHits,
+			&metric.CredentialCacheMisses,
+		)
+	}
+
+	return variablesFactory, nil
+}
+
+// teamVariablesFactory looks up the credentials of teams with their own
+// credential manager from that manager, and all others from the given
+// factory.
+func (cmd *RunCommand) teamVariablesFactory(logger lager.Logger, variablesFactory creds.VariablesFactory, teamFactory db.TeamFactory) creds.VariablesFactory {
+	return creds.NewTeamVariablesFactory(
+		logger.Session("team-credential-managers"),
+		variablesFactory,
+		teamCredentialManagers{teamFactory: teamFactory},
+		cmd.managerVariablesFactory,
+		clock.NewClock(),
+		cmd.CredentialManagement.TeamManagerCacheDuration,
+	)
+}
+
+type teamCredentialManagers struct {
+	teamFactory db.TeamFactory
+}
+
+func (managers teamCredentialManagers) TeamCredentialManager(teamName string) (*atc.TeamCredentialManager, error) {
+	team, found, err := managers.teamFactory.FindTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return team.CredentialManager(), nil
 }
 
 func (cmd *RunCommand) newKey() *encryption.Key {
@@ -1085,6 +1257,24 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	if cmd.MaxActiveTasksPerWorker < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--max-active-tasks-per-worker must not be negative"),
+		)
+	}
+
+	if err := cmd.Auditor.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if cmd.BuildEvents.ArchiveBatchSize < 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--build-events-archive-batch-size must be at least 1"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1109,6 +1299,29 @@ func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes)
 }
 
+func (cmd *RunCommand) configureTracing() (*sdktrace.TracerProvider, error) {
+	if !cmd.Tracing.IsConfigured() {
+		return nil, nil
+	}
+
+	traceProvider, err := cmd.Tracing.TraceProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	tracing.ConfigureTraceProvider(traceProvider)
+
+	return traceProvider, nil
+}
+
+func (cmd *RunCommand) constructBuildEventStore() (eventstore.Store, error) {
+	if !cmd.BuildEvents.IsConfigured() {
+		return nil, nil
+	}
+
+	return cmd.BuildEvents.NewStore()
+}
+
 func (cmd *RunCommand) constructDBConn(
 	driverName string,
 	logger lager.Logger,
@@ -1160,6 +1373,8 @@ func (cmd *RunCommand) chooseBuildContainerStrategy() worker.ContainerPlacementS
 		strategy = worker.NewRandomPlacementStrategy()
 	case "fewest-build-containers":
 		strategy = worker.NewFewestBuildContainersPlacementStrategy()
+	case "limit-active-tasks":
+		strategy = worker.NewLimitActiveTasksPlacementStrategy(cmd.MaxActiveTasksPerWorker)
 	default:
 		strategy = worker.NewVolumeLocalityPlacementStrategy()
 	}
@@ -1200,6 +1415,7 @@ func (cmd *RunCommand) constructEngine(
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
+	teamFactory db.TeamFactory,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerPool,
@@ -1211,11 +1427,13 @@ func (cmd *RunCommand) constructEngine(
 		defaultLimits,
 		strategy,
 		resourceFactory,
+		teamFactory,
+		cmd.CredentialManagers,
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(cmd.EnableRedactSecrets),
 		cmd.ExternalURL.String(),
 	)
 
@@ -1255,6 +1473,21 @@ func (cmd *RunCommand) constructHTTPHandler(
 	return httpHandler
 }
 
+func (cmd *RunCommand) configureRBAC(logger lager.Logger, accessFactory accessor.AccessFactory) error {
+	content, err := ioutil.ReadFile(string(cmd.ConfigRBAC))
+	if err != nil {
+		return fmt.Errorf("failed to read rbac config: %s", err)
+	}
+
+	var mapping accessor.CustomActionRoleMap
+	err = yaml.Unmarshal(content, &mapping)
+	if err != nil {
+		return fmt.Errorf("failed to parse rbac config: %s", err)
+	}
+
+	return accessFactory.CustomizeActionRoleMap(logger, mapping)
+}
+
 func (cmd *RunCommand) constructAPIHandler(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
@@ -1274,6 +1507,8 @@ func (cmd *RunCommand) constructAPIHandler(
 	variablesFactory creds.VariablesFactory,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
+	dbAuditRepository db.AuditRepository,
+	buildEventStore eventstore.Store,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1281,6 +1516,13 @@ func (cmd *RunCommand) constructAPIHandler(
 	checkBuildWriteAccessHandlerFactory := auth.NewCheckBuildWriteAccessHandlerFactory(dbBuildFactory)
 	checkWorkerTeamAccessHandlerFactory := auth.NewCheckWorkerTeamAccessHandlerFactory(dbWorkerFactory)
 
+	var auditRepository db.AuditRepository
+	if cmd.Auditor.EnableDBAuditLog {
+		auditRepository = dbAuditRepository
+	}
+
+	aud := auditor.NewAuditor(logger.Session("auditor"), cmd.Auditor, auditRepository)
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
 		wrappa.NewAPIAuthWrappa(
@@ -1289,8 +1531,10 @@ func (cmd *RunCommand) constructAPIHandler(
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
 		),
+		wrappa.NewAPIAuditWrappa(aud),
 		wrappa.NewConcourseVersionWrappa(concourse.Version),
 		wrappa.NewAccessorWrappa(accessFactory),
+		wrappa.NewAPITracingWrappa(),
 	}
 
 	return api.NewHandler(
@@ -1308,8 +1552,9 @@ func (cmd *RunCommand) constructAPIHandler(
 		gcContainerDestroyer,
 		dbBuildFactory,
 		resourceConfigFactory,
+		dbAuditRepository,
 
-		buildserver.NewEventHandler,
+		buildserver.NewEventHandlerFactory(eventstore.NewBuildEvents(buildEventStore)),
 		drain,
 
 		workerClient,
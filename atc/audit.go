@@ -0,0 +1,14 @@
+package atc
+
+// AuditEvent is a record of a mutating API request: who made it, what it
+// acted upon, and how it went.
+type AuditEvent struct {
+	ID       int    `json:"id"`
+	Time     int64  `json:"time"`
+	UserName string `json:"user_name,omitempty"`
+	TeamName string `json:"team_name,omitempty"`
+	Action   string `json:"action"`
+	Method   string `json:"method"`
+	Target   string `json:"target"`
+	Status   int    `json:"status"`
+}
@@ -0,0 +1,194 @@
+package auditor
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type AuditConfig struct {
+	EnableBuildAuditLog     bool `long:"enable-build-auditing"     description:"Enable auditing for all api requests connected to builds."`
+	EnableContainerAuditLog bool `long:"enable-container-auditing" description:"Enable auditing for all api requests connected to containers."`
+	EnableJobAuditLog       bool `long:"enable-job-auditing"       description:"Enable auditing for all api requests connected to jobs."`
+	EnablePipelineAuditLog  bool `long:"enable-pipeline-auditing"  description:"Enable auditing for all api requests connected to pipelines."`
+	EnableResourceAuditLog  bool `long:"enable-resource-auditing"  description:"Enable auditing for all api requests connected to resources."`
+	EnableSystemAuditLog    bool `long:"enable-system-auditing"    description:"Enable auditing for all api requests connected to system transactions."`
+	EnableTeamAuditLog      bool `long:"enable-team-auditing"      description:"Enable auditing for all api requests connected to teams."`
+	EnableWorkerAuditLog    bool `long:"enable-worker-auditing"    description:"Enable auditing for all api requests connected to workers."`
+	EnableVolumeAuditLog    bool `long:"enable-volume-auditing"    description:"Enable auditing for all api requests connected to volumes."`
+
+	EnableActions  []string `long:"enable-audit-action"  description:"Enable auditing for an individual api request, by its route name, e.g. SaveConfig. Can be specified multiple times."`
+	DisableActions []string `long:"disable-audit-action" description:"Disable auditing for an individual api request enabled by its group, by its route name. Can be specified multiple times."`
+
+	EnableDBAuditLog bool `long:"enable-db-auditing" description:"Also record audited requests in the database, to be queried via the API or 'fly audit'."`
+}
+
+// Validate checks that the individually enabled and disabled actions are the
+// names of api routes.
+func (config AuditConfig) Validate() error {
+	routes := map[string]bool{}
+	for _, route := range atc.Routes {
+		routes[route.Name] = true
+	}
+
+	for _, action := range append(config.EnableActions, config.DisableActions...) {
+		if !routes[action] {
+			return fmt.Errorf("unknown audit action '%s'", action)
+		}
+	}
+
+	return nil
+}
+
+//go:generate counterfeiter . Auditor
+
+type Auditor interface {
+	IsEnabled(action string) bool
+	Audit(db.AuditEvent)
+}
+
+// NewAuditor constructs an Auditor which logs the events for any enabled
+// actions. Actions are enabled by their group, or individually, unless they
+// are individually disabled. If the repository is non-nil, the events are recorded there too.
+func NewAuditor(logger lager.Logger, config AuditConfig, repository db.AuditRepository) Auditor {
+	enabled := map[string]bool{}
+
+	for _, group := range []struct {
+		enabled bool
+		actions []string
+	}{
+		{config.EnableBuildAuditLog, buildActions},
+		{config.EnableContainerAuditLog, containerActions},
+		{config.EnableJobAuditLog, jobActions},
+		{config.EnablePipelineAuditLog, pipelineActions},
+		{config.EnableResourceAuditLog, resourceActions},
+		{config.EnableSystemAuditLog, systemActions},
+		{config.EnableTeamAuditLog, teamActions},
+		{config.EnableWorkerAuditLog, workerActions},
+		{config.EnableVolumeAuditLog, volumeActions},
+	} {
+		if !group.enabled {
+			continue
+		}
+
+		for _, action := range group.actions {
+			enabled[action] = true
+		}
+	}
+
+	for _, action := range config.EnableActions {
+		enabled[action] = true
+	}
+
+	for _, action := range config.DisableActions {
+		delete(enabled, action)
+	}
+
+	return &auditor{
+		logger:     logger,
+		enabled:    enabled,
+		repository: repository,
+	}
+}
+
+type auditor struct {
+	logger     lager.Logger
+	enabled    map[string]bool
+	repository db.AuditRepository
+}
+
+func (a *auditor) IsEnabled(action string) bool {
+	return a.enabled[action]
+}
+
+func (a *auditor) Audit(event db.AuditEvent) {
+	if !a.enabled[event.Action] {
+		return
+	}
+
+	a.logger.Info("audit", lager.Data{
+		"action": event.Action,
+		"user":   event.UserName,
+		"team":   event.TeamName,
+		"method": event.Method,
+		"target": event.Target,
+		"status": event.Status,
+	})
+
+	if a.repository == nil {
+		return
+	}
+
+	err := a.repository.Record(event)
+	if err != nil {
+		a.logger.Error("failed-to-record-audit-event", err, lager.Data{
+			"action": event.Action,
+		})
+	}
+}
+
+var buildActions = []string{
+	atc.CreateBuild,
+	atc.AbortBuild,
+	atc.CreateJobBuild,
+	atc.CreatePipelineBuild,
+	atc.CreateArtifact,
+}
+
+var containerActions = []string{
+	atc.ReportWorkerContainers,
+}
+
+var jobActions = []string{
+	atc.PauseJob,
+	atc.UnpauseJob,
+	atc.ClearTaskCache,
+}
+
+var pipelineActions = []string{
+	atc.SaveConfig,
+	atc.DeletePipeline,
+	atc.OrderPipelines,
+	atc.PausePipeline,
+	atc.UnpausePipeline,
+	atc.ExposePipeline,
+	atc.HidePipeline,
+	atc.RenamePipeline,
+}
+
+var resourceActions = []string{
+	atc.CheckResource,
+	atc.CheckResourceWebHook,
+	atc.CheckResourceType,
+	atc.CreateResourceCheck,
+	atc.EnableResourceVersion,
+	atc.DisableResourceVersion,
+	atc.PinResourceVersion,
+	atc.UnpinResource,
+	atc.SetPinCommentOnResource,
+}
+
+var systemActions = []string{
+	atc.SetLogLevel,
+}
+
+var teamActions = []string{
+	atc.SetTeam,
+	atc.RenameTeam,
+	atc.DestroyTeam,
+}
+
+var workerActions = []string{
+	atc.RegisterWorker,
+	atc.LandWorker,
+	atc.RetireWorker,
+	atc.PruneWorker,
+	atc.HeartbeatWorker,
+	atc.DeleteWorker,
+}
+
+var volumeActions = []string{
+	atc.ReportWorkerVolumes,
+}
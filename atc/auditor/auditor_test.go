@@ -0,0 +1,197 @@
+package auditor_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auditor", func() {
+	var (
+		logger         *lagertest.TestLogger
+		config         auditor.AuditConfig
+		fakeRepository *dbfakes.FakeAuditRepository
+
+		aud   auditor.Auditor
+		event db.AuditEvent
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		config = auditor.AuditConfig{}
+		fakeRepository = new(dbfakes.FakeAuditRepository)
+
+		event = db.AuditEvent{
+			Time:     time.Unix(123, 0),
+			UserName: "some-user",
+			TeamName: "some-team",
+			Action:   atc.PausePipeline,
+			Method:   "PUT",
+			Target:   "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+			Status:   http.StatusOK,
+		}
+	})
+
+	JustBeforeEach(func() {
+		aud = auditor.NewAuditor(logger, config, fakeRepository)
+	})
+
+	Context("when the action's group is not enabled", func() {
+		BeforeEach(func() {
+			config.EnableJobAuditLog = true
+		})
+
+		It("is not enabled", func() {
+			Expect(aud.IsEnabled(atc.PausePipeline)).To(BeFalse())
+		})
+
+		It("does not audit the event", func() {
+			aud.Audit(event)
+			Expect(logger.LogMessages()).To(BeEmpty())
+			Expect(fakeRepository.RecordCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the action's group is enabled", func() {
+		BeforeEach(func() {
+			config.EnablePipelineAuditLog = true
+		})
+
+		It("is enabled", func() {
+			Expect(aud.IsEnabled(atc.PausePipeline)).To(BeTrue())
+			Expect(aud.IsEnabled(atc.PauseJob)).To(BeFalse())
+		})
+
+		It("logs the event", func() {
+			aud.Audit(event)
+
+			Expect(logger.Logs()).To(HaveLen(1))
+			Expect(logger.Logs()[0].Message).To(Equal("test.audit"))
+			Expect(logger.Logs()[0].LogLevel).To(Equal(lager.INFO))
+			Expect(logger.Logs()[0].Data).To(Equal(lager.Data{
+				"action": atc.PausePipeline,
+				"user":   "some-user",
+				"team":   "some-team",
+				"method": "PUT",
+				"target": "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+				"status": float64(http.StatusOK),
+			}))
+		})
+
+		It("records the event", func() {
+			aud.Audit(event)
+
+			Expect(fakeRepository.RecordCallCount()).To(Equal(1))
+			Expect(fakeRepository.RecordArgsForCall(0)).To(Equal(event))
+		})
+
+		Context("when recording the event fails", func() {
+			BeforeEach(func() {
+				fakeRepository.RecordReturns(errors.New("nope"))
+			})
+
+			It("logs the error", func() {
+				aud.Audit(event)
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-record-audit-event"))
+			})
+		})
+
+		Context("when there is no repository", func() {
+			JustBeforeEach(func() {
+				aud = auditor.NewAuditor(logger, config, nil)
+			})
+
+			It("only logs the event", func() {
+				aud.Audit(event)
+				Expect(logger.LogMessages()).To(Equal([]string{"test.audit"}))
+			})
+		})
+	})
+
+	Context("when the action is enabled individually", func() {
+		BeforeEach(func() {
+			config.EnableActions = []string{atc.PausePipeline}
+		})
+
+		It("is enabled", func() {
+			Expect(aud.IsEnabled(atc.PausePipeline)).To(BeTrue())
+			Expect(aud.IsEnabled(atc.UnpausePipeline)).To(BeFalse())
+		})
+
+		It("audits the event", func() {
+			aud.Audit(event)
+			Expect(fakeRepository.RecordCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the action's group is enabled but the action is disabled individually", func() {
+		BeforeEach(func() {
+			config.EnablePipelineAuditLog = true
+			config.DisableActions = []string{atc.PausePipeline}
+		})
+
+		It("is not enabled", func() {
+			Expect(aud.IsEnabled(atc.PausePipeline)).To(BeFalse())
+			Expect(aud.IsEnabled(atc.UnpausePipeline)).To(BeTrue())
+		})
+
+		It("does not audit the event", func() {
+			aud.Audit(event)
+			Expect(fakeRepository.RecordCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when every group is enabled", func() {
+		BeforeEach(func() {
+			config = auditor.AuditConfig{
+				EnableBuildAuditLog:     true,
+				EnableContainerAuditLog: true,
+				EnableJobAuditLog:       true,
+				EnablePipelineAuditLog:  true,
+				EnableResourceAuditLog:  true,
+				EnableSystemAuditLog:    true,
+				EnableTeamAuditLog:      true,
+				EnableWorkerAuditLog:    true,
+				EnableVolumeAuditLog:    true,
+			}
+		})
+
+		It("enables every non-GET route", func() {
+			for _, route := range atc.Routes {
+				if route.Method != "GET" {
+					Expect(aud.IsEnabled(route.Name)).To(BeTrue(), "no audit group for "+route.Name)
+				}
+			}
+		})
+	})
+})
+
+var _ = Describe("AuditConfig", func() {
+	Describe("Validate", func() {
+		It("allows the names of api routes", func() {
+			config := auditor.AuditConfig{
+				EnableActions:  []string{atc.SaveConfig},
+				DisableActions: []string{atc.PausePipeline},
+			}
+
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("rejects unknown actions", func() {
+			config := auditor.AuditConfig{
+				DisableActions: []string{"bogus"},
+			}
+
+			Expect(config.Validate()).To(MatchError("unknown audit action 'bogus'"))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package auditorfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAuditor struct {
+	AuditStub        func(db.AuditEvent)
+	auditMutex       sync.RWMutex
+	auditArgsForCall []struct {
+		arg1 db.AuditEvent
+	}
+	IsEnabledStub        func(string) bool
+	isEnabledMutex       sync.RWMutex
+	isEnabledArgsForCall []struct {
+		arg1 string
+	}
+	isEnabledReturns struct {
+		result1 bool
+	}
+	isEnabledReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditor) Audit(arg1 db.AuditEvent) {
+	fake.auditMutex.Lock()
+	fake.auditArgsForCall = append(fake.auditArgsForCall, struct {
+		arg1 db.AuditEvent
+	}{arg1})
+	fake.recordInvocation("Audit", []interface{}{arg1})
+	fake.auditMutex.Unlock()
+	if fake.AuditStub != nil {
+		fake.AuditStub(arg1)
+	}
+}
+
+func (fake *FakeAuditor) AuditCallCount() int {
+	fake.auditMutex.RLock()
+	defer fake.auditMutex.RUnlock()
+	return len(fake.auditArgsForCall)
+}
+
+func (fake *FakeAuditor) AuditCalls(stub func(db.AuditEvent)) {
+	fake.auditMutex.Lock()
+	defer fake.auditMutex.Unlock()
+	fake.AuditStub = stub
+}
+
+func (fake *FakeAuditor) AuditArgsForCall(i int) db.AuditEvent {
+	fake.auditMutex.RLock()
+	defer fake.auditMutex.RUnlock()
+	argsForCall := fake.auditArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditor) IsEnabled(arg1 string) bool {
+	fake.isEnabledMutex.Lock()
+	ret, specificReturn := fake.isEnabledReturnsOnCall[len(fake.isEnabledArgsForCall)]
+	fake.isEnabledArgsForCall = append(fake.isEnabledArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("IsEnabled", []interface{}{arg1})
+	fake.isEnabledMutex.Unlock()
+	if fake.IsEnabledStub != nil {
+		return fake.IsEnabledStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isEnabledReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuditor) IsEnabledCallCount() int {
+	fake.isEnabledMutex.RLock()
+	defer fake.isEnabledMutex.RUnlock()
+	return len(fake.isEnabledArgsForCall)
+}
+
+func (fake *FakeAuditor) IsEnabledCalls(stub func(string) bool) {
+	fake.isEnabledMutex.Lock()
+	defer fake.isEnabledMutex.Unlock()
+	fake.IsEnabledStub = stub
+}
+
+func (fake *FakeAuditor) IsEnabledArgsForCall(i int) string {
+	fake.isEnabledMutex.RLock()
+	defer fake.isEnabledMutex.RUnlock()
+	argsForCall := fake.isEnabledArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditor) IsEnabledReturns(result1 bool) {
+	fake.isEnabledMutex.Lock()
+	defer fake.isEnabledMutex.Unlock()
+	fake.IsEnabledStub = nil
+	fake.isEnabledReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeAuditor) IsEnabledReturnsOnCall(i int, result1 bool) {
+	fake.isEnabledMutex.Lock()
+	defer fake.isEnabledMutex.Unlock()
+	fake.IsEnabledStub = nil
+	if fake.isEnabledReturnsOnCall == nil {
+		fake.isEnabledReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isEnabledReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeAuditor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.auditMutex.RLock()
+	defer fake.auditMutex.RUnlock()
+	fake.isEnabledMutex.RLock()
+	defer fake.isEnabledMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auditor.Auditor = new(FakeAuditor)
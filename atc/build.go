@@ -17,6 +17,7 @@ type Build struct {
 	Name         string `json:"name"`
 	Status       string `json:"status"`
 	JobName      string `json:"job_name,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
 	APIURL       string `json:"api_url"`
 	PipelineName string `json:"pipeline_name,omitempty"`
 	StartTime    int64  `json:"start_time,omitempty"`
@@ -1,11 +1,18 @@
 package builds
 
 import (
+	"errors"
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/engine"
 )
 
+// ErrCheckInterrupted is saved to check builds which were left started by a
+// check which never finished, e.g. because its ATC went away.
+var ErrCheckInterrupted = errors.New("check was interrupted")
+
 func NewTracker(
 	logger lager.Logger,
 
@@ -57,6 +64,62 @@ func (bt *Tracker) Track() {
 
 		go engineBuild.Resume(btLog)
 	}
+
+	bt.finishInterruptedChecks(tLog)
+}
+
+// finishInterruptedChecks errors any check build which is no longer being
+// run. Check builds are run by radar rather than the engine, which holds on
+// to a check build's tracking lock until it has finished it, so a started
+// check build whose lock can be acquired has been left behind.
+func (bt *Tracker) finishInterruptedChecks(logger lager.Logger) {
+	checkBuilds, err := bt.buildFactory.GetAllStartedCheckBuilds()
+	if err != nil {
+		logger.Error("failed-to-lookup-started-check-builds", err)
+		return
+	}
+
+	for _, build := range checkBuilds {
+		bLog := logger.WithData(lager.Data{
+			"build":    build.ID(),
+			"pipeline": build.PipelineName(),
+			"resource": build.ResourceName(),
+		})
+
+		bt.finishInterruptedCheck(bLog, build)
+	}
+}
+
+func (bt *Tracker) finishInterruptedCheck(logger lager.Logger, build db.Build) {
+	lock, acquired, err := build.AcquireTrackingLock(logger, time.Minute)
+	if err != nil {
+		logger.Error("failed-to-get-lock", err)
+		return
+	}
+
+	if !acquired {
+		return
+	}
+
+	defer lock.Release()
+
+	// the check may have finished since the builds were looked up
+	found, err := build.Reload()
+	if err != nil {
+		logger.Error("failed-to-reload-check-build", err)
+		return
+	}
+
+	if !found || build.Status() != db.BuildStatusStarted {
+		return
+	}
+
+	logger.Info("finishing-interrupted-check")
+
+	err = build.FinishWithError(ErrCheckInterrupted)
+	if err != nil {
+		logger.Error("failed-to-mark-check-build-as-errored", err)
+	}
 }
 
 func (bt *Tracker) Release() {
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
 )
@@ -92,6 +93,64 @@ var _ = Describe("Tracker", func() {
 				Expect(savedErr3).To(Equal(errors.New("nope")))
 			})
 		})
+
+		Context("when there are started check builds", func() {
+			var (
+				runningCheckBuild     *dbfakes.FakeBuild
+				interruptedCheckBuild *dbfakes.FakeBuild
+				finishedCheckBuild    *dbfakes.FakeBuild
+				fakeLock              *lockfakes.FakeLock
+			)
+
+			BeforeEach(func() {
+				fakeLock = new(lockfakes.FakeLock)
+
+				runningCheckBuild = new(dbfakes.FakeBuild)
+				runningCheckBuild.AcquireTrackingLockReturns(nil, false, nil)
+
+				interruptedCheckBuild = new(dbfakes.FakeBuild)
+				interruptedCheckBuild.AcquireTrackingLockReturns(fakeLock, true, nil)
+				interruptedCheckBuild.ReloadReturns(true, nil)
+				interruptedCheckBuild.StatusReturns(db.BuildStatusStarted)
+
+				finishedCheckBuild = new(dbfakes.FakeBuild)
+				finishedCheckBuild.AcquireTrackingLockReturns(fakeLock, true, nil)
+				finishedCheckBuild.ReloadReturns(true, nil)
+				finishedCheckBuild.StatusReturns(db.BuildStatusSucceeded)
+
+				fakeBuildFactory.GetAllStartedCheckBuildsReturns([]db.Build{
+					runningCheckBuild,
+					interruptedCheckBuild,
+					finishedCheckBuild,
+				}, nil)
+			})
+
+			It("errors the ones which are no longer being run", func() {
+				tracker.Track()
+
+				Expect(interruptedCheckBuild.FinishWithErrorCallCount()).To(Equal(1))
+				Expect(interruptedCheckBuild.FinishWithErrorArgsForCall(0)).To(Equal(builds.ErrCheckInterrupted))
+			})
+
+			It("leaves the ones which are still being run", func() {
+				tracker.Track()
+
+				Expect(runningCheckBuild.ReloadCallCount()).To(BeZero())
+				Expect(runningCheckBuild.FinishWithErrorCallCount()).To(BeZero())
+			})
+
+			It("leaves the ones which finished in the meantime", func() {
+				tracker.Track()
+
+				Expect(finishedCheckBuild.FinishWithErrorCallCount()).To(BeZero())
+			})
+
+			It("releases the locks it acquired", func() {
+				tracker.Track()
+
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(2))
+			})
+		})
 	})
 
 	Describe("Release", func() {
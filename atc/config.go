@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
@@ -20,6 +23,60 @@ type Config struct {
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
 }
 
+// NewConfig parses a pipeline config from YAML (or JSON), decoding it the same
+// way as configs submitted through the API. It does not validate the config.
+func NewConfig(configBytes []byte) (Config, error) {
+	var untypedInput interface{}
+
+	if err := yaml.Unmarshal(configBytes, &untypedInput); err != nil {
+		return Config{}, MalformedConfigError{err}
+	}
+
+	return DecodeConfig(untypedInput)
+}
+
+// DecodeConfig decodes a pipeline config which has already been unmarshaled
+// from YAML or JSON, returning an ExtraKeysError for any unknown nested keys.
+func DecodeConfig(untypedInput interface{}) (Config, error) {
+	var config Config
+	var metadata mapstructure.Metadata
+
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &metadata,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			SanitizeDecodeHook,
+			VersionConfigDecodeHook,
+			InputsConfigDecodeHook,
+			InParallelConfigDecodeHook,
+			ContainerLimitsDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := decoder.Decode(untypedInput); err != nil {
+		return Config{}, err
+	}
+
+	nestedUnused := []string{}
+	for _, unused := range metadata.Unused {
+		if strings.Contains(unused, ".") {
+			nestedUnused = append(nestedUnused, unused)
+		}
+	}
+
+	if len(nestedUnused) > 0 {
+		return Config{}, ExtraKeysError{ExtraKeys: nestedUnused}
+	}
+
+	return config, nil
+}
+
 type GroupConfig struct {
 	Name      string   `yaml:"name" json:"name" mapstructure:"name"`
 	Jobs      []string `yaml:"jobs,omitempty" json:"jobs,omitempty" mapstructure:"jobs"`
@@ -49,6 +106,30 @@ type ResourceConfig struct {
 	Tags         Tags    `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 	Version      Version `yaml:"version,omitempty" json:"version" mapstructure:"version"`
 	Icon         string  `yaml:"icon,omitempty" json:"icon,omitempty" mapstructure:"icon"`
+
+	Webhook *WebhookConfig `yaml:"webhook,omitempty" json:"webhook,omitempty" mapstructure:"webhook"`
+}
+
+const (
+	WebhookProviderGitHub    = "github"
+	WebhookProviderGitLab    = "gitlab"
+	WebhookProviderBitbucket = "bitbucket"
+)
+
+// WebhookConfig configures how a resource's check webhook verifies requests
+// and reads versions from their payloads.
+type WebhookConfig struct {
+	// Provider determines how requests are signed: GitHub and Bitbucket sign
+	// the payload with an HMAC-SHA256 of the secret, and GitLab sends the
+	// secret as-is.
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty" mapstructure:"provider"`
+	Secret   string `yaml:"secret,omitempty" json:"secret,omitempty" mapstructure:"secret"`
+
+	// Version maps each field of a version to a JSON pointer into the
+	// payload, e.g. {ref: /after}. The resource is checked from the version
+	// when every field is present in the payload. It requires a Provider, so
+	// that versions are only read from payloads which have been verified.
+	Version map[string]string `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
 type ResourceType struct {
@@ -292,6 +373,91 @@ func (c InputsConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+// An InParallelConfig represents a set of steps to run in parallel, either
+// given as a bare list of steps or as a map with additional options.
+type InParallelConfig struct {
+	Steps    PlanSequence `yaml:"steps,omitempty" json:"steps" mapstructure:"steps"`
+	Limit    int          `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	FailFast bool         `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
+func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
+	var data interface{}
+
+	err := json.Unmarshal(payload, &data)
+	if err != nil {
+		return err
+	}
+
+	switch data.(type) {
+	case []interface{}:
+		var steps PlanSequence
+		err := json.Unmarshal(payload, &steps)
+		if err != nil {
+			return err
+		}
+
+		c.Steps = steps
+	case map[string]interface{}:
+		type target InParallelConfig
+
+		var config target
+		err := json.Unmarshal(payload, &config)
+		if err != nil {
+			return err
+		}
+
+		*c = InParallelConfig(config)
+	default:
+		return errors.New("unknown type for in_parallel")
+	}
+
+	return nil
+}
+
+func (c *InParallelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var data interface{}
+
+	err := unmarshal(&data)
+	if err != nil {
+		return err
+	}
+
+	switch data.(type) {
+	case []interface{}:
+		var steps PlanSequence
+		err := unmarshal(&steps)
+		if err != nil {
+			return err
+		}
+
+		c.Steps = steps
+	case map[interface{}]interface{}:
+		type target InParallelConfig
+
+		var config target
+		err := unmarshal(&config)
+		if err != nil {
+			return err
+		}
+
+		*c = InParallelConfig(config)
+	default:
+		return errors.New("unknown type for in_parallel")
+	}
+
+	return nil
+}
+
+// An AcrossVarConfig configures a var for a step to be run across. The values
+// are either given as a list, or loaded from a local var at runtime, e.g.
+// ((.:some-var)).
+type AcrossVarConfig struct {
+	Var         string      `yaml:"var" json:"var" mapstructure:"var"`
+	Values      interface{} `yaml:"values,omitempty" json:"values,omitempty" mapstructure:"values"`
+	MaxInFlight int         `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -308,6 +474,10 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan, optionally limiting concurrency and
+	// failing fast
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -330,13 +500,28 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
-	// task config path, e.g. foo/build.yml
+	// task config path, e.g. foo/build.yml, pipeline config path for
+	// set_pipeline, or the file to load for load_var
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
-	// task variables, if task is specified as external file via TaskConfigPath
+	// task variables, if task is specified as external file via TaskConfigPath,
+	// or pipeline variables for set_pipeline
 	TaskVars Params `yaml:"vars,omitempty" json:"vars,omitempty" mapstructure:"vars"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure, within the build's team
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+	// pipeline variables files, e.g. foo/vars.yml
+	VarFiles []string `yaml:"var_files,omitempty" json:"var_files,omitempty" mapstructure:"var_files"`
+
+	// corresponds to a LoadVar plan
+	// name of the local var to set, referred to as ((.:name))
+	LoadVar string `yaml:"load_var,omitempty" json:"load_var,omitempty" mapstructure:"load_var"`
+	// format of the file to load, e.g. json; detected by the file extension if
+	// not specified
+	Format string `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -374,6 +559,13 @@ type PlanConfig struct {
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to run the step once for each combination of the given
+	// vars' values, referred to as local vars, e.g. ((.:some-var))
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+	// used with across to stop running the step for any remaining values once
+	// one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
@@ -400,6 +592,14 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
+	if config.LoadVar != "" {
+		return config.LoadVar
+	}
+
 	return ""
 }
 
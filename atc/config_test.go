@@ -46,4 +46,79 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("InParallelConfig", func() {
+		Context("when unmarshaling a list of steps from YAML", func() {
+			It("treats the list as the steps", func() {
+				var config InParallelConfig
+				bs := []byte(`[{get: some-resource}, {task: some-task}]`)
+				err := yaml.Unmarshal(bs, &config)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config).To(Equal(InParallelConfig{
+					Steps: PlanSequence{
+						{Get: "some-resource"},
+						{Task: "some-task"},
+					},
+				}))
+			})
+		})
+
+		Context("when unmarshaling a map from YAML", func() {
+			It("produces the steps and options", func() {
+				var config InParallelConfig
+				bs := []byte(`{steps: [{get: some-resource}], limit: 3, fail_fast: true}`)
+				err := yaml.Unmarshal(bs, &config)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config).To(Equal(InParallelConfig{
+					Steps: PlanSequence{
+						{Get: "some-resource"},
+					},
+					Limit:    3,
+					FailFast: true,
+				}))
+			})
+		})
+
+		Context("when unmarshaling a list of steps from JSON", func() {
+			It("treats the list as the steps", func() {
+				var config InParallelConfig
+				bs := []byte(`[{"get": "some-resource"}]`)
+				err := json.Unmarshal(bs, &config)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config).To(Equal(InParallelConfig{
+					Steps: PlanSequence{
+						{Get: "some-resource"},
+					},
+				}))
+			})
+		})
+
+		Context("when unmarshaling a map from JSON", func() {
+			It("produces the steps and options", func() {
+				var config InParallelConfig
+				bs := []byte(`{"steps": [{"get": "some-resource"}], "limit": 3, "fail_fast": true}`)
+				err := json.Unmarshal(bs, &config)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config).To(Equal(InParallelConfig{
+					Steps: PlanSequence{
+						{Get: "some-resource"},
+					},
+					Limit:    3,
+					FailFast: true,
+				}))
+			})
+		})
+
+		Context("when unmarshaling an invalid type", func() {
+			It("errors", func() {
+				var config InParallelConfig
+				err := json.Unmarshal([]byte(`"nope"`), &config)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
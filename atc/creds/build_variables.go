@@ -0,0 +1,181 @@
+package creds
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	atctemplate "github.com/concourse/concourse/atc/template"
+)
+
+// LocalVariables are variables scoped to a single build, e.g. those set by a
+// load_var step. They are shared by every step in the build, unless a step
+// runs within a narrower scope (see NewScope).
+type LocalVariables struct {
+	parent *LocalVariables
+
+	lock sync.RWMutex
+	vars map[string]interface{}
+}
+
+func NewLocalVariables() *LocalVariables {
+	return &LocalVariables{
+		vars: map[string]interface{}{},
+	}
+}
+
+// NewScope returns a child scope of local vars, e.g. for each iteration of an
+// across step. Vars set in the child scope are not visible to the parent,
+// while vars set in the parent remain visible to the child.
+func (local *LocalVariables) NewScope() *LocalVariables {
+	return &LocalVariables{
+		parent: local,
+		vars:   map[string]interface{}{},
+	}
+}
+
+// Set sets the value of a local var, replacing any previous value.
+func (local *LocalVariables) Set(name string, value interface{}) {
+	local.lock.Lock()
+	local.vars[name] = value
+	local.lock.Unlock()
+}
+
+// Get returns the value of a local var, if it has been set in this scope or
+// any of its parents.
+func (local *LocalVariables) Get(name string) (interface{}, bool) {
+	local.lock.RLock()
+	value, found := local.vars[name]
+	local.lock.RUnlock()
+
+	if !found && local.parent != nil {
+		return local.parent.Get(name)
+	}
+
+	return value, found
+}
+
+// Names returns the names of all local vars which have been set in this scope
+// or any of its parents, in sorted order.
+func (local *LocalVariables) Names() []string {
+	names := []string{}
+	for name := range local.all() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (local *LocalVariables) all() map[string]interface{} {
+	all := map[string]interface{}{}
+	if local.parent != nil {
+		all = local.parent.all()
+	}
+
+	local.lock.RLock()
+	for name, value := range local.vars {
+		all[name] = value
+	}
+	local.lock.RUnlock()
+
+	return all
+}
+
+// BuildCredentials tracks the credentials resolved for a build, whichever
+// scope of local vars they were resolved in: their values, so that they can
+// be redacted from the build's output, and any leases on them, so that they
+// can be revoked once the build has finished.
+type BuildCredentials struct {
+	redactor *Redactor
+	leases   *Leases
+}
+
+func NewBuildCredentials() *BuildCredentials {
+	return &BuildCredentials{
+		redactor: NewRedactor(),
+		leases:   NewLeases(),
+	}
+}
+
+// Redactor returns the Redactor tracking the values of the build's
+// credentials.
+func (credentials *BuildCredentials) Redactor() *Redactor {
+	return credentials.redactor
+}
+
+// Leases returns the Leases on the build's credentials.
+func (credentials *BuildCredentials) Leases() *Leases {
+	return credentials.leases
+}
+
+// LocalVarsResolver is implemented by Variables which resolve a build's local
+// vars, e.g. ((.:some-var)), in addition to their other vars. Variables which
+// wrap a LocalVarsResolver should implement it too, so that local vars are
+// still resolved through them.
+type LocalVarsResolver interface {
+	Variables
+
+	LocalVariables() *LocalVariables
+}
+
+// BuildVariables layers a build's local vars, referred to as ((.:name)), over
+// the variables of the build's pipeline. Credentials resolved from the
+// pipeline's variables are tracked by the build's BuildCredentials.
+type BuildVariables struct {
+	parent      Variables
+	local       *LocalVariables
+	credentials *BuildCredentials
+}
+
+func NewBuildVariables(parent Variables, local *LocalVariables, credentials *BuildCredentials) Variables {
+	return BuildVariables{
+		parent:      parent,
+		local:       local,
+		credentials: credentials,
+	}
+}
+
+// LocalVariables returns the build's local vars.
+func (variables BuildVariables) LocalVariables() *LocalVariables {
+	return variables.local
+}
+
+func (variables BuildVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	if strings.HasPrefix(varDef.Name, atctemplate.LocalVarPrefix) {
+		value, found := variables.local.Get(strings.TrimPrefix(varDef.Name, atctemplate.LocalVarPrefix))
+		return value, found, nil
+	}
+
+	value, lease, found, err := GetLeased(variables.parent, varDef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if found {
+		if lease != nil {
+			variables.credentials.Leases().Track(lease)
+		}
+
+		variables.credentials.Redactor().Track(value)
+	}
+
+	return value, found, nil
+}
+
+func (variables BuildVariables) List() ([]template.VariableDefinition, error) {
+	varDefs, err := variables.parent.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range variables.local.Names() {
+		varDefs = append(varDefs, template.VariableDefinition{
+			Name: atctemplate.LocalVarPrefix + name,
+		})
+	}
+
+	return varDefs, nil
+}
@@ -0,0 +1,264 @@
+package creds_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalVariables", func() {
+	var localVars *creds.LocalVariables
+
+	BeforeEach(func() {
+		localVars = creds.NewLocalVariables()
+		localVars.Set("a", "parent-a")
+		localVars.Set("b", "parent-b")
+	})
+
+	Describe("NewScope", func() {
+		var scope *creds.LocalVariables
+
+		BeforeEach(func() {
+			scope = localVars.NewScope()
+			scope.Set("b", "child-b")
+			scope.Set("c", "child-c")
+		})
+
+		It("sees the parent's vars, shadowed by its own", func() {
+			value, found := scope.Get("a")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("parent-a"))
+
+			value, found = scope.Get("b")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("child-b"))
+
+			Expect(scope.Names()).To(Equal([]string{"a", "b", "c"}))
+		})
+
+		It("does not affect the parent", func() {
+			value, found := localVars.Get("b")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("parent-b"))
+
+			_, found = localVars.Get("c")
+			Expect(found).To(BeFalse())
+
+			Expect(localVars.Names()).To(Equal([]string{"a", "b"}))
+		})
+	})
+})
+
+var _ = Describe("BuildVariables", func() {
+	var (
+		fakeParent  *credsfakes.FakeVariables
+		localVars   *creds.LocalVariables
+		credentials *creds.BuildCredentials
+
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeParent = new(credsfakes.FakeVariables)
+		localVars = creds.NewLocalVariables()
+		credentials = creds.NewBuildCredentials()
+
+		variables = creds.NewBuildVariables(fakeParent, localVars, credentials)
+	})
+
+	Describe("Get", func() {
+		Context("when the var is a local var", func() {
+			It("returns the local var's value", func() {
+				localVars.Set("some-var", "some-value")
+
+				value, found, err := variables.Get(template.VariableDefinition{Name: ".:some-var"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-value"))
+			})
+
+			It("does not consult the parent", func() {
+				_, found, err := variables.Get(template.VariableDefinition{Name: ".:some-var"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				Expect(fakeParent.GetCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the var is not a local var", func() {
+			BeforeEach(func() {
+				fakeParent.GetReturns("some-secret", true, nil)
+			})
+
+			It("returns the parent's value", func() {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-secret"))
+
+				Expect(fakeParent.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: "some-var"}))
+			})
+
+			It("tracks the value for redaction", func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(credentials.Redactor().Redact("it's some-secret")).To(Equal("it's ((redacted))"))
+			})
+		})
+
+		Context("when the parent leases the var", func() {
+			var fakeLeasedParent *credsfakes.FakeLeasedVariables
+			var fakeLease *credsfakes.FakeLease
+
+			BeforeEach(func() {
+				fakeLease = new(credsfakes.FakeLease)
+
+				fakeLeasedParent = new(credsfakes.FakeLeasedVariables)
+				fakeLeasedParent.GetLeasedReturns("some-secret", fakeLease, true, nil)
+
+				variables = creds.NewBuildVariables(fakeLeasedParent, localVars.NewScope(), credentials)
+			})
+
+			It("tracks the lease so that it is revoked with the build's leases", func() {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-secret"))
+
+				credentials.Leases().Revoke(lagertest.NewTestLogger("test"))
+				Expect(fakeLease.RevokeCallCount()).To(Equal(1))
+			})
+
+			Context("when the var is not found", func() {
+				BeforeEach(func() {
+					fakeLeasedParent.GetLeasedReturns(nil, fakeLease, false, nil)
+				})
+
+				It("does not track the lease", func() {
+					_, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+
+					credentials.Leases().Revoke(lagertest.NewTestLogger("test"))
+					Expect(fakeLease.RevokeCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			fakeParent.ListReturns([]template.VariableDefinition{{Name: "some-secret"}}, nil)
+
+			localVars.Set("b", "some-value")
+			localVars.Set("a", "some-value")
+		})
+
+		It("includes both the parent's and the local vars", func() {
+			varDefs, err := variables.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(varDefs).To(Equal([]template.VariableDefinition{
+				{Name: "some-secret"},
+				{Name: ".:a"},
+				{Name: ".:b"},
+			}))
+		})
+
+		Context("when the parent fails to list", func() {
+			BeforeEach(func() {
+				fakeParent.ListReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				_, err := variables.List()
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+
+	Describe("evaluating with local vars", func() {
+		BeforeEach(func() {
+			fakeParent.GetStub = func(varDef template.VariableDefinition) (interface{}, bool, error) {
+				if varDef.Name == "some-secret" {
+					return "shh", true, nil
+				}
+
+				return nil, false, nil
+			}
+
+			localVars.Set("version", "1.2.3")
+			localVars.Set("metadata", map[string]interface{}{
+				"tags": []interface{}{"a", "b"},
+				"ref":  "abcdef",
+			})
+		})
+
+		It("interpolates local vars alongside the parent's vars", func() {
+			result, err := creds.NewParams(variables, atc.Params{
+				"version": "((.:version))",
+				"tag":     "v((.:version))-((.:metadata.ref))",
+				"tags":    "((.:metadata.tags))",
+				"secret":  "((some-secret))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Params{
+				"version": "1.2.3",
+				"tag":     "v1.2.3-abcdef",
+				"tags":    []interface{}{"a", "b"},
+				"secret":  "shh",
+			}))
+		})
+
+		It("errors when a local var is not set", func() {
+			_, err := creds.NewParams(variables, atc.Params{
+				"version": "((.:bogus))",
+			}).Evaluate()
+			Expect(err).To(MatchError("undefined local var: bogus"))
+		})
+
+		It("errors when a local var has no such field", func() {
+			_, err := creds.NewParams(variables, atc.Params{
+				"version": "((.:metadata.bogus))",
+			}).Evaluate()
+			Expect(err).To(MatchError("local var 'metadata' has no field 'bogus'"))
+		})
+
+		It("interpolates local vars through variables which wrap the build's", func() {
+			result, err := creds.NewParams(wrappedVariables{variables.(creds.LocalVarsResolver)}, atc.Params{
+				"version": "((.:version))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Params{
+				"version": "1.2.3",
+			}))
+		})
+
+		It("leaves local vars in place when evaluated outside of a build", func() {
+			result, err := creds.NewParams(fakeParent, atc.Params{
+				"version": "((.:version))",
+				"secret":  "((some-secret))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Params{
+				"version": "((.:version))",
+				"secret":  "shh",
+			}))
+		})
+	})
+})
+
+type wrappedVariables struct {
+	creds.LocalVarsResolver
+}
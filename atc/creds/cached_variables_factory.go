@@ -0,0 +1,180 @@
+package creds
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+type SecretCacheConfig struct {
+	Enabled          bool          `long:"secret-cache-enabled"           description:"Enable in-memory caching of credentials looked up from every credential manager."`
+	Duration         time.Duration `long:"secret-cache-duration"          default:"1m"    description:"How long to cache credentials which were found."`
+	NotFoundDuration time.Duration `long:"secret-cache-duration-notfound" default:"10s"   description:"How long to cache the absence of credentials which were not found. Set to 0 to disable."`
+	MaxEntries       int           `long:"secret-cache-max-entries"       default:"10000" description:"Maximum number of credentials to cache. The least recently used are evicted first."`
+}
+
+// Counter counts the hits and misses of a CachedVariablesFactory, e.g. a
+// metric.Meter.
+type Counter interface {
+	Inc()
+}
+
+type CachedVariablesFactory struct {
+	factory VariablesFactory
+	cache   *secretCache
+}
+
+type CachedVariables struct {
+	variables    Variables
+	cache        *secretCache
+	teamName     string
+	pipelineName string
+}
+
+// NewCachedVariablesFactory constructs a VariablesFactory which caches the
+// credentials looked up from the given factory in memory, so that the
+// credential manager is not queried for every var of every step.
+//
+// Credentials which are not found are cached too, for NotFoundDuration, as
+// they are looked up from every manager in the lookup order. Errors and leased
+// credentials, which are issued for a single build, are never cached.
+func NewCachedVariablesFactory(factory VariablesFactory, config SecretCacheConfig, clock clock.Clock, hits Counter, misses Counter) VariablesFactory {
+	return &CachedVariablesFactory{
+		factory: factory,
+		cache: &secretCache{
+			config: config,
+			clock:  clock,
+			hits:   hits,
+			misses: misses,
+
+			entries: map[secretCacheKey]*list.Element{},
+			lru:     list.New(),
+		},
+	}
+}
+
+func (cvf CachedVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	return CachedVariables{
+		variables:    cvf.factory.NewVariables(teamName, pipelineName),
+		cache:        cvf.cache,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+func (cv CachedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, _, found, err := cv.GetLeased(varDef)
+	return value, found, err
+}
+
+func (cv CachedVariables) GetLeased(varDef template.VariableDefinition) (interface{}, Lease, bool, error) {
+	key := secretCacheKey{
+		teamName:     cv.teamName,
+		pipelineName: cv.pipelineName,
+		name:         varDef.Name,
+	}
+
+	value, found, cached := cv.cache.get(key)
+	if cached {
+		return value, nil, found, nil
+	}
+
+	value, lease, found, err := GetLeased(cv.variables, varDef)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if lease == nil {
+		cv.cache.put(key, value, found)
+	}
+
+	return value, lease, found, nil
+}
+
+func (cv CachedVariables) List() ([]template.VariableDefinition, error) {
+	return cv.variables.List()
+}
+
+type secretCacheKey struct {
+	teamName     string
+	pipelineName string
+	name         string
+}
+
+type secretCacheEntry struct {
+	key      secretCacheKey
+	value    interface{}
+	found    bool
+	deadline time.Time
+}
+
+type secretCache struct {
+	config SecretCacheConfig
+	clock  clock.Clock
+	hits   Counter
+	misses Counter
+
+	lock    sync.Mutex
+	entries map[secretCacheKey]*list.Element
+	lru     *list.List
+}
+
+func (cache *secretCache) get(key secretCacheKey) (interface{}, bool, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	elem, found := cache.entries[key]
+	if !found {
+		cache.misses.Inc()
+		return nil, false, false
+	}
+
+	entry := elem.Value.(*secretCacheEntry)
+	if !cache.clock.Now().Before(entry.deadline) {
+		cache.remove(elem)
+		cache.misses.Inc()
+		return nil, false, false
+	}
+
+	cache.lru.MoveToFront(elem)
+	cache.hits.Inc()
+
+	return entry.value, entry.found, true
+}
+
+func (cache *secretCache) put(key secretCacheKey, value interface{}, found bool) {
+	ttl := cache.config.Duration
+	if !found {
+		ttl = cache.config.NotFoundDuration
+	}
+
+	if ttl <= 0 || cache.config.MaxEntries <= 0 {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if elem, exists := cache.entries[key]; exists {
+		cache.remove(elem)
+	}
+
+	for cache.lru.Len() >= cache.config.MaxEntries {
+		cache.remove(cache.lru.Back())
+	}
+
+	cache.entries[key] = cache.lru.PushFront(&secretCacheEntry{
+		key:      key,
+		value:    value,
+		found:    found,
+		deadline: cache.clock.Now().Add(ttl),
+	})
+}
+
+func (cache *secretCache) remove(elem *list.Element) {
+	cache.lru.Remove(elem)
+	delete(cache.entries, elem.Value.(*secretCacheEntry).key)
+}
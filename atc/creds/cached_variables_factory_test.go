@@ -0,0 +1,205 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type counter int
+
+func (c *counter) Inc() { *c++ }
+
+var _ = Describe("Cached Variables Factory", func() {
+	var (
+		fakeFactory   *credsfakes.FakeVariablesFactory
+		fakeVariables *credsfakes.FakeVariables
+		fakeClock     *fakeclock.FakeClock
+
+		config creds.SecretCacheConfig
+		hits   counter
+		misses counter
+
+		factory creds.VariablesFactory
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeFactory = new(credsfakes.FakeVariablesFactory)
+		fakeFactory.NewVariablesReturns(fakeVariables)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		config = creds.SecretCacheConfig{
+			Enabled:          true,
+			Duration:         time.Minute,
+			NotFoundDuration: 10 * time.Second,
+			MaxEntries:       2,
+		}
+
+		hits = 0
+		misses = 0
+	})
+
+	JustBeforeEach(func() {
+		factory = creds.NewCachedVariablesFactory(fakeFactory, config, fakeClock, &hits, &misses)
+	})
+
+	get := func(teamName, pipelineName, name string) (interface{}, bool, error) {
+		return factory.NewVariables(teamName, pipelineName).Get(template.VariableDefinition{Name: name})
+	}
+
+	Context("when the credential is found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		It("caches it for the configured duration", func() {
+			value, found, err := get("some-team", "some-pipeline", "some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			fakeVariables.GetReturns("new-value", true, nil)
+
+			fakeClock.Increment(time.Minute - time.Second)
+
+			value, found, err = get("some-team", "some-pipeline", "some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+			fakeClock.Increment(time.Second)
+
+			value, _, _ = get("some-team", "some-pipeline", "some-var")
+			Expect(value).To(Equal("new-value"))
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+
+		It("counts hits and misses", func() {
+			get("some-team", "some-pipeline", "some-var")
+			get("some-team", "some-pipeline", "some-var")
+			get("some-team", "some-pipeline", "some-var")
+
+			Expect(int(hits)).To(Equal(2))
+			Expect(int(misses)).To(Equal(1))
+		})
+
+		It("caches per team and pipeline", func() {
+			get("some-team", "some-pipeline", "some-var")
+			get("other-team", "some-pipeline", "some-var")
+			get("some-team", "other-pipeline", "some-var")
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			teamName, pipelineName := fakeFactory.NewVariablesArgsForCall(1)
+			Expect(teamName).To(Equal("other-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+		})
+
+		Context("when the cache is full", func() {
+			It("evicts the least recently used credential", func() {
+				get("some-team", "some-pipeline", "first-var")
+				get("some-team", "some-pipeline", "second-var")
+				get("some-team", "some-pipeline", "first-var")
+				get("some-team", "some-pipeline", "third-var")
+				Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+				get("some-team", "some-pipeline", "first-var")
+				Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+				get("some-team", "some-pipeline", "second-var")
+				Expect(fakeVariables.GetCallCount()).To(Equal(4))
+			})
+		})
+	})
+
+	Context("when the credential is not found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, nil)
+		})
+
+		It("caches its absence for the configured duration", func() {
+			_, found, err := get("some-team", "some-pipeline", "some-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			fakeClock.Increment(9 * time.Second)
+
+			_, found, _ = get("some-team", "some-pipeline", "some-var")
+			Expect(found).To(BeFalse())
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+			fakeVariables.GetReturns("some-value", true, nil)
+			fakeClock.Increment(time.Second)
+
+			value, found, _ := get("some-team", "some-pipeline", "some-var")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		Context("when negative caching is disabled", func() {
+			BeforeEach(func() {
+				config.NotFoundDuration = 0
+			})
+
+			It("does not cache its absence", func() {
+				get("some-team", "some-pipeline", "some-var")
+				get("some-team", "some-pipeline", "some-var")
+
+				Expect(fakeVariables.GetCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when the lookup fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, disaster)
+		})
+
+		It("returns the error without caching it", func() {
+			_, _, err := get("some-team", "some-pipeline", "some-var")
+			Expect(err).To(Equal(disaster))
+
+			_, _, err = get("some-team", "some-pipeline", "some-var")
+			Expect(err).To(Equal(disaster))
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the credential is leased", func() {
+		var fakeLeasedVariables *credsfakes.FakeLeasedVariables
+		var fakeLease *credsfakes.FakeLease
+
+		BeforeEach(func() {
+			fakeLease = new(credsfakes.FakeLease)
+
+			fakeLeasedVariables = new(credsfakes.FakeLeasedVariables)
+			fakeLeasedVariables.GetLeasedReturns("some-value", fakeLease, true, nil)
+			fakeFactory.NewVariablesReturns(fakeLeasedVariables)
+		})
+
+		It("returns the lease without caching the credential", func() {
+			for i := 0; i < 2; i++ {
+				value, lease, found, err := creds.GetLeased(factory.NewVariables("some-team", "some-pipeline"), template.VariableDefinition{Name: "some-var"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-value"))
+				Expect(lease).To(Equal(fakeLease))
+			}
+
+			Expect(fakeLeasedVariables.GetLeasedCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package creds
+
+import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+type ChainedVariablesFactory struct {
+	factories []VariablesFactory
+}
+
+type ChainedVariables struct {
+	variables []Variables
+}
+
+// NewChainedVariablesFactory constructs a VariablesFactory which looks up
+// credentials from each of the given factories in order, falling through to
+// the next one when a credential is not found.
+func NewChainedVariablesFactory(factories []VariablesFactory) VariablesFactory {
+	return &ChainedVariablesFactory{factories: factories}
+}
+
+func (cvf ChainedVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	variables := make([]Variables, len(cvf.factories))
+	for i, factory := range cvf.factories {
+		variables[i] = factory.NewVariables(teamName, pipelineName)
+	}
+
+	return ChainedVariables{variables: variables}
+}
+
+// Get returns the credential from the first manager which has it. An error
+// from any manager stops the lookup, rather than silently falling through to
+// a manager with lower precedence.
+func (cv ChainedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	result, _, found, err := cv.GetLeased(varDef)
+	return result, found, err
+}
+
+func (cv ChainedVariables) GetLeased(varDef template.VariableDefinition) (interface{}, Lease, bool, error) {
+	for _, variables := range cv.variables {
+		result, lease, found, err := GetLeased(variables, varDef)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if found {
+			return result, lease, true, nil
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+func (cv ChainedVariables) List() ([]template.VariableDefinition, error) {
+	seen := map[string]bool{}
+	varDefs := []template.VariableDefinition{}
+
+	for _, variables := range cv.variables {
+		defs, err := variables.List()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, def := range defs {
+			if seen[def.Name] {
+				continue
+			}
+
+			seen[def.Name] = true
+			varDefs = append(varDefs, def)
+		}
+	}
+
+	return varDefs, nil
+}
@@ -0,0 +1,139 @@
+package creds_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chained Variables Factory", func() {
+	var (
+		firstFactory  *credsfakes.FakeVariablesFactory
+		secondFactory *credsfakes.FakeVariablesFactory
+
+		firstVariables  *credsfakes.FakeVariables
+		secondVariables *credsfakes.FakeVariables
+
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		firstVariables = new(credsfakes.FakeVariables)
+		firstFactory = new(credsfakes.FakeVariablesFactory)
+		firstFactory.NewVariablesReturns(firstVariables)
+
+		secondVariables = new(credsfakes.FakeVariables)
+		secondFactory = new(credsfakes.FakeVariablesFactory)
+		secondFactory.NewVariablesReturns(secondVariables)
+
+		factory := creds.NewChainedVariablesFactory([]creds.VariablesFactory{firstFactory, secondFactory})
+		variables = factory.NewVariables("some-team", "some-pipeline")
+	})
+
+	It("creates variables from each factory for the team and pipeline", func() {
+		teamName, pipelineName := firstFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+
+		teamName, pipelineName = secondFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+	})
+
+	Describe("Get", func() {
+		var varDef template.VariableDefinition
+
+		BeforeEach(func() {
+			varDef = template.VariableDefinition{Name: "some-var"}
+		})
+
+		Context("when the first manager has the variable", func() {
+			BeforeEach(func() {
+				firstVariables.GetReturns("first-value", true, nil)
+				secondVariables.GetReturns("second-value", true, nil)
+			})
+
+			It("returns its value without asking the next one", func() {
+				value, found, err := variables.Get(varDef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("first-value"))
+
+				Expect(firstVariables.GetArgsForCall(0)).To(Equal(varDef))
+				Expect(secondVariables.GetCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when only the second manager has the variable", func() {
+			BeforeEach(func() {
+				secondVariables.GetReturns("second-value", true, nil)
+			})
+
+			It("falls through to it", func() {
+				value, found, err := variables.Get(varDef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("second-value"))
+			})
+		})
+
+		Context("when no manager has the variable", func() {
+			It("returns not found", func() {
+				_, found, err := variables.Get(varDef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a manager fails", func() {
+			BeforeEach(func() {
+				firstVariables.GetReturns(nil, false, errors.New("nope"))
+				secondVariables.GetReturns("second-value", true, nil)
+			})
+
+			It("returns the error rather than falling through", func() {
+				_, _, err := variables.Get(varDef)
+				Expect(err).To(MatchError("nope"))
+				Expect(secondVariables.GetCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			firstVariables.ListReturns([]template.VariableDefinition{
+				{Name: "some-var"},
+				{Name: "shared-var"},
+			}, nil)
+
+			secondVariables.ListReturns([]template.VariableDefinition{
+				{Name: "shared-var"},
+				{Name: "other-var"},
+			}, nil)
+		})
+
+		It("lists the variables of every manager once", func() {
+			Expect(variables.List()).To(Equal([]template.VariableDefinition{
+				{Name: "some-var"},
+				{Name: "shared-var"},
+				{Name: "other-var"},
+			}))
+		})
+
+		Context("when a manager fails", func() {
+			BeforeEach(func() {
+				secondVariables.ListReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				_, err := variables.List()
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+})
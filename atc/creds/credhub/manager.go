@@ -88,6 +88,10 @@ func (manager *CredHubManager) Init(log lager.Logger) error {
 	return nil
 }
 
+func (manager *CredHubManager) Close(logger lager.Logger) {
+	// nothing to clean up
+}
+
 func (manager CredHubManager) IsConfigured() bool {
 	return manager.URL != "" ||
 		manager.UAA.ClientId != "" ||
@@ -0,0 +1,278 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeLeasedVariables struct {
+	GetStub        func(template.VariableDefinition) (interface{}, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 template.VariableDefinition
+	}
+	getReturns struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}
+	GetLeasedStub        func(template.VariableDefinition) (interface{}, creds.Lease, bool, error)
+	getLeasedMutex       sync.RWMutex
+	getLeasedArgsForCall []struct {
+		arg1 template.VariableDefinition
+	}
+	getLeasedReturns struct {
+		result1 interface{}
+		result2 creds.Lease
+		result3 bool
+		result4 error
+	}
+	getLeasedReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 creds.Lease
+		result3 bool
+		result4 error
+	}
+	ListStub        func() ([]template.VariableDefinition, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+	}
+	listReturns struct {
+		result1 []template.VariableDefinition
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []template.VariableDefinition
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLeasedVariables) Get(arg1 template.VariableDefinition) (interface{}, bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 template.VariableDefinition
+	}{arg1})
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeLeasedVariables) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeLeasedVariables) GetCalls(stub func(template.VariableDefinition) (interface{}, bool, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeLeasedVariables) GetArgsForCall(i int) template.VariableDefinition {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasedVariables) GetReturns(result1 interface{}, result2 bool, result3 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLeasedVariables) GetReturnsOnCall(i int, result1 interface{}, result2 bool, result3 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLeasedVariables) GetLeased(arg1 template.VariableDefinition) (interface{}, creds.Lease, bool, error) {
+	fake.getLeasedMutex.Lock()
+	ret, specificReturn := fake.getLeasedReturnsOnCall[len(fake.getLeasedArgsForCall)]
+	fake.getLeasedArgsForCall = append(fake.getLeasedArgsForCall, struct {
+		arg1 template.VariableDefinition
+	}{arg1})
+	fake.recordInvocation("GetLeased", []interface{}{arg1})
+	fake.getLeasedMutex.Unlock()
+	if fake.GetLeasedStub != nil {
+		return fake.GetLeasedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	fakeReturns := fake.getLeasedReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeLeasedVariables) GetLeasedCallCount() int {
+	fake.getLeasedMutex.RLock()
+	defer fake.getLeasedMutex.RUnlock()
+	return len(fake.getLeasedArgsForCall)
+}
+
+func (fake *FakeLeasedVariables) GetLeasedCalls(stub func(template.VariableDefinition) (interface{}, creds.Lease, bool, error)) {
+	fake.getLeasedMutex.Lock()
+	defer fake.getLeasedMutex.Unlock()
+	fake.GetLeasedStub = stub
+}
+
+func (fake *FakeLeasedVariables) GetLeasedArgsForCall(i int) template.VariableDefinition {
+	fake.getLeasedMutex.RLock()
+	defer fake.getLeasedMutex.RUnlock()
+	argsForCall := fake.getLeasedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasedVariables) GetLeasedReturns(result1 interface{}, result2 creds.Lease, result3 bool, result4 error) {
+	fake.getLeasedMutex.Lock()
+	defer fake.getLeasedMutex.Unlock()
+	fake.GetLeasedStub = nil
+	fake.getLeasedReturns = struct {
+		result1 interface{}
+		result2 creds.Lease
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeLeasedVariables) GetLeasedReturnsOnCall(i int, result1 interface{}, result2 creds.Lease, result3 bool, result4 error) {
+	fake.getLeasedMutex.Lock()
+	defer fake.getLeasedMutex.Unlock()
+	fake.GetLeasedStub = nil
+	if fake.getLeasedReturnsOnCall == nil {
+		fake.getLeasedReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 creds.Lease
+			result3 bool
+			result4 error
+		})
+	}
+	fake.getLeasedReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 creds.Lease
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeLeasedVariables) List() ([]template.VariableDefinition, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+	}{})
+	fake.recordInvocation("List", []interface{}{})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLeasedVariables) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeLeasedVariables) ListCalls(stub func() ([]template.VariableDefinition, error)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *FakeLeasedVariables) ListReturns(result1 []template.VariableDefinition, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []template.VariableDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeasedVariables) ListReturnsOnCall(i int, result1 []template.VariableDefinition, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []template.VariableDefinition
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []template.VariableDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeasedVariables) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.getLeasedMutex.RLock()
+	defer fake.getLeasedMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLeasedVariables) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.LeasedVariables = new(FakeLeasedVariables)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeTeamCredentialManagers struct {
+	TeamCredentialManagerStub        func(string) (*atc.TeamCredentialManager, error)
+	teamCredentialManagerMutex       sync.RWMutex
+	teamCredentialManagerArgsForCall []struct {
+		arg1 string
+	}
+	teamCredentialManagerReturns struct {
+		result1 *atc.TeamCredentialManager
+		result2 error
+	}
+	teamCredentialManagerReturnsOnCall map[int]struct {
+		result1 *atc.TeamCredentialManager
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTeamCredentialManagers) TeamCredentialManager(arg1 string) (*atc.TeamCredentialManager, error) {
+	fake.teamCredentialManagerMutex.Lock()
+	ret, specificReturn := fake.teamCredentialManagerReturnsOnCall[len(fake.teamCredentialManagerArgsForCall)]
+	fake.teamCredentialManagerArgsForCall = append(fake.teamCredentialManagerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("TeamCredentialManager", []interface{}{arg1})
+	fake.teamCredentialManagerMutex.Unlock()
+	if fake.TeamCredentialManagerStub != nil {
+		return fake.TeamCredentialManagerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.teamCredentialManagerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamCredentialManagers) TeamCredentialManagerCallCount() int {
+	fake.teamCredentialManagerMutex.RLock()
+	defer fake.teamCredentialManagerMutex.RUnlock()
+	return len(fake.teamCredentialManagerArgsForCall)
+}
+
+func (fake *FakeTeamCredentialManagers) TeamCredentialManagerCalls(stub func(string) (*atc.TeamCredentialManager, error)) {
+	fake.teamCredentialManagerMutex.Lock()
+	defer fake.teamCredentialManagerMutex.Unlock()
+	fake.TeamCredentialManagerStub = stub
+}
+
+func (fake *FakeTeamCredentialManagers) TeamCredentialManagerArgsForCall(i int) string {
+	fake.teamCredentialManagerMutex.RLock()
+	defer fake.teamCredentialManagerMutex.RUnlock()
+	argsForCall := fake.teamCredentialManagerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeamCredentialManagers) TeamCredentialManagerReturns(result1 *atc.TeamCredentialManager, result2 error) {
+	fake.teamCredentialManagerMutex.Lock()
+	defer fake.teamCredentialManagerMutex.Unlock()
+	fake.TeamCredentialManagerStub = nil
+	fake.teamCredentialManagerReturns = struct {
+		result1 *atc.TeamCredentialManager
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamCredentialManagers) TeamCredentialManagerReturnsOnCall(i int, result1 *atc.TeamCredentialManager, result2 error) {
+	fake.teamCredentialManagerMutex.Lock()
+	defer fake.teamCredentialManagerMutex.Unlock()
+	fake.TeamCredentialManagerStub = nil
+	if fake.teamCredentialManagerReturnsOnCall == nil {
+		fake.teamCredentialManagerReturnsOnCall = make(map[int]struct {
+			result1 *atc.TeamCredentialManager
+			result2 error
+		})
+	}
+	fake.teamCredentialManagerReturnsOnCall[i] = struct {
+		result1 *atc.TeamCredentialManager
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamCredentialManagers) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.teamCredentialManagerMutex.RLock()
+	defer fake.teamCredentialManagerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTeamCredentialManagers) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.TeamCredentialManagers = new(FakeTeamCredentialManagers)
@@ -3,7 +3,7 @@ package creds
 import (
 	"encoding/json"
 
-	"github.com/cloudfoundry/bosh-cli/director/template"
+	atctemplate "github.com/concourse/concourse/atc/template"
 	"gopkg.in/yaml.v2"
 )
 
@@ -13,10 +13,13 @@ func evaluate(variablesResolver Variables, in, out interface{}) error {
 		return err
 	}
 
-	tpl := template.NewTemplate(byteParams)
+	// local vars only exist within a build; elsewhere (e.g. when validating
+	// credentials as a pipeline is saved) they are left as-is
+	_, resolvesLocalVars := variablesResolver.(LocalVarsResolver)
 
-	bytes, err := tpl.Evaluate(variablesResolver, nil, template.EvaluateOpts{
+	bytes, err := atctemplate.Interpolate(byteParams, variablesResolver, atctemplate.InterpolateOpts{
 		ExpectAllKeys: true,
+		LocalVars:     resolvesLocalVars,
 	})
 	if err != nil {
 		return err
@@ -1,27 +1,70 @@
 package kubernetes
 
 import (
+	"fmt"
+
 	"code.cloudfoundry.org/lager"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	v1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// MappingSecretPerVar looks up each var from a secret of its own, named
+	// <pipeline>.<var> or <var>.
+	MappingSecretPerVar = "secret-per-var"
+
+	// MappingSecretPerPipeline looks up each var as a key within a secret
+	// named after the pipeline, falling back to a secret shared by the team.
+	MappingSecretPerPipeline = "secret-per-pipeline"
+
+	// MappingLabelSelector looks up each var from the secret labelled with its
+	// name, preferring one also labelled with the pipeline's name.
+	MappingLabelSelector = "label-selector"
+)
+
+// SecretMapping configures how vars are mapped to secrets within a team's
+// namespace.
+type SecretMapping struct {
+	Strategy string
+
+	// used by MappingSecretPerPipeline
+	TeamSecretName string
+
+	// used by MappingLabelSelector
+	VarLabel      string
+	PipelineLabel string
+}
+
 type Kubernetes struct {
-	Clientset       *kubernetes.Clientset
+	Clientset       kubernetes.Interface
 	TeamName        string
 	PipelineName    string
 	NamespacePrefix string
+	Mapping         SecretMapping
 	logger          lager.Logger
 }
 
 func (k Kubernetes) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
 	var namespace = k.NamespacePrefix + k.TeamName
-	var pipelineSecretName = k.PipelineName + "." + varDef.Name
-	var secretName = varDef.Name
+
+	switch k.Mapping.Strategy {
+	case MappingSecretPerPipeline:
+		return k.getFromPipelineSecret(namespace, varDef.Name)
+	case MappingLabelSelector:
+		return k.getFromLabelledSecret(namespace, varDef.Name)
+	default:
+		return k.getFromVarSecret(namespace, varDef.Name)
+	}
+}
+
+func (k Kubernetes) getFromVarSecret(namespace string, varName string) (interface{}, bool, error) {
+	var pipelineSecretName = k.PipelineName + "." + varName
+	var secretName = varName
 
 	secret, found, err := k.findSecret(namespace, pipelineSecretName)
 
@@ -47,9 +90,98 @@ func (k Kubernetes) Get(varDef template.VariableDefinition) (interface{}, bool,
 		"pipelineSecretName": pipelineSecretName,
 		"secretName":         secretName,
 	})
+
+	return nil, false, nil
+}
+
+func (k Kubernetes) getFromPipelineSecret(namespace string, varName string) (interface{}, bool, error) {
+	secretNames := []string{}
+	if k.PipelineName != "" {
+		secretNames = append(secretNames, k.PipelineName)
+	}
+
+	if k.Mapping.TeamSecretName != "" {
+		secretNames = append(secretNames, k.Mapping.TeamSecretName)
+	}
+
+	for _, secretName := range secretNames {
+		secret, found, err := k.findSecret(namespace, secretName)
+		if err != nil {
+			k.logger.Error("k8s-secret-error", err, lager.Data{
+				"namespace":  namespace,
+				"secretName": secretName,
+			})
+			return nil, false, err
+		}
+
+		if !found {
+			continue
+		}
+
+		val, found := secret.Data[varName]
+		if found {
+			return string(val), true, nil
+		}
+	}
+
+	k.logger.Info("k8s-secret-key-not-found", lager.Data{
+		"namespace":   namespace,
+		"secretNames": secretNames,
+		"key":         varName,
+	})
+
 	return nil, false, nil
 }
 
+func (k Kubernetes) getFromLabelledSecret(namespace string, varName string) (interface{}, bool, error) {
+	// vars which can't be a label's value, e.g. some/path, can't be labelled
+	if len(validation.IsValidLabelValue(varName)) != 0 {
+		return nil, false, nil
+	}
+
+	secrets, err := k.Clientset.Core().Secrets(namespace).List(meta_v1.ListOptions{
+		LabelSelector: k.Mapping.VarLabel + "=" + varName,
+	})
+	if err != nil {
+		k.logger.Error("k8s-secret-error", err, lager.Data{
+			"namespace": namespace,
+			"var":       varName,
+		})
+		return nil, false, err
+	}
+
+	var pipelineSecrets, teamSecrets []v1.Secret
+	for _, secret := range secrets.Items {
+		pipelineName, labelled := secret.Labels[k.Mapping.PipelineLabel]
+		if !labelled {
+			teamSecrets = append(teamSecrets, secret)
+		} else if k.PipelineName != "" && pipelineName == k.PipelineName {
+			pipelineSecrets = append(pipelineSecrets, secret)
+		}
+	}
+
+	for _, candidates := range [][]v1.Secret{pipelineSecrets, teamSecrets} {
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return k.getValueFromSecret(&candidates[0])
+		default:
+			return nil, false, fmt.Errorf("found %d secrets labelled %s=%s in namespace '%s'", len(candidates), k.Mapping.VarLabel, varName, namespace)
+		}
+	}
+
+	k.logger.Info("k8s-secret-not-found", lager.Data{
+		"namespace": namespace,
+		"var":       varName,
+	})
+
+	return nil, false, nil
+}
+
+// getValueFromSecret returns the secret's 'value' key if it has one, and
+// otherwise all of its keys, which may be accessed as fields of the var,
+// e.g. ((some-secret.some-key)).
 func (k Kubernetes) getValueFromSecret(secret *v1.Secret) (interface{}, bool, error) {
 	val, found := secret.Data["value"]
 	if found {
@@ -81,6 +213,5 @@ func (k Kubernetes) findSecret(namespace, name string) (*v1.Secret, bool, error)
 
 func (k Kubernetes) List() ([]template.VariableDefinition, error) {
 	// Unimplemented for Kubernetes secrets
-
 	return []template.VariableDefinition{}, nil
 }
@@ -8,16 +8,18 @@ import (
 )
 
 type kubernetesFactory struct {
-	clientset       *kubernetes.Clientset
+	clientset       kubernetes.Interface
 	logger          lager.Logger
 	namespacePrefix string
+	mapping         SecretMapping
 }
 
-func NewKubernetesFactory(logger lager.Logger, clientset *kubernetes.Clientset, namespacePrefix string) *kubernetesFactory {
+func NewKubernetesFactory(logger lager.Logger, clientset kubernetes.Interface, namespacePrefix string, mapping SecretMapping) *kubernetesFactory {
 	factory := &kubernetesFactory{
 		clientset:       clientset,
 		logger:          logger,
 		namespacePrefix: namespacePrefix,
+		mapping:         mapping,
 	}
 
 	return factory
@@ -29,6 +31,7 @@ func (factory *kubernetesFactory) NewVariables(teamName string, pipelineName str
 		TeamName:        teamName,
 		PipelineName:    pipelineName,
 		NamespacePrefix: factory.namespacePrefix,
+		Mapping:         factory.mapping,
 		logger:          factory.logger,
 	}
 }
@@ -0,0 +1,198 @@
+package kubernetes_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	. "github.com/concourse/concourse/atc/creds/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func secret(name string, labels map[string]string, data map[string]string) *v1.Secret {
+	secret := &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: "concourse-some-team",
+			Name:      name,
+			Labels:    labels,
+		},
+		Data: map[string][]byte{},
+	}
+
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+
+	return secret
+}
+
+var _ = Describe("Kubernetes", func() {
+	var (
+		secrets []runtime.Object
+		mapping SecretMapping
+
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		secrets = nil
+		mapping = SecretMapping{
+			Strategy:       MappingSecretPerVar,
+			TeamSecretName: "concourse",
+			VarLabel:       "concourse.ci/var",
+			PipelineLabel:  "concourse.ci/pipeline",
+		}
+	})
+
+	JustBeforeEach(func() {
+		factory := NewKubernetesFactory(
+			lagertest.NewTestLogger("test"),
+			fake.NewSimpleClientset(secrets...),
+			"concourse-",
+			mapping,
+		)
+
+		variables = factory.NewVariables("some-team", "some-pipeline")
+	})
+
+	get := func(name string) (interface{}, bool, error) {
+		return variables.Get(template.VariableDefinition{Name: name})
+	}
+
+	Context("with the secret-per-var mapping", func() {
+		BeforeEach(func() {
+			secrets = []runtime.Object{
+				secret("some-pipeline.pipeline-var", nil, map[string]string{"value": "pipeline-value"}),
+				secret("pipeline-var", nil, map[string]string{"value": "team-value"}),
+				secret("team-var", nil, map[string]string{"value": "team-value"}),
+				secret("multi-key", nil, map[string]string{"username": "some-user", "tls.crt": "some-cert"}),
+			}
+		})
+
+		It("prefers the pipeline's secret over the team's", func() {
+			value, found, err := get("pipeline-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-value"))
+
+			value, found, err = get("team-var")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team-value"))
+		})
+
+		It("returns every key of secrets without a 'value' key", func() {
+			value, found, err := get("multi-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{
+				"username": "some-user",
+				"tls.crt":  "some-cert",
+			}))
+		})
+
+		It("does not find missing secrets", func() {
+			_, found, err := get("missing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("with the secret-per-pipeline mapping", func() {
+		BeforeEach(func() {
+			mapping.Strategy = MappingSecretPerPipeline
+
+			secrets = []runtime.Object{
+				secret("some-pipeline", nil, map[string]string{"shared": "pipeline-value", "pipeline-only": "some-value"}),
+				secret("concourse", nil, map[string]string{"shared": "team-value", "team-only": "other-value"}),
+			}
+		})
+
+		It("looks up vars as keys of the pipeline's secret, then the team's", func() {
+			value, found, err := get("shared")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-value"))
+
+			value, found, err = get("pipeline-only")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			value, found, err = get("team-only")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("other-value"))
+		})
+
+		It("does not find missing keys", func() {
+			_, found, err := get("missing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the pipeline has no secret", func() {
+			BeforeEach(func() {
+				secrets = secrets[1:]
+			})
+
+			It("falls back to the team's", func() {
+				value, found, err := get("shared")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("team-value"))
+			})
+		})
+	})
+
+	Context("with the label-selector mapping", func() {
+		BeforeEach(func() {
+			mapping.Strategy = MappingLabelSelector
+
+			secrets = []runtime.Object{
+				secret("a", map[string]string{"concourse.ci/var": "shared", "concourse.ci/pipeline": "some-pipeline"}, map[string]string{"value": "pipeline-value"}),
+				secret("b", map[string]string{"concourse.ci/var": "shared"}, map[string]string{"value": "team-value"}),
+				secret("c", map[string]string{"concourse.ci/var": "other", "concourse.ci/pipeline": "other-pipeline"}, map[string]string{"value": "other-value"}),
+				secret("d", map[string]string{"concourse.ci/var": "creds"}, map[string]string{"username": "some-user"}),
+				secret("e", map[string]string{"concourse.ci/var": "ambiguous"}, map[string]string{"value": "1"}),
+				secret("f", map[string]string{"concourse.ci/var": "ambiguous"}, map[string]string{"value": "2"}),
+			}
+		})
+
+		It("prefers the secret labelled with the pipeline", func() {
+			value, found, err := get("shared")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-value"))
+		})
+
+		It("ignores secrets labelled with other pipelines", func() {
+			_, found, err := get("other")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns every key of secrets without a 'value' key", func() {
+			value, found, err := get("creds")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{"username": "some-user"}))
+		})
+
+		It("fails when several secrets are labelled with the var", func() {
+			_, _, err := get("ambiguous")
+			Expect(err).To(MatchError("found 2 secrets labelled concourse.ci/var=ambiguous in namespace 'concourse-some-team'"))
+		})
+
+		It("does not find vars which cannot be a label's value", func() {
+			_, found, err := get("some/path")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -3,10 +3,13 @@ package kubernetes
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/concourse/atc/creds"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -16,6 +19,11 @@ type KubernetesManager struct {
 	InClusterConfig bool   `long:"in-cluster" description:"Enables the in-cluster client."`
 	ConfigPath      string `long:"config-path" description:"Path to Kubernetes config when running ATC outside Kubernetes."`
 	NamespacePrefix string `long:"namespace-prefix" default:"concourse-" description:"Prefix to use for Kubernetes namespaces under which secrets will be looked up."`
+
+	Mapping        string `long:"mapping" default:"secret-per-var" choice:"secret-per-var" choice:"secret-per-pipeline" choice:"label-selector" description:"How vars are mapped to secrets within a team's namespace: a secret per var, keys within a secret per pipeline, or secrets labelled with the var's name."`
+	TeamSecretName string `long:"team-secret-name" default:"concourse" description:"With the secret-per-pipeline mapping, secret whose keys are shared by all of a team's pipelines."`
+	VarLabel       string `long:"var-label" default:"concourse.ci/var" description:"With the label-selector mapping, label naming the var held by a secret."`
+	PipelineLabel  string `long:"pipeline-label" default:"concourse.ci/pipeline" description:"With the label-selector mapping, label restricting a secret to a single pipeline."`
 }
 
 func (manager *KubernetesManager) MarshalJSON() ([]byte, error) {
@@ -24,6 +32,7 @@ func (manager *KubernetesManager) MarshalJSON() ([]byte, error) {
 		"in_cluster_config": manager.InClusterConfig,
 		"config_path":       manager.ConfigPath,
 		"namespace_config":  manager.NamespacePrefix,
+		"mapping":           manager.Mapping,
 	})
 }
 
@@ -31,6 +40,10 @@ func (manager KubernetesManager) Init(log lager.Logger) error {
 	return nil
 }
 
+func (manager KubernetesManager) Close(logger lager.Logger) {
+	// nothing to clean up
+}
+
 func (manager KubernetesManager) IsConfigured() bool {
 	return manager.InClusterConfig || manager.ConfigPath != ""
 }
@@ -51,6 +64,16 @@ func (manager KubernetesManager) Validate() error {
 	if manager.InClusterConfig && manager.ConfigPath != "" {
 		return errors.New("Either in-cluster or config-path can be used, not both.")
 	}
+	if manager.Mapping == MappingLabelSelector {
+		if errs := validation.IsQualifiedName(manager.VarLabel); len(errs) != 0 {
+			return fmt.Errorf("invalid var label '%s': %s", manager.VarLabel, strings.Join(errs, ", "))
+		}
+
+		if errs := validation.IsQualifiedName(manager.PipelineLabel); len(errs) != 0 {
+			return fmt.Errorf("invalid pipeline label '%s': %s", manager.PipelineLabel, strings.Join(errs, ", "))
+		}
+	}
+
 	_, err := manager.buildConfig()
 	return err
 }
@@ -69,5 +92,10 @@ func (manager KubernetesManager) NewVariablesFactory(logger lager.Logger) (creds
 		return nil, err
 	}
 
-	return NewKubernetesFactory(logger, clientset, manager.NamespacePrefix), nil
+	return NewKubernetesFactory(logger, clientset, manager.NamespacePrefix, SecretMapping{
+		Strategy:       manager.Mapping,
+		TeamSecretName: manager.TeamSecretName,
+		VarLabel:       manager.VarLabel,
+		PipelineLabel:  manager.PipelineLabel,
+	}), nil
 }
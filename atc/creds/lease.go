@@ -0,0 +1,85 @@
+package creds
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+//go:generate counterfeiter . Lease
+
+// A Lease on a credential issued by a credential manager, e.g. one of Vault's
+// dynamic secrets, which is revoked once the build it was issued for has
+// finished.
+type Lease interface {
+	ID() string
+	Revoke() error
+}
+
+//go:generate counterfeiter . LeasedVariables
+
+// LeasedVariables are Variables which may issue a lease on the credentials
+// they return. Credentials which are not leased are returned with a nil
+// Lease.
+type LeasedVariables interface {
+	Variables
+
+	GetLeased(template.VariableDefinition) (interface{}, Lease, bool, error)
+}
+
+// GetLeased gets a credential along with its lease, if the variables are
+// LeasedVariables and the credential is leased.
+func GetLeased(variables Variables, varDef template.VariableDefinition) (interface{}, Lease, bool, error) {
+	if leased, ok := variables.(LeasedVariables); ok {
+		return leased.GetLeased(varDef)
+	}
+
+	value, found, err := variables.Get(varDef)
+	return value, nil, found, err
+}
+
+// Leases tracks the leases on the credentials resolved for a build, so that
+// they can be revoked once it has finished rather than left to expire.
+type Leases struct {
+	lock   sync.Mutex
+	leases []Lease
+}
+
+func NewLeases() *Leases {
+	return &Leases{}
+}
+
+// Track adds a lease to be revoked. It is safe to call on a nil Leases.
+func (leases *Leases) Track(lease Lease) {
+	if leases == nil || lease == nil {
+		return
+	}
+
+	leases.lock.Lock()
+	leases.leases = append(leases.leases, lease)
+	leases.lock.Unlock()
+}
+
+// Revoke revokes every tracked lease. Leases which fail to be revoked are
+// logged and left to expire. It is safe to call on a nil Leases.
+func (leases *Leases) Revoke(logger lager.Logger) {
+	if leases == nil {
+		return
+	}
+
+	leases.lock.Lock()
+	tracked := leases.leases
+	leases.leases = nil
+	leases.lock.Unlock()
+
+	for _, lease := range tracked {
+		err := lease.Revoke()
+		if err != nil {
+			logger.Error("failed-to-revoke-lease", err, lager.Data{"lease": lease.ID()})
+			continue
+		}
+
+		logger.Debug("revoked-lease", lager.Data{"lease": lease.ID()})
+	}
+}
@@ -0,0 +1,65 @@
+package creds_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Leases", func() {
+	var (
+		logger *lagertest.TestLogger
+		leases *creds.Leases
+
+		fakeLease1 *credsfakes.FakeLease
+		fakeLease2 *credsfakes.FakeLease
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		leases = creds.NewLeases()
+
+		fakeLease1 = new(credsfakes.FakeLease)
+		fakeLease1.IDReturns("lease-1")
+		fakeLease2 = new(credsfakes.FakeLease)
+		fakeLease2.IDReturns("lease-2")
+
+		leases.Track(fakeLease1)
+		leases.Track(nil)
+		leases.Track(fakeLease2)
+	})
+
+	It("revokes every tracked lease once", func() {
+		leases.Revoke(logger)
+		leases.Revoke(logger)
+
+		Expect(fakeLease1.RevokeCallCount()).To(Equal(1))
+		Expect(fakeLease2.RevokeCallCount()).To(Equal(1))
+	})
+
+	Context("when a lease fails to be revoked", func() {
+		BeforeEach(func() {
+			fakeLease1.RevokeReturns(errors.New("nope"))
+		})
+
+		It("logs the failure and revokes the rest", func() {
+			leases.Revoke(logger)
+
+			Expect(fakeLease2.RevokeCallCount()).To(Equal(1))
+			Expect(logger.LogMessages()).To(ContainElement("test.failed-to-revoke-lease"))
+		})
+	})
+
+	It("is safe to use when nil", func() {
+		var nilLeases *creds.Leases
+		nilLeases.Track(fakeLease1)
+		nilLeases.Revoke(logger)
+
+		Expect(fakeLease1.RevokeCallCount()).To(BeZero())
+	})
+})
@@ -1,15 +1,25 @@
 package creds
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	atctemplate "github.com/concourse/concourse/atc/template"
 	flags "github.com/jessevdk/go-flags"
 )
 
+//go:generate counterfeiter . Manager
+
 type Manager interface {
 	IsConfigured() bool
 	Validate() error
 	Health() (*HealthResponse, error)
 	Init(lager.Logger) error
+	Close(lager.Logger)
 
 	NewVariablesFactory(lager.Logger) (VariablesFactory, error)
 }
@@ -20,8 +30,103 @@ type ManagerFactory interface {
 
 type Managers map[string]Manager
 
+// Configured returns the names of the configured managers, in sorted order.
+func (managers Managers) Configured() []string {
+	configured := []string{}
+	for name, manager := range managers {
+		if manager.IsConfigured() {
+			configured = append(configured, name)
+		}
+	}
+
+	sort.Strings(configured)
+
+	return configured
+}
+
+// ValidateVarSources validates that any vars from a named credential manager,
+// e.g. ((vault:some/path)), refer to a manager which the team's credentials
+// are looked up from. Teams with their own credential manager may only refer
+// to that manager, and all other teams to the managers configured for the ATC.
+func (managers Managers) ValidateVarSources(config atc.Config, teamManager *atc.TeamCredentialManager) ([]string, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	configured := map[string]bool{}
+	if teamManager != nil {
+		configured[teamManager.Type] = true
+	} else {
+		for _, name := range managers.Configured() {
+			configured[name] = true
+		}
+	}
+
+	errorMessages := []string{}
+	for _, name := range atctemplate.SourceVarNames(payload) {
+		if !configured[name] {
+			errorMessages = append(errorMessages, UnknownVarSourceError{Source: name}.Error())
+		}
+	}
+
+	return errorMessages, nil
+}
+
+// LookupOrder returns the names of the configured managers in the order in
+// which credentials should be looked up from them.
+//
+// If no order is given, the configured managers are looked up in alphabetical
+// order, and a warning is logged if there is more than one. Otherwise, every
+// configured manager must appear in the order exactly once.
+func (managers Managers) LookupOrder(logger lager.Logger, order []string) ([]string, error) {
+	configured := managers.Configured()
+
+	if len(order) == 0 {
+		if len(configured) > 1 {
+			logger.Info("multiple-credential-managers-configured", lager.Data{
+				"lookup-order": configured,
+				"warning":      "credentials are looked up in alphabetical order of credential manager; specify the order with --credential-manager",
+			})
+		}
+
+		return configured, nil
+	}
+
+	ordered := map[string]bool{}
+	for _, name := range order {
+		manager, found := managers[name]
+		if !found {
+			return nil, fmt.Errorf("unknown credential manager '%s'", name)
+		}
+
+		if !manager.IsConfigured() {
+			return nil, fmt.Errorf("credential manager '%s' is not configured", name)
+		}
+
+		if ordered[name] {
+			return nil, fmt.Errorf("credential manager '%s' specified more than once", name)
+		}
+
+		ordered[name] = true
+	}
+
+	for _, name := range configured {
+		if !ordered[name] {
+			return nil, fmt.Errorf("credential manager '%s' is configured but not included in the lookup order", name)
+		}
+	}
+
+	return order, nil
+}
+
 type CredentialManagementConfig struct {
 	RetryConfig SecretRetryConfig
+	CacheConfig SecretCacheConfig
+
+	LookupOrder []string `long:"credential-manager" description:"Name of a configured credential manager to look up credentials from. Can be specified multiple times, in which case credentials not found in one manager are looked up in the next. Defaults to the configured managers in alphabetical order."`
+
+	TeamManagerCacheDuration time.Duration `long:"team-credential-manager-cache-duration" default:"1m" description:"How long to cache the credential manager config of each team before looking it up again."`
 }
 
 type HealthResponse struct {
@@ -0,0 +1,190 @@
+package creds_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managers", func() {
+	Describe("LookupOrder", func() {
+		var (
+			logger   *lagertest.TestLogger
+			managers creds.Managers
+
+			vaultManager      *credsfakes.FakeManager
+			kubernetesManager *credsfakes.FakeManager
+			credhubManager    *credsfakes.FakeManager
+
+			order []string
+
+			lookupOrder []string
+			lookupErr   error
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("test")
+
+			vaultManager = new(credsfakes.FakeManager)
+			kubernetesManager = new(credsfakes.FakeManager)
+			credhubManager = new(credsfakes.FakeManager)
+
+			managers = creds.Managers{
+				"vault":      vaultManager,
+				"kubernetes": kubernetesManager,
+				"credhub":    credhubManager,
+			}
+
+			order = nil
+		})
+
+		JustBeforeEach(func() {
+			lookupOrder, lookupErr = managers.LookupOrder(logger, order)
+		})
+
+		Context("when no manager is configured", func() {
+			It("returns no managers", func() {
+				Expect(lookupErr).ToNot(HaveOccurred())
+				Expect(lookupOrder).To(BeEmpty())
+			})
+		})
+
+		Context("when one manager is configured", func() {
+			BeforeEach(func() {
+				vaultManager.IsConfiguredReturns(true)
+			})
+
+			It("returns it", func() {
+				Expect(lookupErr).ToNot(HaveOccurred())
+				Expect(lookupOrder).To(Equal([]string{"vault"}))
+			})
+		})
+
+		Context("when multiple managers are configured", func() {
+			BeforeEach(func() {
+				vaultManager.IsConfiguredReturns(true)
+				kubernetesManager.IsConfiguredReturns(true)
+			})
+
+			It("returns them in alphabetical order", func() {
+				Expect(lookupErr).ToNot(HaveOccurred())
+				Expect(lookupOrder).To(Equal([]string{"kubernetes", "vault"}))
+			})
+
+			It("warns that no order was given", func() {
+				Expect(logger.LogMessages()).To(Equal([]string{"test.multiple-credential-managers-configured"}))
+			})
+
+			Context("when an order is given", func() {
+				BeforeEach(func() {
+					order = []string{"vault", "kubernetes"}
+				})
+
+				It("returns the managers in that order", func() {
+					Expect(lookupErr).ToNot(HaveOccurred())
+					Expect(lookupOrder).To(Equal([]string{"vault", "kubernetes"}))
+				})
+
+				It("does not warn", func() {
+					Expect(logger.LogMessages()).To(BeEmpty())
+				})
+			})
+
+			Context("when the order leaves out a configured manager", func() {
+				BeforeEach(func() {
+					order = []string{"vault"}
+				})
+
+				It("returns an error", func() {
+					Expect(lookupErr).To(MatchError("credential manager 'kubernetes' is configured but not included in the lookup order"))
+				})
+			})
+
+			Context("when the order includes a manager more than once", func() {
+				BeforeEach(func() {
+					order = []string{"vault", "kubernetes", "vault"}
+				})
+
+				It("returns an error", func() {
+					Expect(lookupErr).To(MatchError("credential manager 'vault' specified more than once"))
+				})
+			})
+
+			Context("when the order includes a manager which is not configured", func() {
+				BeforeEach(func() {
+					order = []string{"vault", "kubernetes", "credhub"}
+				})
+
+				It("returns an error", func() {
+					Expect(lookupErr).To(MatchError("credential manager 'credhub' is not configured"))
+				})
+			})
+
+			Context("when the order includes an unknown manager", func() {
+				BeforeEach(func() {
+					order = []string{"vault", "kubernetes", "bogus"}
+				})
+
+				It("returns an error", func() {
+					Expect(lookupErr).To(MatchError("unknown credential manager 'bogus'"))
+				})
+			})
+		})
+	})
+
+	Describe("ValidateVarSources", func() {
+		var managers creds.Managers
+
+		BeforeEach(func() {
+			vaultManager := new(credsfakes.FakeManager)
+			vaultManager.IsConfiguredReturns(true)
+
+			managers = creds.Managers{
+				"vault":   vaultManager,
+				"credhub": new(credsfakes.FakeManager),
+			}
+		})
+
+		config := atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name: "some-resource",
+					Type: "some-type",
+					Source: atc.Source{
+						"a": "((vault:some/path))",
+						"b": "((credhub:some/path))",
+						"c": "((bogus:some/path))",
+						"d": "((plain-var))",
+					},
+				},
+			},
+		}
+
+		It("reports vars from managers which are not configured", func() {
+			errorMessages, err := managers.ValidateVarSources(config, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errorMessages).To(ConsistOf(
+				"unknown credential manager 'credhub'",
+				"unknown credential manager 'bogus'",
+			))
+		})
+
+		Context("when the team has its own credential manager", func() {
+			It("reports vars from any other manager", func() {
+				errorMessages, err := managers.ValidateVarSources(config, &atc.TeamCredentialManager{
+					Type:   "credhub",
+					Config: map[string]interface{}{"url": "https://credhub.example.com"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(errorMessages).To(ConsistOf(
+					"unknown credential manager 'vault'",
+					"unknown credential manager 'bogus'",
+				))
+			})
+		})
+	})
+})
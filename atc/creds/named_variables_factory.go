@@ -0,0 +1,75 @@
+package creds
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	atctemplate "github.com/concourse/concourse/atc/template"
+)
+
+// UnknownVarSourceError is returned when a var refers to a credential manager
+// which has not been configured, e.g. ((bogus:some/path)).
+type UnknownVarSourceError struct {
+	Source string
+}
+
+func (err UnknownVarSourceError) Error() string {
+	return fmt.Sprintf("unknown credential manager '%s'", err.Source)
+}
+
+type NamedVariablesFactory struct {
+	factory VariablesFactory
+	named   map[string]VariablesFactory
+}
+
+type NamedVariables struct {
+	variables Variables
+	named     map[string]Variables
+}
+
+// NewNamedVariablesFactory constructs a VariablesFactory which looks up vars
+// prefixed with the name of a credential manager, e.g.
+// ((vault:some/path.field)), from that manager alone. All other vars are
+// looked up from the given factory.
+func NewNamedVariablesFactory(factory VariablesFactory, named map[string]VariablesFactory) VariablesFactory {
+	return &NamedVariablesFactory{factory: factory, named: named}
+}
+
+func (nvf NamedVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	named := make(map[string]Variables, len(nvf.named))
+	for name, factory := range nvf.named {
+		named[name] = factory.NewVariables(teamName, pipelineName)
+	}
+
+	return NamedVariables{
+		variables: nvf.factory.NewVariables(teamName, pipelineName),
+		named:     named,
+	}
+}
+
+func (nv NamedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, _, found, err := nv.GetLeased(varDef)
+	return value, found, err
+}
+
+func (nv NamedVariables) GetLeased(varDef template.VariableDefinition) (interface{}, Lease, bool, error) {
+	source, path, ok := atctemplate.ParseSourceVarName(varDef.Name)
+	if !ok {
+		return GetLeased(nv.variables, varDef)
+	}
+
+	variables, found := nv.named[source]
+	if !found {
+		return nil, nil, false, UnknownVarSourceError{Source: source}
+	}
+
+	return GetLeased(variables, template.VariableDefinition{
+		Name:    path,
+		Type:    varDef.Type,
+		Options: varDef.Options,
+	})
+}
+
+func (nv NamedVariables) List() ([]template.VariableDefinition, error) {
+	return nv.variables.List()
+}
@@ -0,0 +1,164 @@
+package creds_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Named Variables Factory", func() {
+	var (
+		defaultFactory *credsfakes.FakeVariablesFactory
+		vaultFactory   *credsfakes.FakeVariablesFactory
+
+		defaultVariables *credsfakes.FakeVariables
+		vaultVariables   *credsfakes.FakeVariables
+
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		defaultVariables = new(credsfakes.FakeVariables)
+		defaultFactory = new(credsfakes.FakeVariablesFactory)
+		defaultFactory.NewVariablesReturns(defaultVariables)
+
+		vaultVariables = new(credsfakes.FakeVariables)
+		vaultFactory = new(credsfakes.FakeVariablesFactory)
+		vaultFactory.NewVariablesReturns(vaultVariables)
+
+		factory := creds.NewNamedVariablesFactory(defaultFactory, map[string]creds.VariablesFactory{
+			"vault": vaultFactory,
+		})
+
+		variables = factory.NewVariables("some-team", "some-pipeline")
+	})
+
+	It("creates variables from each factory for the team and pipeline", func() {
+		teamName, pipelineName := defaultFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+
+		teamName, pipelineName = vaultFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+	})
+
+	Describe("Get", func() {
+		Context("when the var does not name a credential manager", func() {
+			BeforeEach(func() {
+				defaultVariables.GetReturns("default-value", true, nil)
+			})
+
+			It("looks it up from the default variables", func() {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("default-value"))
+
+				Expect(defaultVariables.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: "some-var"}))
+				Expect(vaultVariables.GetCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the var is a local var", func() {
+			It("looks it up from the default variables", func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: ".:some-var"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(defaultVariables.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: ".:some-var"}))
+			})
+		})
+
+		Context("when the var names a configured credential manager", func() {
+			BeforeEach(func() {
+				vaultVariables.GetReturns("vault-value", true, nil)
+			})
+
+			It("looks up its path from that manager alone", func() {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "vault:some/path"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("vault-value"))
+
+				Expect(vaultVariables.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: "some/path"}))
+				Expect(defaultVariables.GetCallCount()).To(BeZero())
+			})
+
+			Context("when the manager fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					vaultVariables.GetReturns(nil, false, disaster)
+				})
+
+				It("returns the error", func() {
+					_, _, err := variables.Get(template.VariableDefinition{Name: "vault:some/path"})
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when the var names an unknown credential manager", func() {
+			It("returns an error", func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: "bogus:some/path"})
+				Expect(err).To(Equal(creds.UnknownVarSourceError{Source: "bogus"}))
+				Expect(err).To(MatchError("unknown credential manager 'bogus'"))
+			})
+		})
+	})
+
+	Describe("Evaluate", func() {
+		BeforeEach(func() {
+			vaultVariables.GetReturns(map[interface{}]interface{}{
+				"username": "some-user",
+				"password": "some-password",
+			}, true, nil)
+
+			defaultVariables.GetReturns("default-value", true, nil)
+		})
+
+		It("interpolates vars from the named manager, including fields", func() {
+			source, err := creds.NewSource(variables, map[string]interface{}{
+				"username": "((vault:team/db.username))",
+				"uri":      "postgres://((vault:team/db.username)):((vault:team/db.password))@db",
+				"other":    "((some-var))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source).To(BeEquivalentTo(map[string]interface{}{
+				"username": "some-user",
+				"uri":      "postgres://some-user:some-password@db",
+				"other":    "default-value",
+			}))
+
+			Expect(vaultVariables.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: "team/db"}))
+		})
+
+		It("fails when a field is missing", func() {
+			_, err := creds.NewSource(variables, map[string]interface{}{
+				"token": "((vault:team/db.token))",
+			}).Evaluate()
+			Expect(err).To(MatchError("var 'vault:team/db' has no field 'token'"))
+		})
+
+		It("fails when the var is not found", func() {
+			vaultVariables.GetReturns(nil, false, nil)
+
+			_, err := creds.NewSource(variables, map[string]interface{}{
+				"token": "((vault:team/token))",
+			}).Evaluate()
+			Expect(err).To(MatchError("undefined var: vault:team/token"))
+		})
+
+		It("fails when the manager is unknown", func() {
+			_, err := creds.NewSource(variables, map[string]interface{}{
+				"token": "((bogus:team/token))",
+			}).Evaluate()
+			Expect(err).To(MatchError("unknown credential manager 'bogus'"))
+		})
+	})
+})
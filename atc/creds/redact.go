@@ -0,0 +1,131 @@
+package creds
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces each credential scrubbed from a build's logs.
+const RedactedValue = "((redacted))"
+
+// MinRedactedLength is the length below which credentials are not scrubbed.
+// Short values, e.g. "true" or "1", are likely to be credential fields other
+// than secrets, and would otherwise be scrubbed from all over the logs.
+const MinRedactedLength = 5
+
+// Redactor tracks the credentials resolved for a build, so that they can be
+// scrubbed from the build's logs.
+//
+// Each credential is scrubbed as-is, base64-encoded and JSON-escaped. The
+// lines of multi-line credentials, e.g. private keys, are also scrubbed
+// individually. Credentials, or lines, shorter than MinRedactedLength are not
+// scrubbed.
+type Redactor struct {
+	lock     sync.RWMutex
+	secrets  map[string]bool
+	replacer *strings.Replacer
+}
+
+func NewRedactor() *Redactor {
+	return &Redactor{
+		secrets: map[string]bool{},
+	}
+}
+
+// Track records the string values within a credential, including those
+// nested within maps and lists.
+func (redactor *Redactor) Track(value interface{}) {
+	switch typedValue := value.(type) {
+	case string:
+		redactor.add(typedValue)
+
+	case map[interface{}]interface{}:
+		for _, v := range typedValue {
+			redactor.Track(v)
+		}
+
+	case map[string]interface{}:
+		for _, v := range typedValue {
+			redactor.Track(v)
+		}
+
+	case []interface{}:
+		for _, v := range typedValue {
+			redactor.Track(v)
+		}
+	}
+}
+
+// Redact replaces every tracked credential within the text. A nil Redactor
+// leaves the text as-is.
+func (redactor *Redactor) Redact(text string) string {
+	if redactor == nil {
+		return text
+	}
+
+	redactor.lock.RLock()
+	replacer := redactor.replacer
+	redactor.lock.RUnlock()
+
+	if replacer == nil {
+		return text
+	}
+
+	return replacer.Replace(text)
+}
+
+func (redactor *Redactor) add(secret string) {
+	forms := []string{}
+	for _, s := range append([]string{secret}, strings.Split(secret, "\n")...) {
+		if len(strings.TrimSpace(s)) < MinRedactedLength {
+			continue
+		}
+
+		forms = append(forms, s, base64.StdEncoding.EncodeToString([]byte(s)))
+
+		escaped, err := json.Marshal(s)
+		if err == nil {
+			forms = append(forms, string(escaped[1:len(escaped)-1]))
+		}
+	}
+
+	redactor.lock.Lock()
+	defer redactor.lock.Unlock()
+
+	added := false
+	for _, form := range forms {
+		if !redactor.secrets[form] {
+			redactor.secrets[form] = true
+			added = true
+		}
+	}
+
+	if !added {
+		return
+	}
+
+	// the replacer prefers earlier arguments when matches start at the same
+	// position, so replace longer secrets first
+	secrets := []string{}
+	for s := range redactor.secrets {
+		secrets = append(secrets, s)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+
+		return secrets[i] < secrets[j]
+	})
+
+	oldnew := []string{}
+	for _, s := range secrets {
+		oldnew = append(oldnew, s, RedactedValue)
+	}
+
+	redactor.replacer = strings.NewReplacer(oldnew...)
+}
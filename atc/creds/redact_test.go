@@ -0,0 +1,72 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redactor", func() {
+	var redactor *creds.Redactor
+
+	BeforeEach(func() {
+		redactor = creds.NewRedactor()
+	})
+
+	It("leaves text as-is when nothing is tracked", func() {
+		Expect(redactor.Redact("some text")).To(Equal("some text"))
+	})
+
+	It("leaves text as-is when nil", func() {
+		var nilRedactor *creds.Redactor
+		Expect(nilRedactor.Redact("some text")).To(Equal("some text"))
+	})
+
+	Context("when credentials are tracked", func() {
+		BeforeEach(func() {
+			redactor.Track("some-password")
+			redactor.Track(map[interface{}]interface{}{
+				"username": "some-user",
+				"keys":     []interface{}{"key-\"a\"", "key-b"},
+				"port":     5432,
+			})
+			redactor.Track("-----BEGIN KEY-----\nabcdef\n-----END KEY-----")
+			redactor.Track("")
+			redactor.Track(map[string]interface{}{
+				"insecure": "true",
+				"index":    "1",
+			})
+		})
+
+		It("scrubs them, including those nested within maps and lists", func() {
+			Expect(redactor.Redact("login some-user:some-password with key-b")).To(Equal("login ((redacted)):((redacted)) with ((redacted))"))
+		})
+
+		It("scrubs them when base64-encoded", func() {
+			// base64 of "some-password"
+			Expect(redactor.Redact("auth c29tZS1wYXNzd29yZA==")).To(Equal("auth ((redacted))"))
+		})
+
+		It("scrubs them when JSON-escaped", func() {
+			Expect(redactor.Redact(`{"key":"key-\"a\""}`)).To(Equal(`{"key":"((redacted))"}`))
+		})
+
+		It("scrubs each line of multi-line credentials", func() {
+			Expect(redactor.Redact("abcdef\n")).To(Equal("((redacted))\n"))
+		})
+
+		It("prefers the longest match", func() {
+			redactor.Track("some-password-suffix")
+			Expect(redactor.Redact("some-password-suffix")).To(Equal("((redacted))"))
+		})
+
+		It("does not scrub short values", func() {
+			Expect(redactor.Redact("insecure: true, index: 1")).To(Equal("insecure: true, index: 1"))
+		})
+
+		It("does not scrub non-string values", func() {
+			Expect(redactor.Redact("port 5432")).To(Equal("port 5432"))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package secretsdir
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// Manager looks up credentials from a directory tree on the web node, e.g. a
+// mounted Kubernetes secret or a directory decrypted by sops.
+type Manager struct {
+	Path string `long:"path" description:"Directory from which to read credentials, laid out as TEAM/PIPELINE/NAME and TEAM/NAME. Each may be a file, a directory of fields, or a YAML document named NAME.yml."`
+
+	Store *Store
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	manager.Store = NewStore(manager.Path)
+	return nil
+}
+
+// Close stops watching the directory for changes.
+func (manager *Manager) Close(logger lager.Logger) {
+	if manager.Store == nil {
+		return
+	}
+
+	err := manager.Store.Close()
+	if err != nil {
+		logger.Error("failed-to-stop-watching-secrets", err)
+	}
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"path":   manager.Path,
+		"health": health,
+	})
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "ReadDir",
+	}
+
+	loadedAt, err := manager.Store.Loaded()
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status":    "UP",
+		"loaded_at": loadedAt.String(),
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Path != ""
+}
+
+func (manager *Manager) Validate() error {
+	info, err := os.Stat(manager.Path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", manager.Path)
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewVariablesFactory(log lager.Logger) (creds.VariablesFactory, error) {
+	err := manager.Store.Load()
+	if err != nil {
+		log.Error("failed-to-load-secrets", err)
+		return nil, err
+	}
+
+	err = manager.Store.Watch(log)
+	if err != nil {
+		log.Error("failed-to-watch-secrets", err)
+		return nil, err
+	}
+
+	return NewSecretsDirFactory(manager.Store), nil
+}
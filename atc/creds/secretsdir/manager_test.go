@@ -0,0 +1,89 @@
+package secretsdir_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds/secretsdir"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		root    string
+		manager secretsdir.Manager
+	)
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "secrets-dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		manager = secretsdir.Manager{}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	Describe("IsConfigured()", func() {
+		It("fails on an empty Manager", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes if Path is set", func() {
+			manager.Path = root
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		It("passes if the path is a directory", func() {
+			manager.Path = root
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("fails if the path does not exist", func() {
+			manager.Path = filepath.Join(root, "bogus")
+			Expect(manager.Validate()).To(HaveOccurred())
+		})
+
+		It("fails if the path is not a directory", func() {
+			writeFile(filepath.Join(root, "some-file"), "some-value")
+
+			manager.Path = filepath.Join(root, "some-file")
+			Expect(manager.Validate()).To(MatchError(ContainSubstring("is not a directory")))
+		})
+	})
+	Describe("Close()", func() {
+		It("does nothing if the manager was never initialized", func() {
+			manager.Close(lagertest.NewTestLogger("test"))
+		})
+
+		It("stops watching the directory", func() {
+			logger := lagertest.NewTestLogger("test")
+
+			writeFile(filepath.Join(root, "main", "some-file"), "some-value")
+
+			manager.Path = root
+			Expect(manager.Init(logger)).To(Succeed())
+
+			_, err := manager.NewVariablesFactory(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			manager.Close(logger)
+
+			writeFile(filepath.Join(root, "main", "some-file"), "some-new-value")
+
+			Consistently(func() interface{} {
+				value, _ := manager.Store.Get([]string{"main", "some-file"})
+				return value
+			}, 500*time.Millisecond).Should(Equal("some-value"))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package secretsdir
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+type SecretsDir struct {
+	store        *Store
+	TeamName     string
+	PipelineName string
+}
+
+func NewSecretsDir(store *Store, teamName string, pipelineName string) *SecretsDir {
+	return &SecretsDir{
+		store:        store,
+		TeamName:     teamName,
+		PipelineName: pipelineName,
+	}
+}
+
+// Get looks up the credential under the pipeline's directory, falling back
+// to the team's directory. The name may itself be a path, e.g. some/secret.
+func (s *SecretsDir) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	name := splitPath(varDef.Name)
+	if len(name) == 0 {
+		return nil, false, nil
+	}
+
+	if s.PipelineName != "" {
+		value, found := s.store.Get(append([]string{s.TeamName, s.PipelineName}, name...))
+		if found {
+			return value, true, nil
+		}
+	}
+
+	value, found := s.store.Get(append([]string{s.TeamName}, name...))
+	return value, found, nil
+}
+
+func (s *SecretsDir) List() ([]template.VariableDefinition, error) {
+	// not implemented, see vault implementation
+	return []template.VariableDefinition{}, nil
+}
+
+func splitPath(path string) []string {
+	segs := []string{}
+	for _, seg := range strings.Split(path, "/") {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+
+	return segs
+}
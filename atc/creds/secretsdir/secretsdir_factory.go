@@ -0,0 +1,19 @@
+package secretsdir
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type secretsDirFactory struct {
+	store *Store
+}
+
+func NewSecretsDirFactory(store *Store) *secretsDirFactory {
+	return &secretsDirFactory{
+		store: store,
+	}
+}
+
+func (factory *secretsDirFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	return NewSecretsDir(factory.store, teamName, pipelineName)
+}
@@ -0,0 +1,13 @@
+package secretsdir_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSecretsDir(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Secrets Directory Creds Suite")
+}
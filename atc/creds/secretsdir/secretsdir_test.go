@@ -0,0 +1,90 @@
+package secretsdir_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds/secretsdir"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretsDir", func() {
+	var (
+		root      string
+		store     *secretsdir.Store
+		variables *secretsdir.SecretsDir
+	)
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "secrets-dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		writeFile(filepath.Join(root, "main", "some-pipeline", "shadowed"), "pipeline-value")
+		writeFile(filepath.Join(root, "main", "shadowed"), "team-value")
+		writeFile(filepath.Join(root, "main", "team-only"), "team-value")
+		writeFile(filepath.Join(root, "main", "nested", "path", "secret"), "nested-value")
+		writeFile(filepath.Join(root, "other", "secret"), "other-team-value")
+
+		store = secretsdir.NewStore(root)
+		Expect(store.Load()).To(Succeed())
+
+		variables = secretsdir.NewSecretsDir(store, "main", "some-pipeline")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	get := func(name string) (interface{}, bool) {
+		value, found, err := variables.Get(template.VariableDefinition{Name: name})
+		Expect(err).NotTo(HaveOccurred())
+		return value, found
+	}
+
+	It("prefers the pipeline's secrets", func() {
+		value, found := get("shadowed")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("pipeline-value"))
+	})
+
+	It("falls back to the team's secrets", func() {
+		value, found := get("team-only")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-value"))
+	})
+
+	It("looks up nested paths", func() {
+		value, found := get("nested/path/secret")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("nested-value"))
+
+		value, found = get("/nested/path/secret")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("nested-value"))
+	})
+
+	It("does not find other teams' secrets", func() {
+		_, found := get("secret")
+		Expect(found).To(BeFalse())
+
+		_, found = get("../other/secret")
+		Expect(found).To(BeFalse())
+	})
+
+	Context("without a pipeline", func() {
+		BeforeEach(func() {
+			variables = secretsdir.NewSecretsDir(store, "main", "")
+		})
+
+		It("looks up the team's secrets", func() {
+			value, found := get("shadowed")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team-value"))
+		})
+	})
+})
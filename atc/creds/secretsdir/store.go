@@ -0,0 +1,287 @@
+package secretsdir
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"gopkg.in/fsnotify.v1"
+	"gopkg.in/yaml.v2"
+)
+
+// ReloadDelay is how long to wait for changes to the directory to settle
+// before reloading it.
+var ReloadDelay = 100 * time.Millisecond
+
+// Store holds the secrets read from a directory tree in memory, reloading
+// them whenever the tree changes.
+//
+// Each file in the tree is a secret whose value is the file's content, with
+// any trailing newline removed. Files named with a .yml or .yaml extension
+// are parsed as YAML instead, and are named without the extension. Each
+// directory is a secret whose value maps the names within it to their values,
+// so that e.g. a mounted Kubernetes secret can be referred to as
+// ((some-secret.some-key)). Hidden files and directories are ignored.
+type Store struct {
+	root string
+
+	lock     sync.RWMutex
+	secrets  map[interface{}]interface{}
+	dirs     []string
+	loadedAt time.Time
+	loadErr  error
+
+	watcher *fsnotify.Watcher
+}
+
+func NewStore(root string) *Store {
+	return &Store{
+		root:    root,
+		secrets: map[interface{}]interface{}{},
+	}
+}
+
+// Load reads the directory tree. If it fails, the secrets which were
+// previously loaded are kept.
+func (store *Store) Load() error {
+	dirs := []string{}
+	secrets, err := readDir(store.root, &dirs)
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.loadErr = err
+	if err != nil {
+		return err
+	}
+
+	store.secrets = secrets
+	store.dirs = dirs
+	store.loadedAt = time.Now()
+
+	return nil
+}
+
+// Loaded returns when the secrets were last loaded, and the error from the
+// last attempt to load them, if any.
+func (store *Store) Loaded() (time.Time, error) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	return store.loadedAt, store.loadErr
+}
+
+// Get returns the value at the given path within the tree.
+func (store *Store) Get(path []string) (interface{}, bool) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	var value interface{} = store.secrets
+	for _, seg := range path {
+		dir, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = dir[seg]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// Watch reloads the secrets in the background whenever the tree changes,
+// until the store is closed.
+func (store *Store) Watch(logger lager.Logger) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	store.watcher = watcher
+
+	err = store.addWatches()
+	if err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go store.watch(logger.Session("watch"))
+
+	return nil
+}
+
+// Close stops watching the tree.
+func (store *Store) Close() error {
+	if store.watcher == nil {
+		return nil
+	}
+
+	return store.watcher.Close()
+}
+
+func (store *Store) watch(logger lager.Logger) {
+	for {
+		select {
+		case _, ok := <-store.watcher.Events:
+			if !ok {
+				return
+			}
+
+			// changes tend to arrive in bursts, e.g. as kubernetes swaps the
+			// files of an updated secret, so wait for them to settle
+			if !store.settle() {
+				return
+			}
+
+			err := store.Load()
+			if err != nil {
+				logger.Error("failed-to-reload-secrets", err)
+				continue
+			}
+
+			logger.Info("reloaded-secrets")
+
+			err = store.addWatches()
+			if err != nil {
+				logger.Error("failed-to-watch-secrets", err)
+			}
+
+		case err, ok := <-store.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			logger.Error("failed-to-watch-secrets", err)
+		}
+	}
+}
+
+func (store *Store) settle() bool {
+	timer := time.NewTimer(ReloadDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case _, ok := <-store.watcher.Events:
+			if !ok {
+				return false
+			}
+
+			if !timer.Stop() {
+				<-timer.C
+			}
+
+			timer.Reset(ReloadDelay)
+
+		case <-timer.C:
+			return true
+		}
+	}
+}
+
+func (store *Store) addWatches() error {
+	store.lock.RLock()
+	dirs := store.dirs
+	store.lock.RUnlock()
+
+	for _, dir := range dirs {
+		err := store.watcher.Add(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readDir follows symlinks, as Kubernetes mounts each key of a secret as a
+// symlink into a hidden directory which is swapped out on update.
+func readDir(dir string, dirs *[]string) (map[interface{}]interface{}, error) {
+	*dirs = append(*dirs, dir)
+
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := f.Readdirnames(-1)
+	_ = f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := map[interface{}]interface{}{}
+	for _, name := range names {
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var key string
+		var value interface{}
+
+		switch ext := filepath.Ext(name); {
+		case info.IsDir():
+			key = name
+			value, err = readDir(path, dirs)
+
+		case ext == ".yml" || ext == ".yaml":
+			key = strings.TrimSuffix(name, ext)
+			value, err = readYAML(path)
+
+		default:
+			key = name
+			value, err = readFile(path)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, found := secrets[key]; found {
+			return nil, fmt.Errorf("conflicting secrets for %s", filepath.Join(dir, key))
+		}
+
+		secrets[key] = value
+	}
+
+	return secrets, nil
+}
+
+func readFile(path string) (interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.TrimSuffix(string(content), "\n"), nil
+}
+
+func readYAML(path string) (interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = yaml.Unmarshal(content, &value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	return value, nil
+}
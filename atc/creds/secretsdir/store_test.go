@@ -0,0 +1,180 @@
+package secretsdir_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds/secretsdir"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func writeFile(path string, content string) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+var _ = Describe("Store", func() {
+	var (
+		root  string
+		store *secretsdir.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "secrets-dir")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = secretsdir.NewStore(root)
+	})
+
+	AfterEach(func() {
+		Expect(store.Close()).To(Succeed())
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	Describe("Load", func() {
+		BeforeEach(func() {
+			writeFile(filepath.Join(root, "main", "some-file"), "some-value\n")
+			writeFile(filepath.Join(root, "main", "some-dir", "username"), "some-user")
+			writeFile(filepath.Join(root, "main", "some-dir", "password"), "some-password")
+			writeFile(filepath.Join(root, "main", "some-doc.yml"), "username: yaml-user\nport: 5432\n")
+			writeFile(filepath.Join(root, "main", ".hidden"), "hidden-value")
+		})
+
+		JustBeforeEach(func() {
+			Expect(store.Load()).To(Succeed())
+		})
+
+		It("reads files, removing the trailing newline", func() {
+			value, found := store.Get([]string{"main", "some-file"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		It("reads directories as maps of their contents", func() {
+			value, found := store.Get([]string{"main", "some-dir"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{
+				"username": "some-user",
+				"password": "some-password",
+			}))
+		})
+
+		It("parses YAML documents, named without their extension", func() {
+			value, found := store.Get([]string{"main", "some-doc"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{
+				"username": "yaml-user",
+				"port":     5432,
+			}))
+
+			value, found = store.Get([]string{"main", "some-doc", "username"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("yaml-user"))
+		})
+
+		It("ignores hidden files", func() {
+			_, found := store.Get([]string{"main", ".hidden"})
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find paths which are not in the tree", func() {
+			_, found := store.Get([]string{"main", "bogus"})
+			Expect(found).To(BeFalse())
+
+			_, found = store.Get([]string{"main", "some-file", "bogus"})
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when symlinks are present, as in a mounted kubernetes secret", func() {
+			BeforeEach(func() {
+				writeFile(filepath.Join(root, "other", "..data", "token"), "some-token")
+				Expect(os.Symlink(filepath.Join("..data", "token"), filepath.Join(root, "other", "token"))).To(Succeed())
+			})
+
+			It("follows them", func() {
+				value, found := store.Get([]string{"other", "token"})
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-token"))
+			})
+		})
+	})
+
+	Context("when two secrets have the same name", func() {
+		BeforeEach(func() {
+			writeFile(filepath.Join(root, "main", "some-secret"), "some-value")
+			writeFile(filepath.Join(root, "main", "some-secret.yml"), "some: value")
+		})
+
+		It("fails to load", func() {
+			Expect(store.Load()).To(MatchError(ContainSubstring("conflicting secrets")))
+
+			_, err := store.Loaded()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when a YAML document is malformed", func() {
+		BeforeEach(func() {
+			writeFile(filepath.Join(root, "main", "some-secret.yml"), "{")
+			Expect(store.Load()).To(HaveOccurred())
+		})
+
+		It("keeps the secrets which were previously loaded", func() {
+			Expect(os.Remove(filepath.Join(root, "main", "some-secret.yml"))).To(Succeed())
+			writeFile(filepath.Join(root, "main", "some-file"), "some-value")
+			Expect(store.Load()).To(Succeed())
+
+			writeFile(filepath.Join(root, "main", "some-secret.yml"), "{")
+			Expect(store.Load()).To(HaveOccurred())
+
+			value, found := store.Get([]string{"main", "some-file"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+	})
+
+	Describe("Watch", func() {
+		BeforeEach(func() {
+			secretsdir.ReloadDelay = 10 * time.Millisecond
+
+			writeFile(filepath.Join(root, "main", "some-file"), "some-value")
+
+			Expect(store.Load()).To(Succeed())
+			Expect(store.Watch(lagertest.NewTestLogger("test"))).To(Succeed())
+		})
+
+		get := func(path ...string) interface{} {
+			value, _ := store.Get(path)
+			return value
+		}
+
+		It("reloads changed files", func() {
+			writeFile(filepath.Join(root, "main", "some-file"), "some-new-value")
+
+			Eventually(func() interface{} { return get("main", "some-file") }).Should(Equal("some-new-value"))
+		})
+
+		It("reloads files added in new directories", func() {
+			writeFile(filepath.Join(root, "main", "some-pipeline", "some-file"), "pipeline-value")
+			Eventually(func() interface{} { return get("main", "some-pipeline", "some-file") }).Should(Equal("pipeline-value"))
+
+			writeFile(filepath.Join(root, "main", "some-pipeline", "other-file"), "other-value")
+			Eventually(func() interface{} { return get("main", "some-pipeline", "other-file") }).Should(Equal("other-value"))
+		})
+
+		It("removes deleted files", func() {
+			Expect(os.Remove(filepath.Join(root, "main", "some-file"))).To(Succeed())
+
+			Eventually(func() interface{} { return get("main", "some-file") }).Should(BeNil())
+		})
+	})
+})
@@ -64,6 +64,10 @@ func (manager *Manager) Init(log lager.Logger) error {
 	return nil
 }
 
+func (manager *Manager) Close(logger lager.Logger) {
+	// nothing to clean up
+}
+
 func (manager *Manager) Health() (*creds.HealthResponse, error) {
 	health := &creds.HealthResponse{
 		Method: "GetSecretValue",
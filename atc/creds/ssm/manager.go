@@ -78,6 +78,10 @@ func (manager *SsmManager) Init(log lager.Logger) error {
 	return nil
 }
 
+func (manager *SsmManager) Close(logger lager.Logger) {
+	// nothing to clean up
+}
+
 func (manager *SsmManager) getSession() (*session.Session, error) {
 
 	config := &aws.Config{Region: &manager.AwsRegion}
@@ -0,0 +1,494 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// TeamManagerPolicy restricts how teams may configure a credential manager.
+// Teams must not be able to read files on the web node, e.g. through a path
+// or CA cert option, or use the web node's own credentials, e.g. its IAM
+// role, so only the options which are known to be safe are allowed.
+type TeamManagerPolicy struct {
+	// Allowed lists the options a team may set.
+	Allowed []string
+
+	// Required lists the options a team must set, e.g. the URL of the
+	// server, rather than falling back to a default.
+	Required []string
+
+	// Validate checks the values of the options, if set.
+	Validate func(config map[string]interface{}) error
+}
+
+var teamManagerPolicies = map[string]TeamManagerPolicy{}
+
+// RegisterTeamManager allows teams to configure the named credential manager
+// within the given policy. Managers which are not registered can only be
+// configured for the whole ATC.
+func RegisterTeamManager(name string, policy TeamManagerPolicy) {
+	teamManagerPolicies[name] = policy
+}
+
+// ConfigureManager constructs a Manager of the given type, configured as if
+// its flags had been given the team's config, e.g. {"url": "..."} for
+// --vault-url. Lists are passed as a repeated flag, maps as a repeated flag
+// of NAME:VALUE pairs, and bools as a flag without an argument if true.
+//
+// The type and config must be allowed by the policy the manager was
+// registered with by RegisterTeamManager.
+func ConfigureManager(credentialManager atc.TeamCredentialManager) (Manager, error) {
+	factory, found := managerFactories[credentialManager.Type]
+	if !found {
+		return nil, fmt.Errorf("unknown credential manager type '%s'", credentialManager.Type)
+	}
+
+	err := checkTeamManagerPolicy(credentialManager)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := flags.NewNamedParser(credentialManager.Type, flags.None)
+	parser.NamespaceDelimiter = "-"
+
+	group, err := parser.AddGroup("Credential Management", "", &struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	manager := factory.AddConfig(group)
+
+	longNames := map[string]string{}
+	for _, subGroup := range group.Groups() {
+		for _, option := range subGroup.Options() {
+			longNames[option.LongName] = option.LongNameWithNamespace()
+		}
+	}
+
+	names := []string{}
+	for name := range credentialManager.Config {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	args := []string{}
+	for _, name := range names {
+		longName, found := longNames[name]
+		if !found {
+			return nil, fmt.Errorf("unknown option '%s' for credential manager '%s'", name, credentialManager.Type)
+		}
+
+		// bool flags take no argument
+		if enabled, ok := credentialManager.Config[name].(bool); ok {
+			if enabled {
+				args = append(args, "--"+longName)
+			}
+
+			continue
+		}
+
+		values, err := flagValues(credentialManager.Config[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid option '%s' for credential manager '%s': %s", name, credentialManager.Type, err)
+		}
+
+		for _, value := range values {
+			args = append(args, "--"+longName+"="+value)
+		}
+	}
+
+	_, err = parser.ParseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+func checkTeamManagerPolicy(credentialManager atc.TeamCredentialManager) error {
+	policy, found := teamManagerPolicies[credentialManager.Type]
+	if !found {
+		return fmt.Errorf("credential manager '%s' cannot be configured by teams", credentialManager.Type)
+	}
+
+	allowed := map[string]bool{}
+	for _, name := range policy.Allowed {
+		allowed[name] = true
+	}
+
+	names := []string{}
+	for name := range credentialManager.Config {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !allowed[name] {
+			return fmt.Errorf("option '%s' of credential manager '%s' cannot be configured by teams", name, credentialManager.Type)
+		}
+	}
+
+	for _, name := range policy.Required {
+		if _, found := credentialManager.Config[name]; !found {
+			return fmt.Errorf("credential manager '%s' requires option '%s'", credentialManager.Type, name)
+		}
+	}
+
+	if policy.Validate != nil {
+		return policy.Validate(credentialManager.Config)
+	}
+
+	return nil
+}
+
+func flagValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			itemValues, err := flagValues(item)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, itemValues...)
+		}
+
+		return values, nil
+
+	case map[string]interface{}:
+		keys := []string{}
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		values := []string{}
+		for _, key := range keys {
+			values = append(values, fmt.Sprintf("%s:%v", key, v[key]))
+		}
+
+		return values, nil
+
+	case string, float64, int:
+		return []string{fmt.Sprintf("%v", v)}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+//go:generate counterfeiter . TeamCredentialManagers
+
+// TeamCredentialManagers looks up the credential manager configured for a
+// team, if any.
+type TeamCredentialManagers interface {
+	TeamCredentialManager(teamName string) (*atc.TeamCredentialManager, error)
+}
+
+// TeamManagerVariablesFactory constructs the VariablesFactory for a team's
+// credential manager, e.g. wrapping it with retries and caching.
+type TeamManagerVariablesFactory func(lager.Logger, Manager) (VariablesFactory, error)
+
+type TeamVariablesFactory struct {
+	logger     lager.Logger
+	factory    VariablesFactory
+	teams      TeamCredentialManagers
+	newFactory TeamManagerVariablesFactory
+	clock      clock.Clock
+	ttl        time.Duration
+
+	lock      sync.Mutex
+	managers  map[string]cachedTeamCredentialManager
+	factories map[string]*teamVariablesFactory
+}
+
+type cachedTeamCredentialManager struct {
+	manager   *atc.TeamCredentialManager
+	expiresAt time.Time
+}
+
+type teamVariablesFactory struct {
+	config  string
+	manager *teamManager
+	factory VariablesFactory
+}
+
+// NewTeamVariablesFactory constructs a VariablesFactory which looks up the
+// credentials of teams with their own credential manager from that manager
+// alone. All other teams' credentials are looked up from the given factory.
+//
+// Each team's manager config is cached for the given TTL, rather than being
+// looked up whenever the team's credentials are. The manager is constructed
+// when its credentials are first looked up, and again whenever its config
+// changes. Managers are constructed without holding up other teams' lookups,
+// and a replaced manager is closed once no lookups are still using it.
+func NewTeamVariablesFactory(logger lager.Logger, factory VariablesFactory, teams TeamCredentialManagers, newFactory TeamManagerVariablesFactory, clock clock.Clock, ttl time.Duration) VariablesFactory {
+	return &TeamVariablesFactory{
+		logger:     logger,
+		factory:    factory,
+		teams:      teams,
+		newFactory: newFactory,
+		clock:      clock,
+		ttl:        ttl,
+
+		managers:  map[string]cachedTeamCredentialManager{},
+		factories: map[string]*teamVariablesFactory{},
+	}
+}
+
+func (tvf *TeamVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	factory, err := tvf.teamFactory(teamName)
+	if err != nil {
+		tvf.logger.Error("failed-to-configure-team-credential-manager", err, lager.Data{"team": teamName})
+		return failedVariables{err: fmt.Errorf("team credential manager misconfigured: %s", err)}
+	}
+
+	if factory == nil {
+		return tvf.factory.NewVariables(teamName, pipelineName)
+	}
+
+	return teamVariables{
+		factory:      tvf,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+
+		manager:   factory.manager,
+		variables: factory.factory.NewVariables(teamName, pipelineName),
+	}
+}
+
+func (tvf *TeamVariablesFactory) teamCredentialManager(teamName string) (*atc.TeamCredentialManager, error) {
+	tvf.lock.Lock()
+	cached, found := tvf.managers[teamName]
+	tvf.lock.Unlock()
+
+	if found && tvf.clock.Now().Before(cached.expiresAt) {
+		return cached.manager, nil
+	}
+
+	credentialManager, err := tvf.teams.TeamCredentialManager(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	tvf.lock.Lock()
+	tvf.managers[teamName] = cachedTeamCredentialManager{
+		manager:   credentialManager,
+		expiresAt: tvf.clock.Now().Add(tvf.ttl),
+	}
+	tvf.lock.Unlock()
+
+	return credentialManager, nil
+}
+
+// teamFactory returns the factory for the team's own credential manager, or
+// nil if it has none.
+func (tvf *TeamVariablesFactory) teamFactory(teamName string) (*teamVariablesFactory, error) {
+	credentialManager, err := tvf.teamCredentialManager(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if credentialManager == nil {
+		tvf.lock.Lock()
+		existing, found := tvf.factories[teamName]
+		delete(tvf.factories, teamName)
+		tvf.lock.Unlock()
+
+		if found {
+			existing.manager.retire()
+		}
+
+		return nil, nil
+	}
+
+	config, err := json.Marshal(credentialManager)
+	if err != nil {
+		return nil, err
+	}
+
+	tvf.lock.Lock()
+	existing, found := tvf.factories[teamName]
+	tvf.lock.Unlock()
+
+	if found && existing.config == string(config) {
+		return existing, nil
+	}
+
+	// the manager may authenticate over the network, e.g. with the team's
+	// vault, so it is constructed without holding the lock
+	factory, err := tvf.configure(teamName, *credentialManager)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.config = string(config)
+
+	tvf.lock.Lock()
+	existing, found = tvf.factories[teamName]
+	if found && existing.config == factory.config {
+		// configured by a concurrent lookup in the meantime
+		tvf.lock.Unlock()
+		factory.manager.retire()
+		return existing, nil
+	}
+
+	tvf.factories[teamName] = factory
+	tvf.lock.Unlock()
+
+	if found {
+		existing.manager.retire()
+	}
+
+	return factory, nil
+}
+
+func (tvf *TeamVariablesFactory) configure(teamName string, credentialManager atc.TeamCredentialManager) (*teamVariablesFactory, error) {
+	logger := tvf.logger.Session("team-credential-manager", lager.Data{
+		"team": teamName,
+		"type": credentialManager.Type,
+	})
+
+	manager, err := ConfigureManager(credentialManager)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Init(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	factory, err := tvf.newFactory(logger, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	// vars may name the team's manager as their source, e.g. ((vault:foo))
+	factory = NewNamedVariablesFactory(factory, map[string]VariablesFactory{
+		credentialManager.Type: factory,
+	})
+
+	logger.Info("configured")
+
+	return &teamVariablesFactory{
+		manager: &teamManager{manager: manager, logger: logger},
+		factory: factory,
+	}, nil
+}
+
+// teamManager counts the lookups using a team's credential manager, so that
+// once it has been replaced it is closed only after they have finished.
+type teamManager struct {
+	manager Manager
+	logger  lager.Logger
+
+	lock    sync.Mutex
+	lookups int
+	retired bool
+}
+
+// acquire counts a lookup, unless the manager has been replaced.
+func (m *teamManager) acquire() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.retired {
+		return false
+	}
+
+	m.lookups++
+
+	return true
+}
+
+func (m *teamManager) release() {
+	m.lock.Lock()
+	m.lookups--
+	closing := m.retired && m.lookups == 0
+	m.lock.Unlock()
+
+	if closing {
+		m.manager.Close(m.logger)
+	}
+}
+
+// retire prevents any more lookups, and closes the manager once those in
+// flight have finished.
+func (m *teamManager) retire() {
+	m.lock.Lock()
+	m.retired = true
+	closing := m.lookups == 0
+	m.lock.Unlock()
+
+	if closing {
+		m.manager.Close(m.logger)
+	}
+}
+
+// teamVariables look up credentials from the team's manager at the time they
+// were constructed. Once it has been replaced, they are looked up from the
+// team's current manager instead.
+type teamVariables struct {
+	factory      *TeamVariablesFactory
+	teamName     string
+	pipelineName string
+
+	manager   *teamManager
+	variables Variables
+}
+
+func (variables teamVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, _, found, err := variables.GetLeased(varDef)
+	return value, found, err
+}
+
+func (variables teamVariables) GetLeased(varDef template.VariableDefinition) (interface{}, Lease, bool, error) {
+	if !variables.manager.acquire() {
+		return GetLeased(variables.factory.NewVariables(variables.teamName, variables.pipelineName), varDef)
+	}
+
+	defer variables.manager.release()
+
+	return GetLeased(variables.variables, varDef)
+}
+
+func (variables teamVariables) List() ([]template.VariableDefinition, error) {
+	if !variables.manager.acquire() {
+		return variables.factory.NewVariables(variables.teamName, variables.pipelineName).List()
+	}
+
+	defer variables.manager.release()
+
+	return variables.variables.List()
+}
+
+type failedVariables struct {
+	err error
+}
+
+func (variables failedVariables) Get(template.VariableDefinition) (interface{}, bool, error) {
+	return nil, false, variables.err
+}
+
+func (variables failedVariables) List() ([]template.VariableDefinition, error) {
+	return nil, variables.err
+}
@@ -0,0 +1,393 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type teamTestManager struct {
+	credsfakes.FakeManager
+
+	URL    string            `long:"url"`
+	Paths  []string          `long:"path"`
+	Params map[string]string `long:"param"`
+	Cache  bool              `long:"cache"`
+}
+
+type teamTestManagerFactory struct{}
+
+func (teamTestManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &teamTestManager{}
+
+	subGroup, err := group.AddGroup("Team Test Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "team-test"
+
+	return manager
+}
+
+func init() {
+	creds.Register("team-test", teamTestManagerFactory{})
+	creds.RegisterTeamManager("team-test", creds.TeamManagerPolicy{
+		Allowed:  []string{"url", "path", "param", "cache"},
+		Required: []string{"url"},
+		Validate: func(config map[string]interface{}) error {
+			if config["url"] == "bogus" {
+				return errors.New("bogus url")
+			}
+
+			return nil
+		},
+	})
+
+	creds.Register("team-test-unsafe", teamTestManagerFactory{})
+}
+
+var _ = Describe("ConfigureManager", func() {
+	It("configures the manager's flags from the config", func() {
+		manager, err := creds.ConfigureManager(atc.TeamCredentialManager{
+			Type: "team-test",
+			Config: map[string]interface{}{
+				"url":   "https://vault.example.com",
+				"path":  []interface{}{"a", "b"},
+				"param": map[string]interface{}{"role_id": "some-role"},
+				"cache": true,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(manager).To(BeAssignableToTypeOf(&teamTestManager{}))
+
+		configured := manager.(*teamTestManager)
+		Expect(configured.URL).To(Equal("https://vault.example.com"))
+		Expect(configured.Paths).To(Equal([]string{"a", "b"}))
+		Expect(configured.Params).To(Equal(map[string]string{"role_id": "some-role"}))
+		Expect(configured.Cache).To(BeTrue())
+	})
+
+	It("fails for an unknown type", func() {
+		_, err := creds.ConfigureManager(atc.TeamCredentialManager{Type: "bogus"})
+		Expect(err).To(MatchError("unknown credential manager type 'bogus'"))
+	})
+
+	It("fails for a type which teams may not configure", func() {
+		_, err := creds.ConfigureManager(atc.TeamCredentialManager{
+			Type:   "team-test-unsafe",
+			Config: map[string]interface{}{"url": "https://vault.example.com"},
+		})
+		Expect(err).To(MatchError("credential manager 'team-test-unsafe' cannot be configured by teams"))
+	})
+
+	It("fails for an option which teams may not configure", func() {
+		_, err := creds.ConfigureManager(atc.TeamCredentialManager{
+			Type:   "team-test",
+			Config: map[string]interface{}{"url": "https://vault.example.com", "bogus": "value"},
+		})
+		Expect(err).To(MatchError("option 'bogus' of credential manager 'team-test' cannot be configured by teams"))
+	})
+
+	It("fails when a required option is missing", func() {
+		_, err := creds.ConfigureManager(atc.TeamCredentialManager{
+			Type:   "team-test",
+			Config: map[string]interface{}{"cache": true},
+		})
+		Expect(err).To(MatchError("credential manager 'team-test' requires option 'url'"))
+	})
+
+	It("fails when the policy rejects the config", func() {
+		_, err := creds.ConfigureManager(atc.TeamCredentialManager{
+			Type:   "team-test",
+			Config: map[string]interface{}{"url": "bogus"},
+		})
+		Expect(err).To(MatchError("bogus url"))
+	})
+})
+
+var _ = Describe("TeamVariablesFactory", func() {
+	var (
+		fakeFactory     *credsfakes.FakeVariablesFactory
+		fakeTeams       *credsfakes.FakeTeamCredentialManagers
+		fakeTeamFactory *credsfakes.FakeVariablesFactory
+		fakeVariables   *credsfakes.FakeVariables
+
+		newFactoryManagers []creds.Manager
+		newFactoryErr      error
+
+		fakeClock *fakeclock.FakeClock
+
+		factory creds.VariablesFactory
+	)
+
+	BeforeEach(func() {
+		fakeFactory = new(credsfakes.FakeVariablesFactory)
+		fakeTeams = new(credsfakes.FakeTeamCredentialManagers)
+
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeTeamFactory = new(credsfakes.FakeVariablesFactory)
+		fakeTeamFactory.NewVariablesReturns(fakeVariables)
+
+		newFactoryManagers = nil
+		newFactoryErr = nil
+
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		factory = creds.NewTeamVariablesFactory(
+			lagertest.NewTestLogger("test"),
+			fakeFactory,
+			fakeTeams,
+			func(_ lager.Logger, manager creds.Manager) (creds.VariablesFactory, error) {
+				newFactoryManagers = append(newFactoryManagers, manager)
+				return fakeTeamFactory, newFactoryErr
+			},
+			fakeClock,
+			time.Minute,
+		)
+	})
+
+	Context("when the team has no credential manager", func() {
+		It("uses the default factory", func() {
+			factory.NewVariables("some-team", "some-pipeline")
+
+			Expect(fakeFactory.NewVariablesCallCount()).To(Equal(1))
+			teamName, pipelineName := fakeFactory.NewVariablesArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+
+			Expect(newFactoryManagers).To(BeEmpty())
+		})
+	})
+
+	Context("when the team has a credential manager", func() {
+		BeforeEach(func() {
+			fakeTeams.TeamCredentialManagerReturns(&atc.TeamCredentialManager{
+				Type:   "team-test",
+				Config: map[string]interface{}{"url": "https://vault.example.com"},
+			}, nil)
+		})
+
+		It("uses the team's manager", func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+
+			value, found, err := factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(fakeFactory.NewVariablesCallCount()).To(BeZero())
+			Expect(fakeTeams.TeamCredentialManagerArgsForCall(0)).To(Equal("some-team"))
+
+			Expect(newFactoryManagers).To(HaveLen(1))
+			Expect(newFactoryManagers[0].(*teamTestManager).URL).To(Equal("https://vault.example.com"))
+		})
+
+		It("looks up vars naming the team's manager as their source from it", func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+
+			value, found, err := factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "team-test:some-var"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(fakeVariables.GetArgsForCall(0)).To(Equal(template.VariableDefinition{Name: "some-var"}))
+		})
+
+		It("caches the team's config until the TTL has passed", func() {
+			factory.NewVariables("some-team", "some-pipeline")
+			factory.NewVariables("some-team", "other-pipeline")
+			Expect(fakeTeams.TeamCredentialManagerCallCount()).To(Equal(1))
+
+			fakeClock.Increment(time.Minute)
+
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(fakeTeams.TeamCredentialManagerCallCount()).To(Equal(2))
+		})
+
+		It("constructs the manager again only once its config changes", func() {
+			factory.NewVariables("some-team", "some-pipeline")
+			fakeClock.Increment(time.Minute)
+			factory.NewVariables("some-team", "other-pipeline")
+			Expect(newFactoryManagers).To(HaveLen(1))
+
+			fakeTeams.TeamCredentialManagerReturns(&atc.TeamCredentialManager{
+				Type:   "team-test",
+				Config: map[string]interface{}{"url": "https://other-vault.example.com"},
+			}, nil)
+
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(newFactoryManagers).To(HaveLen(1))
+
+			fakeClock.Increment(time.Minute)
+
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(newFactoryManagers).To(HaveLen(2))
+			Expect(newFactoryManagers[1].(*teamTestManager).URL).To(Equal("https://other-vault.example.com"))
+
+			Expect(newFactoryManagers[0].(*teamTestManager).CloseCallCount()).To(Equal(1))
+			Expect(newFactoryManagers[1].(*teamTestManager).CloseCallCount()).To(BeZero())
+		})
+
+		It("closes the manager once the team's manager is removed", func() {
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(newFactoryManagers).To(HaveLen(1))
+
+			fakeTeams.TeamCredentialManagerReturns(nil, nil)
+			fakeClock.Increment(time.Minute)
+
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(newFactoryManagers[0].(*teamTestManager).CloseCallCount()).To(Equal(1))
+			Expect(fakeFactory.NewVariablesCallCount()).To(Equal(1))
+		})
+
+		It("closes a replaced manager only once the lookups using it have finished", func() {
+			variables := factory.NewVariables("some-team", "some-pipeline")
+
+			looking := make(chan struct{})
+			finish := make(chan struct{})
+			fakeVariables.GetStub = func(template.VariableDefinition) (interface{}, bool, error) {
+				close(looking)
+				<-finish
+				return "some-value", true, nil
+			}
+
+			lookedUp := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(lookedUp)
+
+				_, _, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			Eventually(looking).Should(BeClosed())
+
+			fakeTeams.TeamCredentialManagerReturns(&atc.TeamCredentialManager{
+				Type:   "team-test",
+				Config: map[string]interface{}{"url": "https://other-vault.example.com"},
+			}, nil)
+			fakeClock.Increment(time.Minute)
+
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(newFactoryManagers).To(HaveLen(2))
+			Expect(newFactoryManagers[0].(*teamTestManager).CloseCallCount()).To(BeZero())
+
+			close(finish)
+			Eventually(lookedUp).Should(BeClosed())
+
+			Expect(newFactoryManagers[0].(*teamTestManager).CloseCallCount()).To(Equal(1))
+		})
+
+		It("looks up credentials from the team's current manager once it has been replaced", func() {
+			variables := factory.NewVariables("some-team", "some-pipeline")
+
+			otherVariables := new(credsfakes.FakeVariables)
+			otherVariables.GetReturns("other-value", true, nil)
+
+			otherTeamFactory := new(credsfakes.FakeVariablesFactory)
+			otherTeamFactory.NewVariablesReturns(otherVariables)
+			fakeTeamFactory = otherTeamFactory
+
+			fakeTeams.TeamCredentialManagerReturns(&atc.TeamCredentialManager{
+				Type:   "team-test",
+				Config: map[string]interface{}{"url": "https://other-vault.example.com"},
+			}, nil)
+			fakeClock.Increment(time.Minute)
+
+			factory.NewVariables("some-team", "other-pipeline")
+			Expect(newFactoryManagers).To(HaveLen(2))
+
+			value, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("other-value"))
+
+			Expect(fakeVariables.GetCallCount()).To(BeZero())
+			Expect(newFactoryManagers[0].(*teamTestManager).CloseCallCount()).To(Equal(1))
+		})
+
+		It("does not hold up other teams while a team's manager is being constructed", func() {
+			fakeTeams.TeamCredentialManagerStub = func(teamName string) (*atc.TeamCredentialManager, error) {
+				return &atc.TeamCredentialManager{
+					Type:   "team-test",
+					Config: map[string]interface{}{"url": "https://" + teamName + ".example.com"},
+				}, nil
+			}
+
+			constructing := make(chan struct{})
+			finish := make(chan struct{})
+			newFactory := func(_ lager.Logger, manager creds.Manager) (creds.VariablesFactory, error) {
+				if manager.(*teamTestManager).URL == "https://slow-team.example.com" {
+					close(constructing)
+					<-finish
+				}
+
+				return fakeTeamFactory, nil
+			}
+
+			factory = creds.NewTeamVariablesFactory(lagertest.NewTestLogger("test"), fakeFactory, fakeTeams, newFactory, fakeClock, time.Minute)
+
+			constructed := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(constructed)
+
+				factory.NewVariables("slow-team", "some-pipeline")
+			}()
+
+			Eventually(constructing).Should(BeClosed())
+
+			fakeVariables.GetReturns("some-value", true, nil)
+
+			value, _, err := factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("some-value"))
+
+			close(finish)
+			Eventually(constructed).Should(BeClosed())
+		})
+
+		Context("when the manager fails to be constructed", func() {
+			BeforeEach(func() {
+				newFactoryErr = errors.New("nope")
+			})
+
+			It("fails to look up credentials rather than falling back", func() {
+				_, _, err := factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).To(MatchError("team credential manager misconfigured: nope"))
+
+				Expect(fakeFactory.NewVariablesCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the team's credential manager cannot be looked up", func() {
+		BeforeEach(func() {
+			fakeTeams.TeamCredentialManagerReturns(nil, errors.New("db down"))
+		})
+
+		It("fails to look up credentials", func() {
+			_, _, err := factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).To(MatchError("team credential manager misconfigured: db down"))
+		})
+
+		It("does not cache the failure", func() {
+			factory.NewVariables("some-team", "some-pipeline")
+			factory.NewVariables("some-team", "some-pipeline")
+			Expect(fakeTeams.TeamCredentialManagerCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package vault
 
 import (
+	"net/url"
 	"path"
 	"sync/atomic"
 	"time"
@@ -45,8 +46,27 @@ func NewAPIClient(logger lager.Logger, apiURL string, tlsConfig *vaultapi.TLSCon
 
 // Read must be called after a successful login has occurred or an
 // un-authorized client will be used.
+//
+// The path may be followed by a query, e.g. ?version=2, which is passed on to
+// vault.
 func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
-	return ac.client().Logical().Read(path)
+	path, query := splitQuery(path)
+	if query == "" {
+		return ac.client().Logical().Read(path)
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ac.client().Logical().ReadWithData(path, values)
+}
+
+// Revoke the lease on a secret, e.g. one issued by a dynamic secrets
+// engine.
+func (ac *APIClient) Revoke(leaseID string) error {
+	return ac.client().Sys().Revoke(leaseID)
 }
 
 func (ac *APIClient) loginParams() map[string]interface{} {
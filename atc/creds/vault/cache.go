@@ -15,6 +15,10 @@ type cachedSecret struct {
 // A Cache caches secrets read from a SecretReader until the lease on
 // the secret expires. Once expired the credential is proactively
 // deleted from cache to maintain a smaller cache footprint.
+//
+// Secrets issued with a lease ID, e.g. by a dynamic secrets engine,
+// are never cached, as each lease is revoked once the build it was
+// issued for has finished.
 type Cache struct {
 	sync.RWMutex
 	cache    map[string]*cachedSecret
@@ -91,7 +95,7 @@ func (c *Cache) Read(path string) (*vaultapi.Secret, error) {
 	// Otherwise fetch the secret using the client. Clients are
 	// thread safe for read use.
 	secret, err := c.sr.Read(path)
-	if err != nil || secret == nil {
+	if err != nil || secret == nil || secret.LeaseID != "" {
 		return secret, err
 	}
 
@@ -107,3 +107,34 @@ func TestCache(t *testing.T) {
 	cache.RUnlock()
 
 }
+
+func TestCacheSkipsLeasedSecrets(t *testing.T) {
+	msr := &MockSecretReader{
+		secrets: []*vaultapi.Secret{
+			&vaultapi.Secret{
+				LeaseID:       "database/creds/lease-1",
+				LeaseDuration: 10,
+			},
+			&vaultapi.Secret{
+				LeaseID:       "database/creds/lease-2",
+				LeaseDuration: 10,
+			},
+		},
+	}
+
+	cache := NewCache(msr, 0)
+
+	for _, leaseID := range []string{"database/creds/lease-1", "database/creds/lease-2"} {
+		secret, err := cache.Read("database/creds/role")
+		if err != nil {
+			t.Error("got error reading leased secret", err)
+		}
+		if secret.LeaseID != leaseID {
+			t.Errorf("read lease %s expected %s", secret.LeaseID, leaseID)
+		}
+	}
+
+	if len(msr.reads) != 2 {
+		t.Errorf("Got reads [%v], expected two reads of %s", msr.reads, "database/creds/role")
+	}
+}
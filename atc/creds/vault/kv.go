@@ -0,0 +1,128 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// mountsPath is the path from which the mount, and its options, to which a
+// path belongs can be read by any token with access to the path.
+const mountsPath = "sys/internal/ui/mounts/"
+
+type kvMount struct {
+	path    string
+	version string
+}
+
+// A KVReader is a SecretReader which reads secrets from KV secrets engine
+// mounts of either version, detecting the version of each mount on first use.
+//
+// Paths read from version 2 mounts have data/ inserted after the mount path,
+// and the secret's data is unwrapped from the versioned data. A path may be
+// suffixed with ?version=N to read a specific version of a secret, which is
+// only supported by version 2 mounts.
+type KVReader struct {
+	sr SecretReader
+
+	mountsL sync.RWMutex
+	mounts  []kvMount
+}
+
+// NewKVReader reading from the given SecretReader.
+func NewKVReader(sr SecretReader) *KVReader {
+	return &KVReader{
+		sr: sr,
+	}
+}
+
+func (kv *KVReader) Read(path string) (*vaultapi.Secret, error) {
+	path, query := splitQuery(strings.TrimPrefix(path, "/"))
+
+	mount, err := kv.mount(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if mount.version != "2" {
+		if query != "" {
+			return nil, fmt.Errorf("cannot read '%s?%s': secret versions are only supported by KV version 2 mounts", path, query)
+		}
+
+		return kv.sr.Read(path)
+	}
+
+	dataPath := mount.path + "data/" + strings.TrimPrefix(path, mount.path)
+	if query != "" {
+		dataPath += "?" + query
+	}
+
+	secret, err := kv.sr.Read(dataPath)
+	if err != nil || secret == nil {
+		return secret, err
+	}
+
+	// deleted and destroyed versions have no data
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	unwrapped := *secret
+	unwrapped.Data = data
+
+	return &unwrapped, nil
+}
+
+func (kv *KVReader) mount(path string) (kvMount, error) {
+	kv.mountsL.RLock()
+	for _, mount := range kv.mounts {
+		if strings.HasPrefix(path, mount.path) {
+			kv.mountsL.RUnlock()
+			return mount, nil
+		}
+	}
+	kv.mountsL.RUnlock()
+
+	mount := kvMount{version: "1"}
+
+	secret, err := kv.sr.Read(mountsPath + path)
+	if err != nil {
+		return kvMount{}, err
+	}
+
+	// older versions of vault do not have the mounts endpoint, in which case
+	// the mount is assumed to be version 1
+	if secret == nil {
+		return mount, nil
+	}
+
+	mount.path, _ = secret.Data["path"].(string)
+	if mount.path == "" {
+		return mount, nil
+	}
+
+	if options, ok := secret.Data["options"].(map[string]interface{}); ok {
+		if version, ok := options["version"].(string); ok && version != "" {
+			mount.version = version
+		}
+	}
+
+	kv.mountsL.Lock()
+	kv.mounts = append(kv.mounts, mount)
+	kv.mountsL.Unlock()
+
+	return mount, nil
+}
+
+// splitQuery splits a path from the query, e.g. version=2, following it.
+func splitQuery(path string) (string, string) {
+	i := strings.Index(path, "?")
+	if i == -1 {
+		return path, ""
+	}
+
+	return path[:i], path[i+1:]
+}
@@ -0,0 +1,187 @@
+package vault_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds/vault"
+	vaultapi "github.com/hashicorp/vault/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingSecretReader struct {
+	MockSecretReader
+
+	reads []string
+	err   error
+}
+
+func (rsr *recordingSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
+	rsr.reads = append(rsr.reads, lookupPath)
+
+	if rsr.err != nil {
+		return nil, rsr.err
+	}
+
+	return rsr.MockSecretReader.Read(lookupPath)
+}
+
+func mountSecret(path string, version string) *vaultapi.Secret {
+	return &vaultapi.Secret{
+		Data: map[string]interface{}{
+			"path":    path,
+			"type":    "kv",
+			"options": map[string]interface{}{"version": version},
+		},
+	}
+}
+
+var _ = Describe("KVReader", func() {
+	var rsr *recordingSecretReader
+	var v *vault.Vault
+
+	BeforeEach(func() {
+		rsr = &recordingSecretReader{
+			MockSecretReader: MockSecretReader{&[]MockSecret{}},
+		}
+
+		v = &vault.Vault{
+			SecretReader: vault.NewKVReader(rsr),
+			PathPrefix:   "/concourse",
+			TeamName:     "team",
+		}
+	})
+
+	Context("when the mount is KV version 1", func() {
+		BeforeEach(func() {
+			*rsr.secrets = []MockSecret{
+				{
+					path:   "sys/internal/ui/mounts/concourse/team/foo",
+					secret: mountSecret("concourse/", "1"),
+				},
+				{
+					path: "concourse/team/foo",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "bar"},
+					},
+				},
+			}
+		})
+
+		It("reads the secret from its path", func() {
+			value, found, err := v.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+		})
+
+		It("only detects the mount's version once", func() {
+			_, _, err := v.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = v.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rsr.reads).To(Equal([]string{
+				"sys/internal/ui/mounts/concourse/team/foo",
+				"concourse/team/foo",
+				"concourse/team/foo",
+			}))
+		})
+
+		It("fails to read a specific version", func() {
+			_, _, err := v.Get(template.VariableDefinition{Name: "foo?version=2"})
+			Expect(err).To(MatchError("cannot read 'concourse/team/foo?version=2': secret versions are only supported by KV version 2 mounts"))
+		})
+	})
+
+	Context("when the mount is KV version 2", func() {
+		BeforeEach(func() {
+			*rsr.secrets = []MockSecret{
+				{
+					path:   "sys/internal/ui/mounts/concourse/team/foo",
+					secret: mountSecret("concourse/", "2"),
+				},
+				{
+					path: "concourse/data/team/foo",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{
+							"data":     map[string]interface{}{"value": "bar"},
+							"metadata": map[string]interface{}{"version": "3"},
+						},
+					},
+				},
+				{
+					path: "concourse/data/team/foo?version=2",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{
+							"data":     map[string]interface{}{"username": "old-user"},
+							"metadata": map[string]interface{}{"version": "2"},
+						},
+					},
+				},
+				{
+					path: "concourse/data/team/foo?version=1",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{
+							"data":     nil,
+							"metadata": map[string]interface{}{"version": "1"},
+						},
+					},
+				},
+			}
+		})
+
+		It("reads the secret's data from the data path", func() {
+			value, found, err := v.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+		})
+
+		It("reads a specific version of the secret", func() {
+			value, found, err := v.Get(template.VariableDefinition{Name: "foo?version=2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{"username": "old-user"}))
+		})
+
+		It("does not find deleted versions", func() {
+			_, found, err := v.Get(template.VariableDefinition{Name: "foo?version=1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the mount cannot be found", func() {
+		BeforeEach(func() {
+			*rsr.secrets = []MockSecret{
+				{
+					path: "concourse/team/foo",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "bar"},
+					},
+				},
+			}
+		})
+
+		It("assumes KV version 1", func() {
+			value, found, err := v.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+		})
+	})
+
+	Context("when detecting the mount fails", func() {
+		BeforeEach(func() {
+			rsr.err = errors.New("nope")
+		})
+
+		It("returns the error", func() {
+			_, _, err := v.Get(template.VariableDefinition{Name: "foo"})
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
@@ -68,6 +68,10 @@ func (manager *VaultManager) Init(log lager.Logger) error {
 	return nil
 }
 
+func (manager *VaultManager) Close(logger lager.Logger) {
+	// nothing to clean up
+}
+
 func (manager *VaultManager) MarshalJSON() ([]byte, error) {
 	health, err := manager.Health()
 	if err != nil {
@@ -132,5 +136,7 @@ func (manager VaultManager) NewVariablesFactory(logger lager.Logger) (creds.Vari
 		sr = NewCache(manager.Client, manager.MaxLease)
 	}
 
-	return NewVaultFactory(sr, ra.LoggedIn(), manager.PathPrefix, manager.SharedPath), nil
+	sr = NewKVReader(sr)
+
+	return NewVaultFactory(sr, manager.Client, ra.LoggedIn(), manager.PathPrefix, manager.SharedPath), nil
 }
@@ -1,6 +1,9 @@
 package vault
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
 )
@@ -9,6 +12,51 @@ type vaultManagerFactory struct{}
 
 func init() {
 	creds.Register("vault", NewVaultManagerFactory())
+
+	// teams may not configure any option which reads files on the web node,
+	// e.g. ca-cert or client-key
+	creds.RegisterTeamManager("vault", creds.TeamManagerPolicy{
+		Allowed: []string{
+			"url",
+			"path-prefix",
+			"shared-path",
+			"cache",
+			"max-lease",
+			"server-name",
+			"insecure-skip-verify",
+			"client-token",
+			"auth-backend",
+			"auth-backend-max-ttl",
+			"auth-param",
+			"retry-max",
+			"retry-initial",
+		},
+		Required: []string{"url"},
+		Validate: validateTeamConfig,
+	})
+}
+
+// teamAuthBackends are the auth backends which only log in with the params
+// given to them. Others, e.g. aws or kubernetes, may log in with the web
+// node's own credentials.
+var teamAuthBackends = map[string]bool{
+	"approle":  true,
+	"userpass": true,
+	"ldap":     true,
+}
+
+func validateTeamConfig(config map[string]interface{}) error {
+	backend, _ := config["auth-backend"].(string)
+	if backend != "" && !teamAuthBackends[backend] {
+		return fmt.Errorf("auth backend '%s' cannot be configured by teams", backend)
+	}
+
+	token, _ := config["client-token"].(string)
+	if backend == "" && token == "" {
+		return errors.New("must configure client token or auth backend")
+	}
+
+	return nil
 }
 
 func NewVaultManagerFactory() creds.ManagerFactory {
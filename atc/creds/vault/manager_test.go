@@ -1,6 +1,8 @@
 package vault_test
 
 import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/vault"
 	"github.com/jessevdk/go-flags"
 
@@ -56,4 +58,48 @@ var _ = Describe("VaultManager", func() {
 			Expect(manager.Validate()).ToNot(BeNil())
 		})
 	})
+
+	Describe("when configured by a team", func() {
+		var config map[string]interface{}
+
+		BeforeEach(func() {
+			config = map[string]interface{}{
+				"url":          "https://vault.example.com",
+				"auth-backend": "approle",
+				"auth-param":   map[string]interface{}{"role_id": "some-role", "secret_id": "some-secret"},
+			}
+		})
+
+		configure := func() error {
+			_, err := creds.ConfigureManager(atc.TeamCredentialManager{Type: "vault", Config: config})
+			return err
+		}
+
+		It("allows an explicit URL and auth", func() {
+			Expect(configure()).To(Succeed())
+		})
+
+		It("requires a URL", func() {
+			delete(config, "url")
+			Expect(configure()).To(MatchError("credential manager 'vault' requires option 'url'"))
+		})
+
+		It("does not allow options which read files on the web node", func() {
+			config["ca-cert"] = "/etc/ssl/private/key.pem"
+			Expect(configure()).To(MatchError("option 'ca-cert' of credential manager 'vault' cannot be configured by teams"))
+		})
+
+		It("does not allow auth backends which may use the web node's credentials", func() {
+			config["auth-backend"] = "aws"
+			Expect(configure()).To(MatchError("auth backend 'aws' cannot be configured by teams"))
+		})
+
+		It("requires a client token or auth backend", func() {
+			delete(config, "auth-backend")
+			Expect(configure()).To(MatchError("must configure client token or auth backend"))
+
+			config["client-token"] = "some-token"
+			Expect(configure()).To(Succeed())
+		})
+	})
 })
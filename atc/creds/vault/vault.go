@@ -4,6 +4,7 @@ import (
 	"path"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
 	vaultapi "github.com/hashicorp/vault/api"
 )
 
@@ -13,10 +14,22 @@ type SecretReader interface {
 	Read(path string) (*vaultapi.Secret, error)
 }
 
+// A LeaseRevoker revokes the lease on a vault secret, e.g. one issued
+// by a dynamic secrets engine.
+type LeaseRevoker interface {
+	Revoke(leaseID string) error
+}
+
 // Vault converts a vault secret to our completely untyped secret
 // data.
+//
+// Secrets issued with a lease, e.g. by the database or aws secrets
+// engines, are returned along with the lease by GetLeased so that it
+// can be revoked once the build has finished. Leases on secrets
+// returned by Get are left to expire.
 type Vault struct {
 	SecretReader SecretReader
+	LeaseRevoker LeaseRevoker
 
 	PathPrefix   string
 	SharedPath   string
@@ -25,6 +38,11 @@ type Vault struct {
 }
 
 func (v Vault) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, _, found, err := v.GetLeased(varDef)
+	return value, found, err
+}
+
+func (v Vault) GetLeased(varDef template.VariableDefinition) (interface{}, creds.Lease, bool, error) {
 	var secret *vaultapi.Secret
 	var found bool
 	var err error
@@ -32,31 +50,39 @@ func (v Vault) Get(varDef template.VariableDefinition) (interface{}, bool, error
 	if v.PipelineName != "" {
 		secret, found, err = v.findSecret(v.path(v.TeamName, v.PipelineName, varDef.Name))
 		if err != nil {
-			return nil, false, err
+			return nil, nil, false, err
 		}
 	}
 
 	if !found {
 		secret, found, err = v.findSecret(v.path(v.TeamName, varDef.Name))
 		if err != nil {
-			return nil, false, err
+			return nil, nil, false, err
 		}
 	}
 
 	if !found && v.SharedPath != "" {
 		secret, found, err = v.findSecret(v.path(v.SharedPath, varDef.Name))
 		if err != nil {
-			return nil, false, err
+			return nil, nil, false, err
 		}
 	}
 
 	if !found {
-		return nil, false, nil
+		return nil, nil, false, nil
+	}
+
+	var lease creds.Lease
+	if secret.LeaseID != "" && v.LeaseRevoker != nil {
+		lease = vaultLease{
+			id:      secret.LeaseID,
+			revoker: v.LeaseRevoker,
+		}
 	}
 
 	val, found := secret.Data["value"]
 	if found {
-		return val, true, nil
+		return val, lease, true, nil
 	}
 
 	evenLessTyped := map[interface{}]interface{}{}
@@ -64,7 +90,7 @@ func (v Vault) Get(varDef template.VariableDefinition) (interface{}, bool, error
 		evenLessTyped[k] = v
 	}
 
-	return evenLessTyped, true, nil
+	return evenLessTyped, lease, true, nil
 }
 
 func (v Vault) findSecret(path string) (*vaultapi.Secret, bool, error) {
@@ -80,6 +106,19 @@ func (v Vault) findSecret(path string) (*vaultapi.Secret, bool, error) {
 	return nil, false, nil
 }
 
+type vaultLease struct {
+	id      string
+	revoker LeaseRevoker
+}
+
+func (lease vaultLease) ID() string {
+	return lease.id
+}
+
+func (lease vaultLease) Revoke() error {
+	return lease.revoker.Revoke(lease.id)
+}
+
 func (v Vault) path(segments ...string) string {
 	return path.Join(append([]string{v.PathPrefix}, segments...)...)
 }
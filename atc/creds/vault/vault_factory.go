@@ -9,14 +9,16 @@ import (
 // The vaultFactory will return a vault implementation of creds.Variables.
 type vaultFactory struct {
 	sr       SecretReader
+	lr       LeaseRevoker
 	prefix   string
 	sharedPath string
 	loggedIn <-chan struct{}
 }
 
-func NewVaultFactory(sr SecretReader, loggedIn <-chan struct{}, prefix string, sharedPath string) *vaultFactory {
+func NewVaultFactory(sr SecretReader, lr LeaseRevoker, loggedIn <-chan struct{}, prefix string, sharedPath string) *vaultFactory {
 	factory := &vaultFactory{
 		sr:       sr,
+		lr:       lr,
 		prefix:   prefix,
 		sharedPath: sharedPath,
 		loggedIn: loggedIn,
@@ -35,6 +37,7 @@ func (factory *vaultFactory) NewVariables(teamName string, pipelineName string)
 
 	return &Vault{
 		SecretReader: factory.sr,
+		LeaseRevoker: factory.lr,
 		PathPrefix:   factory.prefix,
 		SharedPath:   factory.sharedPath,
 		TeamName:     teamName,
@@ -29,6 +29,15 @@ func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
 	return nil, nil
 }
 
+type MockLeaseRevoker struct {
+	revoked []string
+}
+
+func (mlr *MockLeaseRevoker) Revoke(leaseID string) error {
+	mlr.revoked = append(mlr.revoked, leaseID)
+	return nil
+}
+
 var _ = Describe("Vault", func() {
 
 	var v *vault.Vault
@@ -122,4 +131,48 @@ var _ = Describe("Vault", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Describe("GetLeased()", func() {
+		var revoker *MockLeaseRevoker
+
+		JustBeforeEach(func() {
+			revoker = &MockLeaseRevoker{}
+			v.LeaseRevoker = revoker
+			v.SecretReader = &MockSecretReader{&[]MockSecret{
+				{
+					path: "/concourse/team/db",
+					secret: &vaultapi.Secret{
+						LeaseID: "database/creds/team/some-lease",
+						Data:    map[string]interface{}{"username": "some-user"},
+					},
+				},
+				{
+					path: "/concourse/team/foo",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "bar"},
+					},
+				}},
+			}
+		})
+
+		It("should return the lease on a dynamic secret", func() {
+			value, lease, found, err := v.GetLeased(template.VariableDefinition{Name: "db"})
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{"username": "some-user"}))
+			Expect(lease).ToNot(BeNil())
+			Expect(lease.ID()).To(Equal("database/creds/team/some-lease"))
+
+			Expect(lease.Revoke()).To(Succeed())
+			Expect(revoker.revoked).To(Equal([]string{"database/creds/team/some-lease"}))
+		})
+
+		It("should not return a lease on a static secret", func() {
+			value, lease, found, err := v.GetLeased(template.VariableDefinition{Name: "foo"})
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo("bar"))
+			Expect(lease).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,99 @@
+package algorithm
+
+import "sort"
+
+// InputExplanation describes the versions which are candidates for an input,
+// and which of the jobs in its passed constraints each of them has yet to
+// make it through.
+type InputExplanation struct {
+	Name       string
+	Candidates []CandidateExplanation
+}
+
+type CandidateExplanation struct {
+	VersionID int
+
+	// NotPassed lists the IDs of the jobs in the input's passed constraints
+	// which have not had a successful build with the version, in ascending
+	// order.
+	NotPassed []int
+}
+
+// Satisfied returns the IDs of the candidates which have passed every job
+// in the input's passed constraints, newest first.
+func (explanation InputExplanation) Satisfied() []int {
+	satisfied := []int{}
+	for _, candidate := range explanation.Candidates {
+		if len(candidate.NotPassed) == 0 {
+			satisfied = append(satisfied, candidate.VersionID)
+		}
+	}
+
+	return satisfied
+}
+
+// Explain determines the candidates for the input, newest first, without
+// resolving it against any other inputs of the job.
+//
+// Inputs with passed constraints consider every version of the resource, so
+// that those which have been eliminated by them can be reported.
+func (config InputConfig) Explain(db *VersionsDB) InputExplanation {
+	versionCandidates := VersionCandidates{}
+
+	switch {
+	case config.PinnedVersionID != 0:
+		candidate, found := db.FindVersionOfResource(config.ResourceID, config.PinnedVersionID)
+		if found {
+			versionCandidates.Add(candidate)
+		}
+
+	case config.UseEveryVersion || len(config.Passed) != 0:
+		versionCandidates = db.AllVersionsOfResource(config.ResourceID)
+
+	default:
+		candidate, found := db.LatestVersionOfResource(config.ResourceID)
+		if found {
+			versionCandidates.Add(candidate)
+		}
+	}
+
+	passedJobIDs := []int{}
+	passedVersions := map[int]map[int]bool{}
+	for jobID := range config.Passed {
+		passedJobIDs = append(passedJobIDs, jobID)
+		passedVersions[jobID] = map[int]bool{}
+	}
+
+	sort.Ints(passedJobIDs)
+
+	for _, output := range db.BuildOutputs {
+		versions, found := passedVersions[output.JobID]
+		if found && output.ResourceID == config.ResourceID {
+			versions[output.VersionID] = true
+		}
+	}
+
+	explanation := InputExplanation{
+		Name:       config.Name,
+		Candidates: []CandidateExplanation{},
+	}
+
+	versionIDs := versionCandidates.VersionIDs()
+	for {
+		versionID, ok := versionIDs.Next()
+		if !ok {
+			break
+		}
+
+		candidate := CandidateExplanation{VersionID: versionID}
+		for _, jobID := range passedJobIDs {
+			if !passedVersions[jobID][versionID] {
+				candidate.NotPassed = append(candidate.NotPassed, jobID)
+			}
+		}
+
+		explanation.Candidates = append(explanation.Candidates, candidate)
+	}
+
+	return explanation
+}
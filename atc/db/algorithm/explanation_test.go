@@ -0,0 +1,141 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB  *algorithm.VersionsDB
+		inputConfig algorithm.InputConfig
+		explanation algorithm.InputExplanation
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 22, CheckOrder: 4},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         32,
+					JobID:           12,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 4, ResourceID: 22, CheckOrder: 4},
+					BuildID:         34,
+					JobID:           12,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12, "j3": 13},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22},
+		}
+
+		inputConfig = algorithm.InputConfig{
+			Name:       "some-input",
+			JobName:    "j3",
+			Passed:     algorithm.JobSet{},
+			ResourceID: 21,
+			JobID:      13,
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanation = inputConfig.Explain(versionsDB)
+	})
+
+	Context("when the input has no passed constraints", func() {
+		It("has only the latest version as a candidate", func() {
+			Expect(explanation).To(Equal(algorithm.InputExplanation{
+				Name: "some-input",
+				Candidates: []algorithm.CandidateExplanation{
+					{VersionID: 3},
+				},
+			}))
+			Expect(explanation.Satisfied()).To(Equal([]int{3}))
+		})
+
+		Context("when every version is used", func() {
+			BeforeEach(func() {
+				inputConfig.UseEveryVersion = true
+			})
+
+			It("has every version as a candidate, newest first", func() {
+				Expect(explanation.Candidates).To(Equal([]algorithm.CandidateExplanation{
+					{VersionID: 3},
+					{VersionID: 2},
+					{VersionID: 1},
+				}))
+			})
+		})
+
+		Context("when the resource has no versions", func() {
+			BeforeEach(func() {
+				inputConfig.ResourceID = 23
+			})
+
+			It("has no candidates", func() {
+				Expect(explanation.Candidates).To(BeEmpty())
+				Expect(explanation.Satisfied()).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when the input has passed constraints", func() {
+		BeforeEach(func() {
+			inputConfig.Passed = algorithm.JobSet{11: {}, 12: {}}
+		})
+
+		It("reports the jobs each version has not passed", func() {
+			Expect(explanation.Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 3, NotPassed: []int{11, 12}},
+				{VersionID: 2, NotPassed: []int{12}},
+				{VersionID: 1},
+			}))
+		})
+
+		It("is satisfied by the versions which passed every job", func() {
+			Expect(explanation.Satisfied()).To(Equal([]int{1}))
+		})
+
+		Context("when the input is pinned", func() {
+			BeforeEach(func() {
+				inputConfig.PinnedVersionID = 2
+			})
+
+			It("only has the pinned version as a candidate", func() {
+				Expect(explanation.Candidates).To(Equal([]algorithm.CandidateExplanation{
+					{VersionID: 2, NotPassed: []int{12}},
+				}))
+				Expect(explanation.Satisfied()).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when the input is pinned to a version which does not exist", func() {
+		BeforeEach(func() {
+			inputConfig.PinnedVersionID = 4
+		})
+
+		It("has no candidates", func() {
+			Expect(explanation.Candidates).To(BeEmpty())
+		})
+	})
+})
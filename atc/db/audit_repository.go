@@ -0,0 +1,115 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type AuditEvent struct {
+	ID       int
+	Time     time.Time
+	UserName string
+	TeamName string
+	Action   string
+	Method   string
+	Target   string
+	Status   int
+}
+
+// AuditFilter narrows down the audit events returned by an AuditRepository.
+// Zero values are not filtered on.
+type AuditFilter struct {
+	UserName string
+	TeamName string
+	Action   string
+	Since    time.Time
+
+	Limit int
+}
+
+//go:generate counterfeiter . AuditRepository
+
+type AuditRepository interface {
+	Record(AuditEvent) error
+	Events(AuditFilter) ([]AuditEvent, error)
+}
+
+type auditRepository struct {
+	conn Conn
+}
+
+func NewAuditRepository(conn Conn) AuditRepository {
+	return &auditRepository{
+		conn: conn,
+	}
+}
+
+func (repository *auditRepository) Record(event AuditEvent) error {
+	_, err := psql.Insert("audit_events").
+		Columns("time", "user_name", "team_name", "action", "method", "target", "status").
+		Values(event.Time, event.UserName, event.TeamName, event.Action, event.Method, event.Target, event.Status).
+		RunWith(repository.conn).
+		Exec()
+	return err
+}
+
+func (repository *auditRepository) Events(filter AuditFilter) ([]AuditEvent, error) {
+	query := psql.Select("id", "time", "user_name", "team_name", "action", "method", "target", "status").
+		From("audit_events").
+		OrderBy("id DESC")
+
+	conditions := sq.Eq{}
+	if filter.UserName != "" {
+		conditions["user_name"] = filter.UserName
+	}
+
+	if filter.TeamName != "" {
+		conditions["team_name"] = filter.TeamName
+	}
+
+	if filter.Action != "" {
+		conditions["action"] = filter.Action
+	}
+
+	if len(conditions) > 0 {
+		query = query.Where(conditions)
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"time": filter.Since})
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+
+	rows, err := query.RunWith(repository.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		var event AuditEvent
+		err = rows.Scan(
+			&event.ID,
+			&event.Time,
+			&event.UserName,
+			&event.TeamName,
+			&event.Action,
+			&event.Method,
+			&event.Target,
+			&event.Status,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditRepository", func() {
+	var (
+		auditRepository db.AuditRepository
+		now             time.Time
+	)
+
+	BeforeEach(func() {
+		auditRepository = db.NewAuditRepository(dbConn)
+		now = time.Now().Truncate(time.Second)
+
+		events := []db.AuditEvent{
+			{Time: now.Add(-time.Hour), UserName: "some-user", TeamName: "some-team", Action: "PausePipeline", Method: "PUT", Target: "/api/v1/teams/some-team/pipelines/some-pipeline/pause", Status: 200},
+			{Time: now.Add(-time.Minute), UserName: "other-user", TeamName: "some-team", Action: "PauseJob", Method: "PUT", Target: "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/pause", Status: 200},
+			{Time: now, UserName: "some-user", TeamName: "other-team", Action: "DestroyTeam", Method: "DELETE", Target: "/api/v1/teams/other-team", Status: 403},
+		}
+
+		for _, event := range events {
+			err := auditRepository.Record(event)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	actions := func(events []db.AuditEvent) []string {
+		names := []string{}
+		for _, event := range events {
+			names = append(names, event.Action)
+		}
+		return names
+	}
+
+	It("returns all events, most recent first", func() {
+		events, err := auditRepository.Events(db.AuditFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actions(events)).To(Equal([]string{"DestroyTeam", "PauseJob", "PausePipeline"}))
+
+		Expect(events[0].UserName).To(Equal("some-user"))
+		Expect(events[0].TeamName).To(Equal("other-team"))
+		Expect(events[0].Method).To(Equal("DELETE"))
+		Expect(events[0].Target).To(Equal("/api/v1/teams/other-team"))
+		Expect(events[0].Status).To(Equal(403))
+		Expect(events[0].Time.Unix()).To(Equal(now.Unix()))
+	})
+
+	It("filters by user, team, and action", func() {
+		events, err := auditRepository.Events(db.AuditFilter{UserName: "some-user"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actions(events)).To(Equal([]string{"DestroyTeam", "PausePipeline"}))
+
+		events, err = auditRepository.Events(db.AuditFilter{TeamName: "some-team"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actions(events)).To(Equal([]string{"PauseJob", "PausePipeline"}))
+
+		events, err = auditRepository.Events(db.AuditFilter{UserName: "some-user", Action: "PausePipeline"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actions(events)).To(Equal([]string{"PausePipeline"}))
+	})
+
+	It("filters by time", func() {
+		events, err := auditRepository.Events(db.AuditFilter{Since: now.Add(-10 * time.Minute)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actions(events)).To(Equal([]string{"DestroyTeam", "PauseJob"}))
+	})
+
+	It("limits the number of events", func() {
+		events, err := auditRepository.Events(db.AuditFilter{Limit: 1})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actions(events)).To(Equal([]string{"DestroyTeam"}))
+	})
+})
@@ -42,9 +42,10 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.events_archived, b.resource_id, r.name").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
+	JoinClause("LEFT OUTER JOIN resources r ON b.resource_id = r.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
 	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id")
 
@@ -58,6 +59,8 @@ type Build interface {
 	Name() string
 	JobID() int
 	JobName() string
+	ResourceID() int
+	ResourceName() string
 	PipelineID() int
 	PipelineName() string
 	TeamID() int
@@ -108,6 +111,11 @@ type Build interface {
 
 	IsDrained() bool
 	SetDrained(bool) error
+
+	EventsArchived() bool
+	MarkEventsArchived() error
+	DeleteArchivedEvents() error
+	ClearEventsArchived() error
 }
 
 type build struct {
@@ -124,6 +132,10 @@ type build struct {
 	jobID        int
 	jobName      string
 
+	// set on builds which record a resource's check
+	resourceID   int
+	resourceName string
+
 	isManuallyTriggered bool
 
 	schema      string
@@ -135,9 +147,10 @@ type build struct {
 	endTime    time.Time
 	reapTime   time.Time
 
-	conn        Conn
-	lockFactory lock.LockFactory
-	drained     bool
+	conn           Conn
+	lockFactory    lock.LockFactory
+	drained        bool
+	eventsArchived bool
 }
 
 var ErrBuildDisappeared = errors.New("build disappeared from db")
@@ -157,6 +170,8 @@ func (b *build) ID() int                      { return b.id }
 func (b *build) Name() string                 { return b.name }
 func (b *build) JobID() int                   { return b.jobID }
 func (b *build) JobName() string              { return b.jobName }
+func (b *build) ResourceID() int              { return b.resourceID }
+func (b *build) ResourceName() string         { return b.resourceName }
 func (b *build) PipelineID() int              { return b.pipelineID }
 func (b *build) PipelineName() string         { return b.pipelineName }
 func (b *build) TeamID() int                  { return b.teamID }
@@ -172,6 +187,7 @@ func (b *build) ReapTime() time.Time          { return b.reapTime }
 func (b *build) Status() BuildStatus          { return b.status }
 func (b *build) IsScheduled() bool            { return b.scheduled }
 func (b *build) IsDrained() bool              { return b.drained }
+func (b *build) EventsArchived() bool         { return b.eventsArchived }
 
 func (b *build) IsRunning() bool {
 	switch b.status {
@@ -421,6 +437,74 @@ func (b *build) SetDrained(drained bool) error {
 	return err
 }
 
+// MarkEventsArchived marks the build's events as archived elsewhere, so that
+// they are read from the archive from now on.
+//
+// The events are left in the database for clients which were already
+// streaming them, until they are removed by DeleteArchivedEvents.
+func (b *build) MarkEventsArchived() error {
+	_, err := psql.Update("builds").
+		Set("events_archived", true).
+		Set("events_archived_time", sq.Expr("now()")).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchived = true
+
+	return nil
+}
+
+// DeleteArchivedEvents deletes the build's events from the database once
+// they have been archived and are no longer being streamed.
+func (b *build) DeleteArchivedEvents() error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("events_archived_time", nil).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ClearEventsArchived marks the build as no longer having archived events,
+// e.g. once they have been reaped from the archive.
+func (b *build) ClearEventsArchived() error {
+	_, err := psql.Update("builds").
+		Set("events_archived", false).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchived = false
+
+	return nil
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -733,14 +817,9 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
 		from,
@@ -1095,15 +1174,16 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, pipelineID                                      sql.NullInt64
+		jobID, pipelineID, resourceID                          sql.NullInt64
 		schema, privatePlan, jobName, pipelineName, publicPlan sql.NullString
+		resourceName                                           sql.NullString
 		createTime, startTime, endTime, reapTime               pq.NullTime
 		nonce                                                  sql.NullString
-		drained                                                bool
+		drained, eventsArchived                                bool
 		status                                                 string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &eventsArchived, &resourceID, &resourceName)
 	if err != nil {
 		return err
 	}
@@ -1111,6 +1191,8 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.status = BuildStatus(status)
 	b.jobName = jobName.String
 	b.jobID = int(jobID.Int64)
+	b.resourceName = resourceName.String
+	b.resourceID = int(resourceID.Int64)
 	b.pipelineName = pipelineName.String
 	b.pipelineID = int(pipelineID.Int64)
 	b.schema = schema.String
@@ -1119,6 +1201,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
 	b.drained = drained
+	b.eventsArchived = eventsArchived
 
 	var (
 		noncense      *string
@@ -1151,11 +1234,7 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		return err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-	_, err = psql.Insert(table).
+	_, err = psql.Insert(b.eventsTable()).
 		Columns("event_id", "build_id", "type", "version", "payload").
 		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
 		RunWith(tx).
@@ -1163,6 +1242,14 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	return err
 }
 
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 	var buildID int
 	err := psql.Insert("builds").
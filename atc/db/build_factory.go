@@ -18,7 +18,13 @@ type BuildFactory interface {
 	VisibleBuildsWithTime([]string, Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
+	GetAllStartedCheckBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	GetArchivableBuilds(limit int, drainedOnly bool) ([]Build, error)
+	GetReapedArchivedBuilds(limit int) ([]Build, error)
+	GetArchivedBuildsWithEvents(gracePeriod time.Duration, limit int) ([]Build, error)
+	GetDeletedArchivedBuilds(limit int) ([]int, error)
+	ForgetDeletedArchivedBuild(buildID int) error
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
 }
@@ -64,7 +70,8 @@ func (f *buildFactory) VisibleBuildsWithTime(teamNames []string, page Page) ([]B
 		Where(sq.Or{
 			sq.Eq{"p.public": true},
 			sq.Eq{"t.name": teamNames},
-		})
+		}).
+		Where(sq.Eq{"b.resource_id": nil})
 	return getBuildsWithDates(newBuildsQuery, minMaxIdQuery, page, f.conn, f.lockFactory)
 }
 
@@ -73,7 +80,8 @@ func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pa
 		Where(sq.Or{
 			sq.Eq{"p.public": true},
 			sq.Eq{"t.name": teamNames},
-		})
+		}).
+		Where(sq.Eq{"b.resource_id": nil})
 
 	return getBuildsWithPagination(newBuildsQuery, minMaxIdQuery,
 		page, f.conn, f.lockFactory)
@@ -81,7 +89,7 @@ func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pa
 
 func (f *buildFactory) PublicBuilds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(
-		buildsQuery.Where(sq.Eq{"p.public": true}), minMaxIdQuery,
+		buildsQuery.Where(sq.Eq{"p.public": true, "b.resource_id": nil}), minMaxIdQuery,
 		page, f.conn, f.lockFactory)
 }
 
@@ -114,14 +122,119 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetArchivableBuilds returns completed builds whose events are still in the
+// database. If drainedOnly is set, builds which have yet to be drained are
+// left for the drainer.
+//
+// Check builds are left out, as only a few of them are kept for each
+// resource and they are pruned along with their events.
+func (f *buildFactory) GetArchivableBuilds(limit int, drainedOnly bool) ([]Build, error) {
+	conditions := sq.Eq{
+		"b.completed":       true,
+		"b.events_archived": false,
+		"b.reap_time":       nil,
+		"b.resource_id":     nil,
+	}
+
+	if drainedOnly {
+		conditions["b.drained"] = true
+	}
+
+	query := buildsQuery.
+		Where(conditions).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
+// GetReapedArchivedBuilds returns builds whose events have been reaped after
+// they were archived, so that they can be removed from the archive too.
+func (f *buildFactory) GetReapedArchivedBuilds(limit int) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{"b.events_archived": true}).
+		Where(sq.NotEq{"b.reap_time": nil}).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
+// GetArchivedBuildsWithEvents returns builds whose events were archived over
+// gracePeriod ago but are still in the database, so that they can be deleted
+// once clients which were already streaming them have had time to finish.
+func (f *buildFactory) GetArchivedBuildsWithEvents(gracePeriod time.Duration, limit int) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{"b.events_archived": true}).
+		Where(sq.Expr(fmt.Sprintf("now() - b.events_archived_time > '%d seconds'::interval", int(gracePeriod.Seconds())))).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
+// GetDeletedArchivedBuilds returns the IDs of builds which were deleted, e.g.
+// along with their pipeline or team, after their events were archived, so
+// that they can be removed from the archive too.
+func (f *buildFactory) GetDeletedArchivedBuilds(limit int) ([]int, error) {
+	rows, err := psql.Select("build_id").
+		From("deleted_archived_builds").
+		OrderBy("build_id ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	buildIDs := []int{}
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	return buildIDs, nil
+}
+
+// ForgetDeletedArchivedBuild stops returning the build from
+// GetDeletedArchivedBuilds once its events have been removed from the
+// archive.
+func (f *buildFactory) ForgetDeletedArchivedBuild(buildID int) error {
+	_, err := psql.Delete("deleted_archived_builds").
+		Where(sq.Eq{"build_id": buildID}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+// GetAllStartedBuilds returns the started builds to be run by the engine.
+// Check builds are left out, as they're run by radar instead.
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
-		"b.status": BuildStatusStarted,
+		"b.status":      BuildStatusStarted,
+		"b.resource_id": nil,
 	})
 
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetAllStartedCheckBuilds returns the check builds which have yet to finish,
+// so that those whose check was interrupted can be found.
+func (f *buildFactory) GetAllStartedCheckBuilds() ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{"b.status": BuildStatusStarted}).
+		Where(sq.NotEq{"b.resource_id": nil})
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
 func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFactory) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -354,6 +356,184 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("GetArchivableBuilds", func() {
+		var drainedBuild, undrainedBuild, archivedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+
+			_, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			drainedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			undrainedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			archivedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range []db.Build{drainedBuild, undrainedBuild, archivedBuild} {
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err = drainedBuild.SetDrained(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.MarkEventsArchived()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = drainedBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = undrainedBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+
+			checkBuild, checkLock, err := defaultResource.CreateCheckBuild(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = checkBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = checkLock.Release()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns completed builds whose events have not been archived, other than check builds", func() {
+			builds, err := buildFactory.GetArchivableBuilds(10, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal([]db.Build{drainedBuild, undrainedBuild}))
+		})
+
+		It("returns no more than the limit", func() {
+			builds, err := buildFactory.GetArchivableBuilds(1, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal([]db.Build{drainedBuild}))
+		})
+
+		It("returns only drained builds if asked", func() {
+			builds, err := buildFactory.GetArchivableBuilds(10, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal([]db.Build{drainedBuild}))
+		})
+	})
+
+	Describe("GetArchivedBuildsWithEvents", func() {
+		var archivedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			archivedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.MarkEventsArchived()
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns builds archived longer than the grace period ago", func() {
+			builds, err := buildFactory.GetArchivedBuildsWithEvents(0, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(archivedBuild.ID()))
+		})
+
+		It("leaves builds archived within the grace period", func() {
+			builds, err := buildFactory.GetArchivedBuildsWithEvents(time.Hour, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
+
+	Describe("GetDeletedArchivedBuilds", func() {
+		var archivedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			archivedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.MarkEventsArchived()
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.Delete()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not return archived builds which still exist", func() {
+			buildIDs, err := buildFactory.GetDeletedArchivedBuilds(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs).To(BeEmpty())
+		})
+
+		Context("when the team of an archived build is deleted", func() {
+			BeforeEach(func() {
+				err := team.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the build", func() {
+				buildIDs, err := buildFactory.GetDeletedArchivedBuilds(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildIDs).To(Equal([]int{archivedBuild.ID()}))
+			})
+
+			It("no longer returns the build once forgotten", func() {
+				err := buildFactory.ForgetDeletedArchivedBuild(archivedBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				buildIDs, err := buildFactory.GetDeletedArchivedBuilds(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildIDs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetAllStartedCheckBuilds", func() {
+		var startedCheckBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			startedCheckBuild, _, err = defaultResource.CreateCheckBuild(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			finishedCheckBuild, _, err := defaultResource.CreateCheckBuild(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = finishedCheckBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.Start("some-schema", atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the check builds which have yet to finish", func() {
+			builds, err := buildFactory.GetAllStartedCheckBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(startedCheckBuild.ID()))
+		})
+	})
+
 	Describe("GetAllStartedBuilds", func() {
 		var build1DB db.Build
 		var build2DB db.Build
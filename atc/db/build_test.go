@@ -243,6 +243,80 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("MarkEventsArchived", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some-payload"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.MarkEventsArchived()
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("marks the events as archived", func() {
+			Expect(build.EventsArchived()).To(BeTrue())
+		})
+
+		It("keeps the events in the database for clients already streaming them", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some-payload"})))
+		})
+
+		Context("when the archived events are deleted", func() {
+			BeforeEach(func() {
+				err := build.DeleteArchivedEvents()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the events from the database", func() {
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("no longer returns the build as having archived events to delete", func() {
+				builds, err := buildFactory.GetArchivedBuildsWithEvents(0, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
+
+		Context("when cleared", func() {
+			BeforeEach(func() {
+				err := build.ClearEventsArchived()
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("no longer marks the events as archived", func() {
+				Expect(build.EventsArchived()).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		It("saves and propagates events correctly", func() {
 			build, err := team.CreateOneOffBuild()
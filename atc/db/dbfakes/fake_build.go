@@ -67,6 +67,16 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	ClearEventsArchivedStub        func() error
+	clearEventsArchivedMutex       sync.RWMutex
+	clearEventsArchivedArgsForCall []struct {
+	}
+	clearEventsArchivedReturns struct {
+		result1 error
+	}
+	clearEventsArchivedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateTimeStub        func() time.Time
 	createTimeMutex       sync.RWMutex
 	createTimeArgsForCall []struct {
@@ -89,6 +99,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	DeleteArchivedEventsStub        func() error
+	deleteArchivedEventsMutex       sync.RWMutex
+	deleteArchivedEventsArgsForCall []struct {
+	}
+	deleteArchivedEventsReturns struct {
+		result1 error
+	}
+	deleteArchivedEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EndTimeStub        func() time.Time
 	endTimeMutex       sync.RWMutex
 	endTimeArgsForCall []struct {
@@ -112,6 +132,16 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	EventsArchivedStub        func() bool
+	eventsArchivedMutex       sync.RWMutex
+	eventsArchivedArgsForCall []struct {
+	}
+	eventsArchivedReturns struct {
+		result1 bool
+	}
+	eventsArchivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -226,6 +256,16 @@ type FakeBuild struct {
 	markAsAbortedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MarkEventsArchivedStub        func() error
+	markEventsArchivedMutex       sync.RWMutex
+	markEventsArchivedArgsForCall []struct {
+	}
+	markEventsArchivedReturns struct {
+		result1 error
+	}
+	markEventsArchivedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -326,6 +366,26 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	ResourceIDStub        func() int
+	resourceIDMutex       sync.RWMutex
+	resourceIDArgsForCall []struct {
+	}
+	resourceIDReturns struct {
+		result1 int
+	}
+	resourceIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ResourceNameStub        func() string
+	resourceNameMutex       sync.RWMutex
+	resourceNameArgsForCall []struct {
+	}
+	resourceNameReturns struct {
+		result1 string
+	}
+	resourceNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -733,6 +793,58 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ClearEventsArchived() error {
+	fake.clearEventsArchivedMutex.Lock()
+	ret, specificReturn := fake.clearEventsArchivedReturnsOnCall[len(fake.clearEventsArchivedArgsForCall)]
+	fake.clearEventsArchivedArgsForCall = append(fake.clearEventsArchivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ClearEventsArchived", []interface{}{})
+	fake.clearEventsArchivedMutex.Unlock()
+	if fake.ClearEventsArchivedStub != nil {
+		return fake.ClearEventsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.clearEventsArchivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ClearEventsArchivedCallCount() int {
+	fake.clearEventsArchivedMutex.RLock()
+	defer fake.clearEventsArchivedMutex.RUnlock()
+	return len(fake.clearEventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) ClearEventsArchivedCalls(stub func() error) {
+	fake.clearEventsArchivedMutex.Lock()
+	defer fake.clearEventsArchivedMutex.Unlock()
+	fake.ClearEventsArchivedStub = stub
+}
+
+func (fake *FakeBuild) ClearEventsArchivedReturns(result1 error) {
+	fake.clearEventsArchivedMutex.Lock()
+	defer fake.clearEventsArchivedMutex.Unlock()
+	fake.ClearEventsArchivedStub = nil
+	fake.clearEventsArchivedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ClearEventsArchivedReturnsOnCall(i int, result1 error) {
+	fake.clearEventsArchivedMutex.Lock()
+	defer fake.clearEventsArchivedMutex.Unlock()
+	fake.ClearEventsArchivedStub = nil
+	if fake.clearEventsArchivedReturnsOnCall == nil {
+		fake.clearEventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearEventsArchivedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) CreateTime() time.Time {
 	fake.createTimeMutex.Lock()
 	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
@@ -840,6 +952,58 @@ func (fake *FakeBuild) DeleteReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) DeleteArchivedEvents() error {
+	fake.deleteArchivedEventsMutex.Lock()
+	ret, specificReturn := fake.deleteArchivedEventsReturnsOnCall[len(fake.deleteArchivedEventsArgsForCall)]
+	fake.deleteArchivedEventsArgsForCall = append(fake.deleteArchivedEventsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DeleteArchivedEvents", []interface{}{})
+	fake.deleteArchivedEventsMutex.Unlock()
+	if fake.DeleteArchivedEventsStub != nil {
+		return fake.DeleteArchivedEventsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteArchivedEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) DeleteArchivedEventsCallCount() int {
+	fake.deleteArchivedEventsMutex.RLock()
+	defer fake.deleteArchivedEventsMutex.RUnlock()
+	return len(fake.deleteArchivedEventsArgsForCall)
+}
+
+func (fake *FakeBuild) DeleteArchivedEventsCalls(stub func() error) {
+	fake.deleteArchivedEventsMutex.Lock()
+	defer fake.deleteArchivedEventsMutex.Unlock()
+	fake.DeleteArchivedEventsStub = stub
+}
+
+func (fake *FakeBuild) DeleteArchivedEventsReturns(result1 error) {
+	fake.deleteArchivedEventsMutex.Lock()
+	defer fake.deleteArchivedEventsMutex.Unlock()
+	fake.DeleteArchivedEventsStub = nil
+	fake.deleteArchivedEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) DeleteArchivedEventsReturnsOnCall(i int, result1 error) {
+	fake.deleteArchivedEventsMutex.Lock()
+	defer fake.deleteArchivedEventsMutex.Unlock()
+	fake.DeleteArchivedEventsStub = nil
+	if fake.deleteArchivedEventsReturnsOnCall == nil {
+		fake.deleteArchivedEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteArchivedEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) EndTime() time.Time {
 	fake.endTimeMutex.Lock()
 	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
@@ -955,6 +1119,58 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) EventsArchived() bool {
+	fake.eventsArchivedMutex.Lock()
+	ret, specificReturn := fake.eventsArchivedReturnsOnCall[len(fake.eventsArchivedArgsForCall)]
+	fake.eventsArchivedArgsForCall = append(fake.eventsArchivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EventsArchived", []interface{}{})
+	fake.eventsArchivedMutex.Unlock()
+	if fake.EventsArchivedStub != nil {
+		return fake.EventsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.eventsArchivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) EventsArchivedCallCount() int {
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
+	return len(fake.eventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) EventsArchivedCalls(stub func() bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = stub
+}
+
+func (fake *FakeBuild) EventsArchivedReturns(result1 bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = nil
+	fake.eventsArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) EventsArchivedReturnsOnCall(i int, result1 bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = nil
+	if fake.eventsArchivedReturnsOnCall == nil {
+		fake.eventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.eventsArchivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1546,6 +1762,58 @@ func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) MarkEventsArchived() error {
+	fake.markEventsArchivedMutex.Lock()
+	ret, specificReturn := fake.markEventsArchivedReturnsOnCall[len(fake.markEventsArchivedArgsForCall)]
+	fake.markEventsArchivedArgsForCall = append(fake.markEventsArchivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MarkEventsArchived", []interface{}{})
+	fake.markEventsArchivedMutex.Unlock()
+	if fake.MarkEventsArchivedStub != nil {
+		return fake.MarkEventsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markEventsArchivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MarkEventsArchivedCallCount() int {
+	fake.markEventsArchivedMutex.RLock()
+	defer fake.markEventsArchivedMutex.RUnlock()
+	return len(fake.markEventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) MarkEventsArchivedCalls(stub func() error) {
+	fake.markEventsArchivedMutex.Lock()
+	defer fake.markEventsArchivedMutex.Unlock()
+	fake.MarkEventsArchivedStub = stub
+}
+
+func (fake *FakeBuild) MarkEventsArchivedReturns(result1 error) {
+	fake.markEventsArchivedMutex.Lock()
+	defer fake.markEventsArchivedMutex.Unlock()
+	fake.MarkEventsArchivedStub = nil
+	fake.markEventsArchivedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkEventsArchivedReturnsOnCall(i int, result1 error) {
+	fake.markEventsArchivedMutex.Lock()
+	defer fake.markEventsArchivedMutex.Unlock()
+	fake.MarkEventsArchivedStub = nil
+	if fake.markEventsArchivedReturnsOnCall == nil {
+		fake.markEventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markEventsArchivedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2029,6 +2297,110 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ResourceID() int {
+	fake.resourceIDMutex.Lock()
+	ret, specificReturn := fake.resourceIDReturnsOnCall[len(fake.resourceIDArgsForCall)]
+	fake.resourceIDArgsForCall = append(fake.resourceIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceID", []interface{}{})
+	fake.resourceIDMutex.Unlock()
+	if fake.ResourceIDStub != nil {
+		return fake.ResourceIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceIDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ResourceIDCallCount() int {
+	fake.resourceIDMutex.RLock()
+	defer fake.resourceIDMutex.RUnlock()
+	return len(fake.resourceIDArgsForCall)
+}
+
+func (fake *FakeBuild) ResourceIDCalls(stub func() int) {
+	fake.resourceIDMutex.Lock()
+	defer fake.resourceIDMutex.Unlock()
+	fake.ResourceIDStub = stub
+}
+
+func (fake *FakeBuild) ResourceIDReturns(result1 int) {
+	fake.resourceIDMutex.Lock()
+	defer fake.resourceIDMutex.Unlock()
+	fake.ResourceIDStub = nil
+	fake.resourceIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ResourceIDReturnsOnCall(i int, result1 int) {
+	fake.resourceIDMutex.Lock()
+	defer fake.resourceIDMutex.Unlock()
+	fake.ResourceIDStub = nil
+	if fake.resourceIDReturnsOnCall == nil {
+		fake.resourceIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.resourceIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ResourceName() string {
+	fake.resourceNameMutex.Lock()
+	ret, specificReturn := fake.resourceNameReturnsOnCall[len(fake.resourceNameArgsForCall)]
+	fake.resourceNameArgsForCall = append(fake.resourceNameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceName", []interface{}{})
+	fake.resourceNameMutex.Unlock()
+	if fake.ResourceNameStub != nil {
+		return fake.ResourceNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceNameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ResourceNameCallCount() int {
+	fake.resourceNameMutex.RLock()
+	defer fake.resourceNameMutex.RUnlock()
+	return len(fake.resourceNameArgsForCall)
+}
+
+func (fake *FakeBuild) ResourceNameCalls(stub func() string) {
+	fake.resourceNameMutex.Lock()
+	defer fake.resourceNameMutex.Unlock()
+	fake.ResourceNameStub = stub
+}
+
+func (fake *FakeBuild) ResourceNameReturns(result1 string) {
+	fake.resourceNameMutex.Lock()
+	defer fake.resourceNameMutex.Unlock()
+	fake.ResourceNameStub = nil
+	fake.resourceNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ResourceNameReturnsOnCall(i int, result1 string) {
+	fake.resourceNameMutex.Lock()
+	defer fake.resourceNameMutex.Unlock()
+	fake.ResourceNameStub = nil
+	if fake.resourceNameReturnsOnCall == nil {
+		fake.resourceNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.resourceNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -2849,14 +3221,20 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.clearEventsArchivedMutex.RLock()
+	defer fake.clearEventsArchivedMutex.RUnlock()
 	fake.createTimeMutex.RLock()
 	defer fake.createTimeMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deleteArchivedEventsMutex.RLock()
+	defer fake.deleteArchivedEventsMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	fake.finishWithErrorMutex.RLock()
@@ -2879,6 +3257,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.jobNameMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
 	defer fake.markAsAbortedMutex.RUnlock()
+	fake.markEventsArchivedMutex.RLock()
+	defer fake.markEventsArchivedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pipelineMutex.RLock()
@@ -2897,6 +3277,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.resourceIDMutex.RLock()
+	defer fake.resourceIDMutex.RUnlock()
+	fake.resourceNameMutex.RLock()
+	defer fake.resourceNameMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
 	fake.saveEventMutex.RLock()
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
@@ -23,6 +24,17 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
+	ForgetDeletedArchivedBuildStub        func(int) error
+	forgetDeletedArchivedBuildMutex       sync.RWMutex
+	forgetDeletedArchivedBuildArgsForCall []struct {
+		arg1 int
+	}
+	forgetDeletedArchivedBuildReturns struct {
+		result1 error
+	}
+	forgetDeletedArchivedBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAllStartedBuildsStub        func() ([]db.Build, error)
 	getAllStartedBuildsMutex       sync.RWMutex
 	getAllStartedBuildsArgsForCall []struct {
@@ -35,6 +47,59 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetAllStartedCheckBuildsStub        func() ([]db.Build, error)
+	getAllStartedCheckBuildsMutex       sync.RWMutex
+	getAllStartedCheckBuildsArgsForCall []struct {
+	}
+	getAllStartedCheckBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getAllStartedCheckBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetArchivableBuildsStub        func(int, bool) ([]db.Build, error)
+	getArchivableBuildsMutex       sync.RWMutex
+	getArchivableBuildsArgsForCall []struct {
+		arg1 int
+		arg2 bool
+	}
+	getArchivableBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getArchivableBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetArchivedBuildsWithEventsStub        func(time.Duration, int) ([]db.Build, error)
+	getArchivedBuildsWithEventsMutex       sync.RWMutex
+	getArchivedBuildsWithEventsArgsForCall []struct {
+		arg1 time.Duration
+		arg2 int
+	}
+	getArchivedBuildsWithEventsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getArchivedBuildsWithEventsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetDeletedArchivedBuildsStub        func(int) ([]int, error)
+	getDeletedArchivedBuildsMutex       sync.RWMutex
+	getDeletedArchivedBuildsArgsForCall []struct {
+		arg1 int
+	}
+	getDeletedArchivedBuildsReturns struct {
+		result1 []int
+		result2 error
+	}
+	getDeletedArchivedBuildsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	GetDrainableBuildsStub        func() ([]db.Build, error)
 	getDrainableBuildsMutex       sync.RWMutex
 	getDrainableBuildsArgsForCall []struct {
@@ -47,6 +112,19 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetReapedArchivedBuildsStub        func(int) ([]db.Build, error)
+	getReapedArchivedBuildsMutex       sync.RWMutex
+	getReapedArchivedBuildsArgsForCall []struct {
+		arg1 int
+	}
+	getReapedArchivedBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getReapedArchivedBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	MarkNonInterceptibleBuildsStub        func() error
 	markNonInterceptibleBuildsMutex       sync.RWMutex
 	markNonInterceptibleBuildsArgsForCall []struct {
@@ -174,6 +252,66 @@ func (fake *FakeBuildFactory) BuildReturnsOnCall(i int, result1 db.Build, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) ForgetDeletedArchivedBuild(arg1 int) error {
+	fake.forgetDeletedArchivedBuildMutex.Lock()
+	ret, specificReturn := fake.forgetDeletedArchivedBuildReturnsOnCall[len(fake.forgetDeletedArchivedBuildArgsForCall)]
+	fake.forgetDeletedArchivedBuildArgsForCall = append(fake.forgetDeletedArchivedBuildArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("ForgetDeletedArchivedBuild", []interface{}{arg1})
+	fake.forgetDeletedArchivedBuildMutex.Unlock()
+	if fake.ForgetDeletedArchivedBuildStub != nil {
+		return fake.ForgetDeletedArchivedBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.forgetDeletedArchivedBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedArchivedBuildCallCount() int {
+	fake.forgetDeletedArchivedBuildMutex.RLock()
+	defer fake.forgetDeletedArchivedBuildMutex.RUnlock()
+	return len(fake.forgetDeletedArchivedBuildArgsForCall)
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedArchivedBuildCalls(stub func(int) error) {
+	fake.forgetDeletedArchivedBuildMutex.Lock()
+	defer fake.forgetDeletedArchivedBuildMutex.Unlock()
+	fake.ForgetDeletedArchivedBuildStub = stub
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedArchivedBuildArgsForCall(i int) int {
+	fake.forgetDeletedArchivedBuildMutex.RLock()
+	defer fake.forgetDeletedArchivedBuildMutex.RUnlock()
+	argsForCall := fake.forgetDeletedArchivedBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedArchivedBuildReturns(result1 error) {
+	fake.forgetDeletedArchivedBuildMutex.Lock()
+	defer fake.forgetDeletedArchivedBuildMutex.Unlock()
+	fake.ForgetDeletedArchivedBuildStub = nil
+	fake.forgetDeletedArchivedBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedArchivedBuildReturnsOnCall(i int, result1 error) {
+	fake.forgetDeletedArchivedBuildMutex.Lock()
+	defer fake.forgetDeletedArchivedBuildMutex.Unlock()
+	fake.ForgetDeletedArchivedBuildStub = nil
+	if fake.forgetDeletedArchivedBuildReturnsOnCall == nil {
+		fake.forgetDeletedArchivedBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.forgetDeletedArchivedBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildFactory) GetAllStartedBuilds() ([]db.Build, error) {
 	fake.getAllStartedBuildsMutex.Lock()
 	ret, specificReturn := fake.getAllStartedBuildsReturnsOnCall[len(fake.getAllStartedBuildsArgsForCall)]
@@ -229,6 +367,252 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetAllStartedCheckBuilds() ([]db.Build, error) {
+	fake.getAllStartedCheckBuildsMutex.Lock()
+	ret, specificReturn := fake.getAllStartedCheckBuildsReturnsOnCall[len(fake.getAllStartedCheckBuildsArgsForCall)]
+	fake.getAllStartedCheckBuildsArgsForCall = append(fake.getAllStartedCheckBuildsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetAllStartedCheckBuilds", []interface{}{})
+	fake.getAllStartedCheckBuildsMutex.Unlock()
+	if fake.GetAllStartedCheckBuildsStub != nil {
+		return fake.GetAllStartedCheckBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getAllStartedCheckBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetAllStartedCheckBuildsCallCount() int {
+	fake.getAllStartedCheckBuildsMutex.RLock()
+	defer fake.getAllStartedCheckBuildsMutex.RUnlock()
+	return len(fake.getAllStartedCheckBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetAllStartedCheckBuildsCalls(stub func() ([]db.Build, error)) {
+	fake.getAllStartedCheckBuildsMutex.Lock()
+	defer fake.getAllStartedCheckBuildsMutex.Unlock()
+	fake.GetAllStartedCheckBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetAllStartedCheckBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getAllStartedCheckBuildsMutex.Lock()
+	defer fake.getAllStartedCheckBuildsMutex.Unlock()
+	fake.GetAllStartedCheckBuildsStub = nil
+	fake.getAllStartedCheckBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetAllStartedCheckBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getAllStartedCheckBuildsMutex.Lock()
+	defer fake.getAllStartedCheckBuildsMutex.Unlock()
+	fake.GetAllStartedCheckBuildsStub = nil
+	if fake.getAllStartedCheckBuildsReturnsOnCall == nil {
+		fake.getAllStartedCheckBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getAllStartedCheckBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuilds(arg1 int, arg2 bool) ([]db.Build, error) {
+	fake.getArchivableBuildsMutex.Lock()
+	ret, specificReturn := fake.getArchivableBuildsReturnsOnCall[len(fake.getArchivableBuildsArgsForCall)]
+	fake.getArchivableBuildsArgsForCall = append(fake.getArchivableBuildsArgsForCall, struct {
+		arg1 int
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("GetArchivableBuilds", []interface{}{arg1, arg2})
+	fake.getArchivableBuildsMutex.Unlock()
+	if fake.GetArchivableBuildsStub != nil {
+		return fake.GetArchivableBuildsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getArchivableBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCallCount() int {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	return len(fake.getArchivableBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCalls(stub func(int, bool) ([]db.Build, error)) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsArgsForCall(i int) (int, bool) {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	argsForCall := fake.getArchivableBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	fake.getArchivableBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	if fake.getArchivableBuildsReturnsOnCall == nil {
+		fake.getArchivableBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getArchivableBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivedBuildsWithEvents(arg1 time.Duration, arg2 int) ([]db.Build, error) {
+	fake.getArchivedBuildsWithEventsMutex.Lock()
+	ret, specificReturn := fake.getArchivedBuildsWithEventsReturnsOnCall[len(fake.getArchivedBuildsWithEventsArgsForCall)]
+	fake.getArchivedBuildsWithEventsArgsForCall = append(fake.getArchivedBuildsWithEventsArgsForCall, struct {
+		arg1 time.Duration
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("GetArchivedBuildsWithEvents", []interface{}{arg1, arg2})
+	fake.getArchivedBuildsWithEventsMutex.Unlock()
+	if fake.GetArchivedBuildsWithEventsStub != nil {
+		return fake.GetArchivedBuildsWithEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getArchivedBuildsWithEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetArchivedBuildsWithEventsCallCount() int {
+	fake.getArchivedBuildsWithEventsMutex.RLock()
+	defer fake.getArchivedBuildsWithEventsMutex.RUnlock()
+	return len(fake.getArchivedBuildsWithEventsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetArchivedBuildsWithEventsCalls(stub func(time.Duration, int) ([]db.Build, error)) {
+	fake.getArchivedBuildsWithEventsMutex.Lock()
+	defer fake.getArchivedBuildsWithEventsMutex.Unlock()
+	fake.GetArchivedBuildsWithEventsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetArchivedBuildsWithEventsArgsForCall(i int) (time.Duration, int) {
+	fake.getArchivedBuildsWithEventsMutex.RLock()
+	defer fake.getArchivedBuildsWithEventsMutex.RUnlock()
+	argsForCall := fake.getArchivedBuildsWithEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildFactory) GetArchivedBuildsWithEventsReturns(result1 []db.Build, result2 error) {
+	fake.getArchivedBuildsWithEventsMutex.Lock()
+	defer fake.getArchivedBuildsWithEventsMutex.Unlock()
+	fake.GetArchivedBuildsWithEventsStub = nil
+	fake.getArchivedBuildsWithEventsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivedBuildsWithEventsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getArchivedBuildsWithEventsMutex.Lock()
+	defer fake.getArchivedBuildsWithEventsMutex.Unlock()
+	fake.GetArchivedBuildsWithEventsStub = nil
+	if fake.getArchivedBuildsWithEventsReturnsOnCall == nil {
+		fake.getArchivedBuildsWithEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getArchivedBuildsWithEventsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetDeletedArchivedBuilds(arg1 int) ([]int, error) {
+	fake.getDeletedArchivedBuildsMutex.Lock()
+	ret, specificReturn := fake.getDeletedArchivedBuildsReturnsOnCall[len(fake.getDeletedArchivedBuildsArgsForCall)]
+	fake.getDeletedArchivedBuildsArgsForCall = append(fake.getDeletedArchivedBuildsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("GetDeletedArchivedBuilds", []interface{}{arg1})
+	fake.getDeletedArchivedBuildsMutex.Unlock()
+	if fake.GetDeletedArchivedBuildsStub != nil {
+		return fake.GetDeletedArchivedBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeletedArchivedBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetDeletedArchivedBuildsCallCount() int {
+	fake.getDeletedArchivedBuildsMutex.RLock()
+	defer fake.getDeletedArchivedBuildsMutex.RUnlock()
+	return len(fake.getDeletedArchivedBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetDeletedArchivedBuildsCalls(stub func(int) ([]int, error)) {
+	fake.getDeletedArchivedBuildsMutex.Lock()
+	defer fake.getDeletedArchivedBuildsMutex.Unlock()
+	fake.GetDeletedArchivedBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetDeletedArchivedBuildsArgsForCall(i int) int {
+	fake.getDeletedArchivedBuildsMutex.RLock()
+	defer fake.getDeletedArchivedBuildsMutex.RUnlock()
+	argsForCall := fake.getDeletedArchivedBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) GetDeletedArchivedBuildsReturns(result1 []int, result2 error) {
+	fake.getDeletedArchivedBuildsMutex.Lock()
+	defer fake.getDeletedArchivedBuildsMutex.Unlock()
+	fake.GetDeletedArchivedBuildsStub = nil
+	fake.getDeletedArchivedBuildsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetDeletedArchivedBuildsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.getDeletedArchivedBuildsMutex.Lock()
+	defer fake.getDeletedArchivedBuildsMutex.Unlock()
+	fake.GetDeletedArchivedBuildsStub = nil
+	if fake.getDeletedArchivedBuildsReturnsOnCall == nil {
+		fake.getDeletedArchivedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.getDeletedArchivedBuildsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) GetDrainableBuilds() ([]db.Build, error) {
 	fake.getDrainableBuildsMutex.Lock()
 	ret, specificReturn := fake.getDrainableBuildsReturnsOnCall[len(fake.getDrainableBuildsArgsForCall)]
@@ -284,6 +668,69 @@ func (fake *FakeBuildFactory) GetDrainableBuildsReturnsOnCall(i int, result1 []d
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetReapedArchivedBuilds(arg1 int) ([]db.Build, error) {
+	fake.getReapedArchivedBuildsMutex.Lock()
+	ret, specificReturn := fake.getReapedArchivedBuildsReturnsOnCall[len(fake.getReapedArchivedBuildsArgsForCall)]
+	fake.getReapedArchivedBuildsArgsForCall = append(fake.getReapedArchivedBuildsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("GetReapedArchivedBuilds", []interface{}{arg1})
+	fake.getReapedArchivedBuildsMutex.Unlock()
+	if fake.GetReapedArchivedBuildsStub != nil {
+		return fake.GetReapedArchivedBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getReapedArchivedBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetReapedArchivedBuildsCallCount() int {
+	fake.getReapedArchivedBuildsMutex.RLock()
+	defer fake.getReapedArchivedBuildsMutex.RUnlock()
+	return len(fake.getReapedArchivedBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetReapedArchivedBuildsCalls(stub func(int) ([]db.Build, error)) {
+	fake.getReapedArchivedBuildsMutex.Lock()
+	defer fake.getReapedArchivedBuildsMutex.Unlock()
+	fake.GetReapedArchivedBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetReapedArchivedBuildsArgsForCall(i int) int {
+	fake.getReapedArchivedBuildsMutex.RLock()
+	defer fake.getReapedArchivedBuildsMutex.RUnlock()
+	argsForCall := fake.getReapedArchivedBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) GetReapedArchivedBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getReapedArchivedBuildsMutex.Lock()
+	defer fake.getReapedArchivedBuildsMutex.Unlock()
+	fake.GetReapedArchivedBuildsStub = nil
+	fake.getReapedArchivedBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetReapedArchivedBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getReapedArchivedBuildsMutex.Lock()
+	defer fake.getReapedArchivedBuildsMutex.Unlock()
+	fake.GetReapedArchivedBuildsStub = nil
+	if fake.getReapedArchivedBuildsReturnsOnCall == nil {
+		fake.getReapedArchivedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getReapedArchivedBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) MarkNonInterceptibleBuilds() error {
 	fake.markNonInterceptibleBuildsMutex.Lock()
 	ret, specificReturn := fake.markNonInterceptibleBuildsReturnsOnCall[len(fake.markNonInterceptibleBuildsArgsForCall)]
@@ -551,10 +998,22 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.forgetDeletedArchivedBuildMutex.RLock()
+	defer fake.forgetDeletedArchivedBuildMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.getAllStartedCheckBuildsMutex.RLock()
+	defer fake.getAllStartedCheckBuildsMutex.RUnlock()
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	fake.getArchivedBuildsWithEventsMutex.RLock()
+	defer fake.getArchivedBuildsWithEventsMutex.RUnlock()
+	fake.getDeletedArchivedBuildsMutex.RLock()
+	defer fake.getDeletedArchivedBuildsMutex.RUnlock()
 	fake.getDrainableBuildsMutex.RLock()
 	defer fake.getDrainableBuildsMutex.RUnlock()
+	fake.getReapedArchivedBuildsMutex.RLock()
+	defer fake.getReapedArchivedBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
 	fake.publicBuildsMutex.RLock()
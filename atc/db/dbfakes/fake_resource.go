@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
 )
 
 type FakeResource struct {
@@ -22,6 +23,21 @@ type FakeResource struct {
 	aPIPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	CheckBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
+	checkBuildsMutex       sync.RWMutex
+	checkBuildsArgsForCall []struct {
+		arg1 db.Page
+	}
+	checkBuildsReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
+	checkBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
 	CheckErrorStub        func() error
 	checkErrorMutex       sync.RWMutex
 	checkErrorArgsForCall []struct {
@@ -42,6 +58,16 @@ type FakeResource struct {
 	checkEveryReturnsOnCall map[int]struct {
 		result1 string
 	}
+	CheckFailuresStub        func() int
+	checkFailuresMutex       sync.RWMutex
+	checkFailuresArgsForCall []struct {
+	}
+	checkFailuresReturns struct {
+		result1 int
+	}
+	checkFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CheckSetupErrorStub        func() error
 	checkSetupErrorMutex       sync.RWMutex
 	checkSetupErrorArgsForCall []struct {
@@ -72,6 +98,21 @@ type FakeResource struct {
 	configPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	CreateCheckBuildStub        func(lager.Logger) (db.Build, lock.Lock, error)
+	createCheckBuildMutex       sync.RWMutex
+	createCheckBuildArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	createCheckBuildReturns struct {
+		result1 db.Build
+		result2 lock.Lock
+		result3 error
+	}
+	createCheckBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 lock.Lock
+		result3 error
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -154,6 +195,16 @@ type FakeResource struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NextCheckTimeStub        func() time.Time
+	nextCheckTimeMutex       sync.RWMutex
+	nextCheckTimeArgsForCall []struct {
+	}
+	nextCheckTimeReturns struct {
+		result1 time.Time
+	}
+	nextCheckTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NotifyScanStub        func() error
 	notifyScanMutex       sync.RWMutex
 	notifyScanArgsForCall []struct {
@@ -382,6 +433,16 @@ type FakeResource struct {
 		result3 bool
 		result4 error
 	}
+	WebhookConfigStub        func() *atc.WebhookConfig
+	webhookConfigMutex       sync.RWMutex
+	webhookConfigArgsForCall []struct {
+	}
+	webhookConfigReturns struct {
+		result1 *atc.WebhookConfig
+	}
+	webhookConfigReturnsOnCall map[int]struct {
+		result1 *atc.WebhookConfig
+	}
 	WebhookTokenStub        func() string
 	webhookTokenMutex       sync.RWMutex
 	webhookTokenArgsForCall []struct {
@@ -448,6 +509,72 @@ func (fake *FakeResource) APIPinnedVersionReturnsOnCall(i int, result1 atc.Versi
 	}{result1}
 }
 
+func (fake *FakeResource) CheckBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
+	fake.checkBuildsMutex.Lock()
+	ret, specificReturn := fake.checkBuildsReturnsOnCall[len(fake.checkBuildsArgsForCall)]
+	fake.checkBuildsArgsForCall = append(fake.checkBuildsArgsForCall, struct {
+		arg1 db.Page
+	}{arg1})
+	fake.recordInvocation("CheckBuilds", []interface{}{arg1})
+	fake.checkBuildsMutex.Unlock()
+	if fake.CheckBuildsStub != nil {
+		return fake.CheckBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.checkBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) CheckBuildsCallCount() int {
+	fake.checkBuildsMutex.RLock()
+	defer fake.checkBuildsMutex.RUnlock()
+	return len(fake.checkBuildsArgsForCall)
+}
+
+func (fake *FakeResource) CheckBuildsCalls(stub func(db.Page) ([]db.Build, db.Pagination, error)) {
+	fake.checkBuildsMutex.Lock()
+	defer fake.checkBuildsMutex.Unlock()
+	fake.CheckBuildsStub = stub
+}
+
+func (fake *FakeResource) CheckBuildsArgsForCall(i int) db.Page {
+	fake.checkBuildsMutex.RLock()
+	defer fake.checkBuildsMutex.RUnlock()
+	argsForCall := fake.checkBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) CheckBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.checkBuildsMutex.Lock()
+	defer fake.checkBuildsMutex.Unlock()
+	fake.CheckBuildsStub = nil
+	fake.checkBuildsReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CheckBuildsReturnsOnCall(i int, result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.checkBuildsMutex.Lock()
+	defer fake.checkBuildsMutex.Unlock()
+	fake.CheckBuildsStub = nil
+	if fake.checkBuildsReturnsOnCall == nil {
+		fake.checkBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.checkBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) CheckError() error {
 	fake.checkErrorMutex.Lock()
 	ret, specificReturn := fake.checkErrorReturnsOnCall[len(fake.checkErrorArgsForCall)]
@@ -552,6 +679,58 @@ func (fake *FakeResource) CheckEveryReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) CheckFailures() int {
+	fake.checkFailuresMutex.Lock()
+	ret, specificReturn := fake.checkFailuresReturnsOnCall[len(fake.checkFailuresArgsForCall)]
+	fake.checkFailuresArgsForCall = append(fake.checkFailuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CheckFailures", []interface{}{})
+	fake.checkFailuresMutex.Unlock()
+	if fake.CheckFailuresStub != nil {
+		return fake.CheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.checkFailuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CheckFailuresCallCount() int {
+	fake.checkFailuresMutex.RLock()
+	defer fake.checkFailuresMutex.RUnlock()
+	return len(fake.checkFailuresArgsForCall)
+}
+
+func (fake *FakeResource) CheckFailuresCalls(stub func() int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = stub
+}
+
+func (fake *FakeResource) CheckFailuresReturns(result1 int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = nil
+	fake.checkFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) CheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = nil
+	if fake.checkFailuresReturnsOnCall == nil {
+		fake.checkFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.checkFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeResource) CheckSetupError() error {
 	fake.checkSetupErrorMutex.Lock()
 	ret, specificReturn := fake.checkSetupErrorReturnsOnCall[len(fake.checkSetupErrorArgsForCall)]
@@ -708,6 +887,72 @@ func (fake *FakeResource) ConfigPinnedVersionReturnsOnCall(i int, result1 atc.Ve
 	}{result1}
 }
 
+func (fake *FakeResource) CreateCheckBuild(arg1 lager.Logger) (db.Build, lock.Lock, error) {
+	fake.createCheckBuildMutex.Lock()
+	ret, specificReturn := fake.createCheckBuildReturnsOnCall[len(fake.createCheckBuildArgsForCall)]
+	fake.createCheckBuildArgsForCall = append(fake.createCheckBuildArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("CreateCheckBuild", []interface{}{arg1})
+	fake.createCheckBuildMutex.Unlock()
+	if fake.CreateCheckBuildStub != nil {
+		return fake.CreateCheckBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createCheckBuildReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) CreateCheckBuildCallCount() int {
+	fake.createCheckBuildMutex.RLock()
+	defer fake.createCheckBuildMutex.RUnlock()
+	return len(fake.createCheckBuildArgsForCall)
+}
+
+func (fake *FakeResource) CreateCheckBuildCalls(stub func(lager.Logger) (db.Build, lock.Lock, error)) {
+	fake.createCheckBuildMutex.Lock()
+	defer fake.createCheckBuildMutex.Unlock()
+	fake.CreateCheckBuildStub = stub
+}
+
+func (fake *FakeResource) CreateCheckBuildArgsForCall(i int) lager.Logger {
+	fake.createCheckBuildMutex.RLock()
+	defer fake.createCheckBuildMutex.RUnlock()
+	argsForCall := fake.createCheckBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) CreateCheckBuildReturns(result1 db.Build, result2 lock.Lock, result3 error) {
+	fake.createCheckBuildMutex.Lock()
+	defer fake.createCheckBuildMutex.Unlock()
+	fake.CreateCheckBuildStub = nil
+	fake.createCheckBuildReturns = struct {
+		result1 db.Build
+		result2 lock.Lock
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CreateCheckBuildReturnsOnCall(i int, result1 db.Build, result2 lock.Lock, result3 error) {
+	fake.createCheckBuildMutex.Lock()
+	defer fake.createCheckBuildMutex.Unlock()
+	fake.CreateCheckBuildStub = nil
+	if fake.createCheckBuildReturnsOnCall == nil {
+		fake.createCheckBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 lock.Lock
+			result3 error
+		})
+	}
+	fake.createCheckBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 lock.Lock
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -1140,6 +1385,58 @@ func (fake *FakeResource) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) NextCheckTime() time.Time {
+	fake.nextCheckTimeMutex.Lock()
+	ret, specificReturn := fake.nextCheckTimeReturnsOnCall[len(fake.nextCheckTimeArgsForCall)]
+	fake.nextCheckTimeArgsForCall = append(fake.nextCheckTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NextCheckTime", []interface{}{})
+	fake.nextCheckTimeMutex.Unlock()
+	if fake.NextCheckTimeStub != nil {
+		return fake.NextCheckTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.nextCheckTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) NextCheckTimeCallCount() int {
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
+	return len(fake.nextCheckTimeArgsForCall)
+}
+
+func (fake *FakeResource) NextCheckTimeCalls(stub func() time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = stub
+}
+
+func (fake *FakeResource) NextCheckTimeReturns(result1 time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = nil
+	fake.nextCheckTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) NextCheckTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = nil
+	if fake.nextCheckTimeReturnsOnCall == nil {
+		fake.nextCheckTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nextCheckTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResource) NotifyScan() error {
 	fake.notifyScanMutex.Lock()
 	ret, specificReturn := fake.notifyScanReturnsOnCall[len(fake.notifyScanArgsForCall)]
@@ -2265,6 +2562,58 @@ func (fake *FakeResource) VersionsReturnsOnCall(i int, result1 []atc.ResourceVer
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeResource) WebhookConfig() *atc.WebhookConfig {
+	fake.webhookConfigMutex.Lock()
+	ret, specificReturn := fake.webhookConfigReturnsOnCall[len(fake.webhookConfigArgsForCall)]
+	fake.webhookConfigArgsForCall = append(fake.webhookConfigArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WebhookConfig", []interface{}{})
+	fake.webhookConfigMutex.Unlock()
+	if fake.WebhookConfigStub != nil {
+		return fake.WebhookConfigStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.webhookConfigReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) WebhookConfigCallCount() int {
+	fake.webhookConfigMutex.RLock()
+	defer fake.webhookConfigMutex.RUnlock()
+	return len(fake.webhookConfigArgsForCall)
+}
+
+func (fake *FakeResource) WebhookConfigCalls(stub func() *atc.WebhookConfig) {
+	fake.webhookConfigMutex.Lock()
+	defer fake.webhookConfigMutex.Unlock()
+	fake.WebhookConfigStub = stub
+}
+
+func (fake *FakeResource) WebhookConfigReturns(result1 *atc.WebhookConfig) {
+	fake.webhookConfigMutex.Lock()
+	defer fake.webhookConfigMutex.Unlock()
+	fake.WebhookConfigStub = nil
+	fake.webhookConfigReturns = struct {
+		result1 *atc.WebhookConfig
+	}{result1}
+}
+
+func (fake *FakeResource) WebhookConfigReturnsOnCall(i int, result1 *atc.WebhookConfig) {
+	fake.webhookConfigMutex.Lock()
+	defer fake.webhookConfigMutex.Unlock()
+	fake.WebhookConfigStub = nil
+	if fake.webhookConfigReturnsOnCall == nil {
+		fake.webhookConfigReturnsOnCall = make(map[int]struct {
+			result1 *atc.WebhookConfig
+		})
+	}
+	fake.webhookConfigReturnsOnCall[i] = struct {
+		result1 *atc.WebhookConfig
+	}{result1}
+}
+
 func (fake *FakeResource) WebhookToken() string {
 	fake.webhookTokenMutex.Lock()
 	ret, specificReturn := fake.webhookTokenReturnsOnCall[len(fake.webhookTokenArgsForCall)]
@@ -2322,16 +2671,22 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.aPIPinnedVersionMutex.RLock()
 	defer fake.aPIPinnedVersionMutex.RUnlock()
+	fake.checkBuildsMutex.RLock()
+	defer fake.checkBuildsMutex.RUnlock()
 	fake.checkErrorMutex.RLock()
 	defer fake.checkErrorMutex.RUnlock()
 	fake.checkEveryMutex.RLock()
 	defer fake.checkEveryMutex.RUnlock()
+	fake.checkFailuresMutex.RLock()
+	defer fake.checkFailuresMutex.RUnlock()
 	fake.checkSetupErrorMutex.RLock()
 	defer fake.checkSetupErrorMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
 	fake.configPinnedVersionMutex.RLock()
 	defer fake.configPinnedVersionMutex.RUnlock()
+	fake.createCheckBuildMutex.RLock()
+	defer fake.createCheckBuildMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.disableVersionMutex.RLock()
@@ -2348,6 +2703,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.lastCheckStartTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
@@ -2388,6 +2745,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.unpinVersionMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
+	fake.webhookConfigMutex.RLock()
+	defer fake.webhookConfigMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
 	defer fake.webhookTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
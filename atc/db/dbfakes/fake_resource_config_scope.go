@@ -38,6 +38,16 @@ type FakeResourceConfigScope struct {
 	checkErrorReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckFailuresStub        func() int
+	checkFailuresMutex       sync.RWMutex
+	checkFailuresArgsForCall []struct {
+	}
+	checkFailuresReturns struct {
+		result1 int
+	}
+	checkFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	FindVersionStub        func(atc.Version) (db.ResourceConfigVersion, bool, error)
 	findVersionMutex       sync.RWMutex
 	findVersionArgsForCall []struct {
@@ -77,6 +87,16 @@ type FakeResourceConfigScope struct {
 		result2 bool
 		result3 error
 	}
+	ResetCheckFailuresStub        func() error
+	resetCheckFailuresMutex       sync.RWMutex
+	resetCheckFailuresArgsForCall []struct {
+	}
+	resetCheckFailuresReturns struct {
+		result1 error
+	}
+	resetCheckFailuresReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResourceStub        func() db.Resource
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -145,6 +165,17 @@ type FakeResourceConfigScope struct {
 		result1 bool
 		result2 error
 	}
+	UpdateNextCheckTimeStub        func(time.Duration) error
+	updateNextCheckTimeMutex       sync.RWMutex
+	updateNextCheckTimeArgsForCall []struct {
+		arg1 time.Duration
+	}
+	updateNextCheckTimeReturns struct {
+		result1 error
+	}
+	updateNextCheckTimeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -268,6 +299,58 @@ func (fake *FakeResourceConfigScope) CheckErrorReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeResourceConfigScope) CheckFailures() int {
+	fake.checkFailuresMutex.Lock()
+	ret, specificReturn := fake.checkFailuresReturnsOnCall[len(fake.checkFailuresArgsForCall)]
+	fake.checkFailuresArgsForCall = append(fake.checkFailuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CheckFailures", []interface{}{})
+	fake.checkFailuresMutex.Unlock()
+	if fake.CheckFailuresStub != nil {
+		return fake.CheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.checkFailuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigScope) CheckFailuresCallCount() int {
+	fake.checkFailuresMutex.RLock()
+	defer fake.checkFailuresMutex.RUnlock()
+	return len(fake.checkFailuresArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) CheckFailuresCalls(stub func() int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = stub
+}
+
+func (fake *FakeResourceConfigScope) CheckFailuresReturns(result1 int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = nil
+	fake.checkFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResourceConfigScope) CheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = nil
+	if fake.checkFailuresReturnsOnCall == nil {
+		fake.checkFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.checkFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeResourceConfigScope) FindVersion(arg1 atc.Version) (db.ResourceConfigVersion, bool, error) {
 	fake.findVersionMutex.Lock()
 	ret, specificReturn := fake.findVersionReturnsOnCall[len(fake.findVersionArgsForCall)]
@@ -444,6 +527,58 @@ func (fake *FakeResourceConfigScope) LatestVersionReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceConfigScope) ResetCheckFailures() error {
+	fake.resetCheckFailuresMutex.Lock()
+	ret, specificReturn := fake.resetCheckFailuresReturnsOnCall[len(fake.resetCheckFailuresArgsForCall)]
+	fake.resetCheckFailuresArgsForCall = append(fake.resetCheckFailuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResetCheckFailures", []interface{}{})
+	fake.resetCheckFailuresMutex.Unlock()
+	if fake.ResetCheckFailuresStub != nil {
+		return fake.ResetCheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resetCheckFailuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigScope) ResetCheckFailuresCallCount() int {
+	fake.resetCheckFailuresMutex.RLock()
+	defer fake.resetCheckFailuresMutex.RUnlock()
+	return len(fake.resetCheckFailuresArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) ResetCheckFailuresCalls(stub func() error) {
+	fake.resetCheckFailuresMutex.Lock()
+	defer fake.resetCheckFailuresMutex.Unlock()
+	fake.ResetCheckFailuresStub = stub
+}
+
+func (fake *FakeResourceConfigScope) ResetCheckFailuresReturns(result1 error) {
+	fake.resetCheckFailuresMutex.Lock()
+	defer fake.resetCheckFailuresMutex.Unlock()
+	fake.ResetCheckFailuresStub = nil
+	fake.resetCheckFailuresReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigScope) ResetCheckFailuresReturnsOnCall(i int, result1 error) {
+	fake.resetCheckFailuresMutex.Lock()
+	defer fake.resetCheckFailuresMutex.Unlock()
+	fake.ResetCheckFailuresStub = nil
+	if fake.resetCheckFailuresReturnsOnCall == nil {
+		fake.resetCheckFailuresReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetCheckFailuresReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigScope) Resource() db.Resource {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
@@ -792,6 +927,66 @@ func (fake *FakeResourceConfigScope) UpdateLastCheckStartTimeReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeResourceConfigScope) UpdateNextCheckTime(arg1 time.Duration) error {
+	fake.updateNextCheckTimeMutex.Lock()
+	ret, specificReturn := fake.updateNextCheckTimeReturnsOnCall[len(fake.updateNextCheckTimeArgsForCall)]
+	fake.updateNextCheckTimeArgsForCall = append(fake.updateNextCheckTimeArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("UpdateNextCheckTime", []interface{}{arg1})
+	fake.updateNextCheckTimeMutex.Unlock()
+	if fake.UpdateNextCheckTimeStub != nil {
+		return fake.UpdateNextCheckTimeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateNextCheckTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigScope) UpdateNextCheckTimeCallCount() int {
+	fake.updateNextCheckTimeMutex.RLock()
+	defer fake.updateNextCheckTimeMutex.RUnlock()
+	return len(fake.updateNextCheckTimeArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateNextCheckTimeCalls(stub func(time.Duration) error) {
+	fake.updateNextCheckTimeMutex.Lock()
+	defer fake.updateNextCheckTimeMutex.Unlock()
+	fake.UpdateNextCheckTimeStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateNextCheckTimeArgsForCall(i int) time.Duration {
+	fake.updateNextCheckTimeMutex.RLock()
+	defer fake.updateNextCheckTimeMutex.RUnlock()
+	argsForCall := fake.updateNextCheckTimeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) UpdateNextCheckTimeReturns(result1 error) {
+	fake.updateNextCheckTimeMutex.Lock()
+	defer fake.updateNextCheckTimeMutex.Unlock()
+	fake.UpdateNextCheckTimeStub = nil
+	fake.updateNextCheckTimeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigScope) UpdateNextCheckTimeReturnsOnCall(i int, result1 error) {
+	fake.updateNextCheckTimeMutex.Lock()
+	defer fake.updateNextCheckTimeMutex.Unlock()
+	fake.UpdateNextCheckTimeStub = nil
+	if fake.updateNextCheckTimeReturnsOnCall == nil {
+		fake.updateNextCheckTimeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateNextCheckTimeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -799,12 +994,16 @@ func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
 	fake.checkErrorMutex.RLock()
 	defer fake.checkErrorMutex.RUnlock()
+	fake.checkFailuresMutex.RLock()
+	defer fake.checkFailuresMutex.RUnlock()
 	fake.findVersionMutex.RLock()
 	defer fake.findVersionMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.latestVersionMutex.RLock()
 	defer fake.latestVersionMutex.RUnlock()
+	fake.resetCheckFailuresMutex.RLock()
+	defer fake.resetCheckFailuresMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceConfigMutex.RLock()
@@ -817,6 +1016,8 @@ func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	defer fake.updateLastCheckEndTimeMutex.RUnlock()
 	fake.updateLastCheckStartTimeMutex.RLock()
 	defer fake.updateLastCheckStartTimeMutex.RUnlock()
+	fake.updateNextCheckTimeMutex.RLock()
+	defer fake.updateNextCheckTimeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
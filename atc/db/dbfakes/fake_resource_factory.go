@@ -3,11 +3,38 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeResourceFactory struct {
+	CheckableCountStub        func() (int, error)
+	checkableCountMutex       sync.RWMutex
+	checkableCountArgsForCall []struct {
+	}
+	checkableCountReturns struct {
+		result1 int
+		result2 error
+	}
+	checkableCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	HeartbeatCheckerStub        func(string, time.Duration) (int, error)
+	heartbeatCheckerMutex       sync.RWMutex
+	heartbeatCheckerArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	heartbeatCheckerReturns struct {
+		result1 int
+		result2 error
+	}
+	heartbeatCheckerReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	VisibleResourcesStub        func([]string) ([]db.Resource, error)
 	visibleResourcesMutex       sync.RWMutex
 	visibleResourcesArgsForCall []struct {
@@ -25,6 +52,125 @@ type FakeResourceFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeResourceFactory) CheckableCount() (int, error) {
+	fake.checkableCountMutex.Lock()
+	ret, specificReturn := fake.checkableCountReturnsOnCall[len(fake.checkableCountArgsForCall)]
+	fake.checkableCountArgsForCall = append(fake.checkableCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CheckableCount", []interface{}{})
+	fake.checkableCountMutex.Unlock()
+	if fake.CheckableCountStub != nil {
+		return fake.CheckableCountStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checkableCountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceFactory) CheckableCountCallCount() int {
+	fake.checkableCountMutex.RLock()
+	defer fake.checkableCountMutex.RUnlock()
+	return len(fake.checkableCountArgsForCall)
+}
+
+func (fake *FakeResourceFactory) CheckableCountCalls(stub func() (int, error)) {
+	fake.checkableCountMutex.Lock()
+	defer fake.checkableCountMutex.Unlock()
+	fake.CheckableCountStub = stub
+}
+
+func (fake *FakeResourceFactory) CheckableCountReturns(result1 int, result2 error) {
+	fake.checkableCountMutex.Lock()
+	defer fake.checkableCountMutex.Unlock()
+	fake.CheckableCountStub = nil
+	fake.checkableCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceFactory) CheckableCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.checkableCountMutex.Lock()
+	defer fake.checkableCountMutex.Unlock()
+	fake.CheckableCountStub = nil
+	if fake.checkableCountReturnsOnCall == nil {
+		fake.checkableCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.checkableCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceFactory) HeartbeatChecker(arg1 string, arg2 time.Duration) (int, error) {
+	fake.heartbeatCheckerMutex.Lock()
+	ret, specificReturn := fake.heartbeatCheckerReturnsOnCall[len(fake.heartbeatCheckerArgsForCall)]
+	fake.heartbeatCheckerArgsForCall = append(fake.heartbeatCheckerArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("HeartbeatChecker", []interface{}{arg1, arg2})
+	fake.heartbeatCheckerMutex.Unlock()
+	if fake.HeartbeatCheckerStub != nil {
+		return fake.HeartbeatCheckerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.heartbeatCheckerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceFactory) HeartbeatCheckerCallCount() int {
+	fake.heartbeatCheckerMutex.RLock()
+	defer fake.heartbeatCheckerMutex.RUnlock()
+	return len(fake.heartbeatCheckerArgsForCall)
+}
+
+func (fake *FakeResourceFactory) HeartbeatCheckerCalls(stub func(string, time.Duration) (int, error)) {
+	fake.heartbeatCheckerMutex.Lock()
+	defer fake.heartbeatCheckerMutex.Unlock()
+	fake.HeartbeatCheckerStub = stub
+}
+
+func (fake *FakeResourceFactory) HeartbeatCheckerArgsForCall(i int) (string, time.Duration) {
+	fake.heartbeatCheckerMutex.RLock()
+	defer fake.heartbeatCheckerMutex.RUnlock()
+	argsForCall := fake.heartbeatCheckerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceFactory) HeartbeatCheckerReturns(result1 int, result2 error) {
+	fake.heartbeatCheckerMutex.Lock()
+	defer fake.heartbeatCheckerMutex.Unlock()
+	fake.HeartbeatCheckerStub = nil
+	fake.heartbeatCheckerReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceFactory) HeartbeatCheckerReturnsOnCall(i int, result1 int, result2 error) {
+	fake.heartbeatCheckerMutex.Lock()
+	defer fake.heartbeatCheckerMutex.Unlock()
+	fake.HeartbeatCheckerStub = nil
+	if fake.heartbeatCheckerReturnsOnCall == nil {
+		fake.heartbeatCheckerReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.heartbeatCheckerReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceFactory) VisibleResources(arg1 []string) ([]db.Resource, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -96,6 +242,10 @@ func (fake *FakeResourceFactory) VisibleResourcesReturnsOnCall(i int, result1 []
 func (fake *FakeResourceFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checkableCountMutex.RLock()
+	defer fake.checkableCountMutex.RUnlock()
+	fake.heartbeatCheckerMutex.RLock()
+	defer fake.heartbeatCheckerMutex.RUnlock()
 	fake.visibleResourcesMutex.RLock()
 	defer fake.visibleResourcesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
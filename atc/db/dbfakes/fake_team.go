@@ -100,6 +100,16 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	CredentialManagerStub        func() *atc.TeamCredentialManager
+	credentialManagerMutex       sync.RWMutex
+	credentialManagerArgsForCall []struct {
+	}
+	credentialManagerReturns struct {
+		result1 *atc.TeamCredentialManager
+	}
+	credentialManagerReturnsOnCall map[int]struct {
+		result1 *atc.TeamCredentialManager
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -371,6 +381,17 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	UpdateCredentialManagerStub        func(*atc.TeamCredentialManager) error
+	updateCredentialManagerMutex       sync.RWMutex
+	updateCredentialManagerArgsForCall []struct {
+		arg1 *atc.TeamCredentialManager
+	}
+	updateCredentialManagerReturns struct {
+		result1 error
+	}
+	updateCredentialManagerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -827,6 +848,58 @@ func (fake *FakeTeam) CreateStartedBuildReturnsOnCall(i int, result1 db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CredentialManager() *atc.TeamCredentialManager {
+	fake.credentialManagerMutex.Lock()
+	ret, specificReturn := fake.credentialManagerReturnsOnCall[len(fake.credentialManagerArgsForCall)]
+	fake.credentialManagerArgsForCall = append(fake.credentialManagerArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CredentialManager", []interface{}{})
+	fake.credentialManagerMutex.Unlock()
+	if fake.CredentialManagerStub != nil {
+		return fake.CredentialManagerStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.credentialManagerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) CredentialManagerCallCount() int {
+	fake.credentialManagerMutex.RLock()
+	defer fake.credentialManagerMutex.RUnlock()
+	return len(fake.credentialManagerArgsForCall)
+}
+
+func (fake *FakeTeam) CredentialManagerCalls(stub func() *atc.TeamCredentialManager) {
+	fake.credentialManagerMutex.Lock()
+	defer fake.credentialManagerMutex.Unlock()
+	fake.CredentialManagerStub = stub
+}
+
+func (fake *FakeTeam) CredentialManagerReturns(result1 *atc.TeamCredentialManager) {
+	fake.credentialManagerMutex.Lock()
+	defer fake.credentialManagerMutex.Unlock()
+	fake.CredentialManagerStub = nil
+	fake.credentialManagerReturns = struct {
+		result1 *atc.TeamCredentialManager
+	}{result1}
+}
+
+func (fake *FakeTeam) CredentialManagerReturnsOnCall(i int, result1 *atc.TeamCredentialManager) {
+	fake.credentialManagerMutex.Lock()
+	defer fake.credentialManagerMutex.Unlock()
+	fake.CredentialManagerStub = nil
+	if fake.credentialManagerReturnsOnCall == nil {
+		fake.credentialManagerReturnsOnCall = make(map[int]struct {
+			result1 *atc.TeamCredentialManager
+		})
+	}
+	fake.credentialManagerReturnsOnCall[i] = struct {
+		result1 *atc.TeamCredentialManager
+	}{result1}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -2072,6 +2145,66 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UpdateCredentialManager(arg1 *atc.TeamCredentialManager) error {
+	fake.updateCredentialManagerMutex.Lock()
+	ret, specificReturn := fake.updateCredentialManagerReturnsOnCall[len(fake.updateCredentialManagerArgsForCall)]
+	fake.updateCredentialManagerArgsForCall = append(fake.updateCredentialManagerArgsForCall, struct {
+		arg1 *atc.TeamCredentialManager
+	}{arg1})
+	fake.recordInvocation("UpdateCredentialManager", []interface{}{arg1})
+	fake.updateCredentialManagerMutex.Unlock()
+	if fake.UpdateCredentialManagerStub != nil {
+		return fake.UpdateCredentialManagerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateCredentialManagerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateCredentialManagerCallCount() int {
+	fake.updateCredentialManagerMutex.RLock()
+	defer fake.updateCredentialManagerMutex.RUnlock()
+	return len(fake.updateCredentialManagerArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateCredentialManagerCalls(stub func(*atc.TeamCredentialManager) error) {
+	fake.updateCredentialManagerMutex.Lock()
+	defer fake.updateCredentialManagerMutex.Unlock()
+	fake.UpdateCredentialManagerStub = stub
+}
+
+func (fake *FakeTeam) UpdateCredentialManagerArgsForCall(i int) *atc.TeamCredentialManager {
+	fake.updateCredentialManagerMutex.RLock()
+	defer fake.updateCredentialManagerMutex.RUnlock()
+	argsForCall := fake.updateCredentialManagerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateCredentialManagerReturns(result1 error) {
+	fake.updateCredentialManagerMutex.Lock()
+	defer fake.updateCredentialManagerMutex.Unlock()
+	fake.UpdateCredentialManagerStub = nil
+	fake.updateCredentialManagerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCredentialManagerReturnsOnCall(i int, result1 error) {
+	fake.updateCredentialManagerMutex.Lock()
+	defer fake.updateCredentialManagerMutex.Unlock()
+	fake.UpdateCredentialManagerStub = nil
+	if fake.updateCredentialManagerReturnsOnCall == nil {
+		fake.updateCredentialManagerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCredentialManagerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2259,6 +2392,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
+	fake.credentialManagerMutex.RLock()
+	defer fake.credentialManagerMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.findCheckContainersMutex.RLock()
@@ -2299,6 +2434,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.updateCredentialManagerMutex.RLock()
+	defer fake.updateCredentialManagerMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	fake.visiblePipelinesMutex.RLock()
@@ -20,6 +20,18 @@ type FakeWorker struct {
 	activeContainersReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ActiveTasksStub        func() (int, error)
+	activeTasksMutex       sync.RWMutex
+	activeTasksArgsForCall []struct {
+	}
+	activeTasksReturns struct {
+		result1 int
+		result2 error
+	}
+	activeTasksReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	ActiveVolumesStub        func() int
 	activeVolumesMutex       sync.RWMutex
 	activeVolumesArgsForCall []struct {
@@ -64,6 +76,33 @@ type FakeWorker struct {
 		result1 db.CreatingContainer
 		result2 error
 	}
+	CreateTaskContainerStub        func(db.ContainerOwner, db.ContainerMetadata, int) (db.CreatingContainer, bool, error)
+	createTaskContainerMutex       sync.RWMutex
+	createTaskContainerArgsForCall []struct {
+		arg1 db.ContainerOwner
+		arg2 db.ContainerMetadata
+		arg3 int
+	}
+	createTaskContainerReturns struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}
+	createTaskContainerReturnsOnCall map[int]struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}
+	DecreaseActiveTasksStub        func() error
+	decreaseActiveTasksMutex       sync.RWMutex
+	decreaseActiveTasksArgsForCall []struct {
+	}
+	decreaseActiveTasksReturns struct {
+		result1 error
+	}
+	decreaseActiveTasksReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -351,6 +390,61 @@ func (fake *FakeWorker) ActiveContainersReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ActiveTasks() (int, error) {
+	fake.activeTasksMutex.Lock()
+	ret, specificReturn := fake.activeTasksReturnsOnCall[len(fake.activeTasksArgsForCall)]
+	fake.activeTasksArgsForCall = append(fake.activeTasksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ActiveTasks", []interface{}{})
+	fake.activeTasksMutex.Unlock()
+	if fake.ActiveTasksStub != nil {
+		return fake.ActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.activeTasksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ActiveTasksCallCount() int {
+	fake.activeTasksMutex.RLock()
+	defer fake.activeTasksMutex.RUnlock()
+	return len(fake.activeTasksArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveTasksCalls(stub func() (int, error)) {
+	fake.activeTasksMutex.Lock()
+	defer fake.activeTasksMutex.Unlock()
+	fake.ActiveTasksStub = stub
+}
+
+func (fake *FakeWorker) ActiveTasksReturns(result1 int, result2 error) {
+	fake.activeTasksMutex.Lock()
+	defer fake.activeTasksMutex.Unlock()
+	fake.ActiveTasksStub = nil
+	fake.activeTasksReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ActiveTasksReturnsOnCall(i int, result1 int, result2 error) {
+	fake.activeTasksMutex.Lock()
+	defer fake.activeTasksMutex.Unlock()
+	fake.ActiveTasksStub = nil
+	if fake.activeTasksReturnsOnCall == nil {
+		fake.activeTasksReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.activeTasksReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) ActiveVolumes() int {
 	fake.activeVolumesMutex.Lock()
 	ret, specificReturn := fake.activeVolumesReturnsOnCall[len(fake.activeVolumesArgsForCall)]
@@ -571,6 +665,126 @@ func (fake *FakeWorker) CreateContainerReturnsOnCall(i int, result1 db.CreatingC
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) CreateTaskContainer(arg1 db.ContainerOwner, arg2 db.ContainerMetadata, arg3 int) (db.CreatingContainer, bool, error) {
+	fake.createTaskContainerMutex.Lock()
+	ret, specificReturn := fake.createTaskContainerReturnsOnCall[len(fake.createTaskContainerArgsForCall)]
+	fake.createTaskContainerArgsForCall = append(fake.createTaskContainerArgsForCall, struct {
+		arg1 db.ContainerOwner
+		arg2 db.ContainerMetadata
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateTaskContainer", []interface{}{arg1, arg2, arg3})
+	fake.createTaskContainerMutex.Unlock()
+	if fake.CreateTaskContainerStub != nil {
+		return fake.CreateTaskContainerStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createTaskContainerReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) CreateTaskContainerCallCount() int {
+	fake.createTaskContainerMutex.RLock()
+	defer fake.createTaskContainerMutex.RUnlock()
+	return len(fake.createTaskContainerArgsForCall)
+}
+
+func (fake *FakeWorker) CreateTaskContainerCalls(stub func(db.ContainerOwner, db.ContainerMetadata, int) (db.CreatingContainer, bool, error)) {
+	fake.createTaskContainerMutex.Lock()
+	defer fake.createTaskContainerMutex.Unlock()
+	fake.CreateTaskContainerStub = stub
+}
+
+func (fake *FakeWorker) CreateTaskContainerArgsForCall(i int) (db.ContainerOwner, db.ContainerMetadata, int) {
+	fake.createTaskContainerMutex.RLock()
+	defer fake.createTaskContainerMutex.RUnlock()
+	argsForCall := fake.createTaskContainerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorker) CreateTaskContainerReturns(result1 db.CreatingContainer, result2 bool, result3 error) {
+	fake.createTaskContainerMutex.Lock()
+	defer fake.createTaskContainerMutex.Unlock()
+	fake.CreateTaskContainerStub = nil
+	fake.createTaskContainerReturns = struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) CreateTaskContainerReturnsOnCall(i int, result1 db.CreatingContainer, result2 bool, result3 error) {
+	fake.createTaskContainerMutex.Lock()
+	defer fake.createTaskContainerMutex.Unlock()
+	fake.CreateTaskContainerStub = nil
+	if fake.createTaskContainerReturnsOnCall == nil {
+		fake.createTaskContainerReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingContainer
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createTaskContainerReturnsOnCall[i] = struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) DecreaseActiveTasks() error {
+	fake.decreaseActiveTasksMutex.Lock()
+	ret, specificReturn := fake.decreaseActiveTasksReturnsOnCall[len(fake.decreaseActiveTasksArgsForCall)]
+	fake.decreaseActiveTasksArgsForCall = append(fake.decreaseActiveTasksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DecreaseActiveTasks", []interface{}{})
+	fake.decreaseActiveTasksMutex.Unlock()
+	if fake.DecreaseActiveTasksStub != nil {
+		return fake.DecreaseActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.decreaseActiveTasksReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) DecreaseActiveTasksCallCount() int {
+	fake.decreaseActiveTasksMutex.RLock()
+	defer fake.decreaseActiveTasksMutex.RUnlock()
+	return len(fake.decreaseActiveTasksArgsForCall)
+}
+
+func (fake *FakeWorker) DecreaseActiveTasksCalls(stub func() error) {
+	fake.decreaseActiveTasksMutex.Lock()
+	defer fake.decreaseActiveTasksMutex.Unlock()
+	fake.DecreaseActiveTasksStub = stub
+}
+
+func (fake *FakeWorker) DecreaseActiveTasksReturns(result1 error) {
+	fake.decreaseActiveTasksMutex.Lock()
+	defer fake.decreaseActiveTasksMutex.Unlock()
+	fake.DecreaseActiveTasksStub = nil
+	fake.decreaseActiveTasksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) DecreaseActiveTasksReturnsOnCall(i int, result1 error) {
+	fake.decreaseActiveTasksMutex.Lock()
+	defer fake.decreaseActiveTasksMutex.Unlock()
+	fake.DecreaseActiveTasksStub = nil
+	if fake.decreaseActiveTasksReturnsOnCall == nil {
+		fake.decreaseActiveTasksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.decreaseActiveTasksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -1743,6 +1957,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
+	fake.activeTasksMutex.RLock()
+	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
@@ -1751,6 +1967,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
 	defer fake.createContainerMutex.RUnlock()
+	fake.createTaskContainerMutex.RLock()
+	defer fake.createTaskContainerMutex.RUnlock()
+	fake.decreaseActiveTasksMutex.RLock()
+	defer fake.decreaseActiveTasksMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.ephemeralMutex.RLock()
@@ -56,6 +56,18 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
+	ResetActiveTasksStub        func() ([]string, error)
+	resetActiveTasksMutex       sync.RWMutex
+	resetActiveTasksArgsForCall []struct {
+	}
+	resetActiveTasksReturns struct {
+		result1 []string
+		result2 error
+	}
+	resetActiveTasksReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	StallUnresponsiveWorkersStub        func() ([]string, error)
 	stallUnresponsiveWorkersMutex       sync.RWMutex
 	stallUnresponsiveWorkersArgsForCall []struct {
@@ -292,6 +304,61 @@ func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerLifecycle) ResetActiveTasks() ([]string, error) {
+	fake.resetActiveTasksMutex.Lock()
+	ret, specificReturn := fake.resetActiveTasksReturnsOnCall[len(fake.resetActiveTasksArgsForCall)]
+	fake.resetActiveTasksArgsForCall = append(fake.resetActiveTasksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResetActiveTasks", []interface{}{})
+	fake.resetActiveTasksMutex.Unlock()
+	if fake.ResetActiveTasksStub != nil {
+		return fake.ResetActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resetActiveTasksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) ResetActiveTasksCallCount() int {
+	fake.resetActiveTasksMutex.RLock()
+	defer fake.resetActiveTasksMutex.RUnlock()
+	return len(fake.resetActiveTasksArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ResetActiveTasksCalls(stub func() ([]string, error)) {
+	fake.resetActiveTasksMutex.Lock()
+	defer fake.resetActiveTasksMutex.Unlock()
+	fake.ResetActiveTasksStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ResetActiveTasksReturns(result1 []string, result2 error) {
+	fake.resetActiveTasksMutex.Lock()
+	defer fake.resetActiveTasksMutex.Unlock()
+	fake.ResetActiveTasksStub = nil
+	fake.resetActiveTasksReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ResetActiveTasksReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.resetActiveTasksMutex.Lock()
+	defer fake.resetActiveTasksMutex.Unlock()
+	fake.ResetActiveTasksStub = nil
+	if fake.resetActiveTasksReturnsOnCall == nil {
+		fake.resetActiveTasksReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.resetActiveTasksReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 	fake.stallUnresponsiveWorkersMutex.Lock()
 	ret, specificReturn := fake.stallUnresponsiveWorkersReturnsOnCall[len(fake.stallUnresponsiveWorkersArgsForCall)]
@@ -358,6 +425,8 @@ func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	defer fake.getWorkerStateByNameMutex.RUnlock()
 	fake.landFinishedLandingWorkersMutex.RLock()
 	defer fake.landFinishedLandingWorkersMutex.RUnlock()
+	fake.resetActiveTasksMutex.RLock()
+	defer fake.resetActiveTasksMutex.RUnlock()
 	fake.stallUnresponsiveWorkersMutex.RLock()
 	defer fake.stallUnresponsiveWorkersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
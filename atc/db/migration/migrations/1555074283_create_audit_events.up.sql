@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE audit_events (
+    id SERIAL PRIMARY KEY,
+    time TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    user_name TEXT NOT NULL,
+    team_name TEXT NOT NULL,
+    action TEXT NOT NULL,
+    method TEXT NOT NULL,
+    target TEXT NOT NULL,
+    status INTEGER NOT NULL
+  );
+
+  CREATE INDEX audit_events_team_name_idx ON audit_events (team_name);
+COMMIT;
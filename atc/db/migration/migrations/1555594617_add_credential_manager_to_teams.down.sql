@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE teams DROP COLUMN credential_manager, DROP COLUMN credential_manager_nonce;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  DROP INDEX builds_resource_id;
+
+  ALTER TABLE builds DROP COLUMN resource_id;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN resource_id integer REFERENCES resources (id) ON DELETE CASCADE;
+
+  CREATE INDEX builds_resource_id ON builds USING btree (resource_id);
+COMMIT;
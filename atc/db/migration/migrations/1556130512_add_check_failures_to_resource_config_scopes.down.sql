@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN next_check_time,
+    DROP COLUMN check_failures;
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  DROP TRIGGER IF EXISTS archived_build_delete_trigger ON builds;
+
+  DROP FUNCTION IF EXISTS on_archived_build_delete();
+
+  DROP TABLE deleted_archived_builds;
+
+  ALTER TABLE builds DROP COLUMN events_archived_time;
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN events_archived_time timestamp with time zone;
+
+  CREATE TABLE deleted_archived_builds (
+      build_id integer PRIMARY KEY
+  );
+
+  CREATE OR REPLACE FUNCTION on_archived_build_delete() RETURNS TRIGGER AS $$
+  BEGIN
+          INSERT INTO deleted_archived_builds (build_id) VALUES (OLD.id) ON CONFLICT DO NOTHING;
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  DROP TRIGGER IF EXISTS archived_build_delete_trigger ON builds;
+  CREATE TRIGGER archived_build_delete_trigger AFTER DELETE on builds FOR EACH ROW WHEN (OLD.events_archived) EXECUTE PROCEDURE on_archived_build_delete();
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  CREATE TABLE resource_checkers (
+      atc_id text PRIMARY KEY,
+      expires timestamp with time zone NOT NULL
+  );
+COMMIT;
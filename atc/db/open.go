@@ -114,20 +114,29 @@ func shouldRetry(err error) bool {
 	return false
 }
 
-var encryptedColumns = map[string]string{
-	"teams":          "legacy_auth",
-	"resources":      "config",
-	"jobs":           "config",
-	"resource_types": "config",
-	"builds":         "private_plan",
+type encryptedColumn struct {
+	table  string
+	column string
+	nonce  string
+}
+
+var encryptedColumns = []encryptedColumn{
+	{table: "teams", column: "legacy_auth", nonce: "nonce"},
+	{table: "teams", column: "credential_manager", nonce: "credential_manager_nonce"},
+	{table: "resources", column: "config", nonce: "nonce"},
+	{table: "jobs", column: "config", nonce: "nonce"},
+	{table: "resource_types", column: "config", nonce: "nonce"},
+	{table: "builds", column: "private_plan", nonce: "nonce"},
 }
 
 func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
-	for table, col := range encryptedColumns {
+	for _, ec := range encryptedColumns {
+		table, col, nonceCol := ec.table, ec.column, ec.nonce
+
 		rows, err := sqlDB.Query(`
 			SELECT id, ` + col + `
 			FROM ` + table + `
-			WHERE nonce IS NULL
+			WHERE ` + nonceCol + ` IS NULL
 			AND ` + col + ` IS NOT NULL
 		`)
 		if err != nil {
@@ -135,7 +144,8 @@ func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) e
 		}
 
 		tLog := logger.Session("table", lager.Data{
-			"table": table,
+			"table":  table,
+			"column": col,
 		})
 
 		encryptedRows := 0
@@ -168,7 +178,7 @@ func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) e
 
 			_, err = sqlDB.Exec(`
 				UPDATE `+table+`
-				SET `+col+` = $1, nonce = $2
+				SET `+col+` = $1, `+nonceCol+` = $2
 				WHERE id = $3
 			`, encrypted, nonce, id)
 			if err != nil {
@@ -190,18 +200,21 @@ func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) e
 }
 
 func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.Key) error {
-	for table, col := range encryptedColumns {
+	for _, ec := range encryptedColumns {
+		table, col, nonceCol := ec.table, ec.column, ec.nonce
+
 		rows, err := sqlDB.Query(`
-			SELECT id, nonce, ` + col + `
+			SELECT id, ` + nonceCol + `, ` + col + `
 			FROM ` + table + `
-			WHERE nonce IS NOT NULL
+			WHERE ` + nonceCol + ` IS NOT NULL
 		`)
 		if err != nil {
 			return err
 		}
 
 		tLog := logger.Session("table", lager.Data{
-			"table": table,
+			"table":  table,
+			"column": col,
 		})
 
 		decryptedRows := 0
@@ -230,7 +243,7 @@ func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.K
 
 			_, err = sqlDB.Exec(`
 				UPDATE `+table+`
-				SET `+col+` = $1, nonce = NULL
+				SET `+col+` = $1, `+nonceCol+` = NULL
 				WHERE id = $2
 			`, decrypted, id)
 			if err != nil {
@@ -254,18 +267,21 @@ func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.K
 var ErrEncryptedWithUnknownKey = errors.New("row encrypted with neither old nor new key")
 
 func encryptWithNewKey(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Key, oldKey *encryption.Key) error {
-	for table, col := range encryptedColumns {
+	for _, ec := range encryptedColumns {
+		table, col, nonceCol := ec.table, ec.column, ec.nonce
+
 		rows, err := sqlDB.Query(`
-			SELECT id, nonce, ` + col + `
+			SELECT id, ` + nonceCol + `, ` + col + `
 			FROM ` + table + `
-			WHERE nonce IS NOT NULL
+			WHERE ` + nonceCol + ` IS NOT NULL
 		`)
 		if err != nil {
 			return err
 		}
 
 		tLog := logger.Session("table", lager.Data{
-			"table": table,
+			"table":  table,
+			"column": col,
 		})
 
 		encryptedRows := 0
@@ -306,7 +322,7 @@ func encryptWithNewKey(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Ke
 
 			_, err = sqlDB.Exec(`
 				UPDATE `+table+`
-				SET `+col+` = $1, nonce = $2
+				SET `+col+` = $1, `+nonceCol+` = $2
 				WHERE id = $3
 			`, encrypted, newNonce, id)
 			if err != nil {
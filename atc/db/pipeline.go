@@ -459,12 +459,12 @@ func (p *pipeline) resource(where map[string]interface{}) (Resource, bool, error
 
 func (p *pipeline) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(
-		buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id}), minMaxIdQuery, page, p.conn, p.lockFactory)
+		buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id, "b.resource_id": nil}), minMaxIdQuery, page, p.conn, p.lockFactory)
 }
 
 func (p *pipeline) BuildsWithTime(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithDates(
-		buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id}), minMaxIdQuery, page, p.conn, p.lockFactory)
+		buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id, "b.resource_id": nil}), minMaxIdQuery, page, p.conn, p.lockFactory)
 }
 
 func (p *pipeline) Resources() (Resources, error) {
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/lib/pq"
 )
 
@@ -34,7 +35,10 @@ type Resource interface {
 	Tags() atc.Tags
 	CheckSetupError() error
 	CheckError() error
+	CheckFailures() int
+	NextCheckTime() time.Time
 	WebhookToken() string
+	WebhookConfig() *atc.WebhookConfig
 	ConfigPinnedVersion() atc.Version
 	APIPinnedVersion() atc.Version
 	PinComment() string
@@ -59,10 +63,13 @@ type Resource interface {
 	SetCheckSetupError(error) error
 	NotifyScan() error
 
+	CreateCheckBuild(lager.Logger) (Build, lock.Lock, error)
+	CheckBuilds(page Page) ([]Build, Pagination, error)
+
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, rs.last_check_start_time, rs.last_check_end_time, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, rs.last_check_start_time, rs.last_check_end_time, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text, rs.check_failures, rs.next_check_time").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
 	Join("teams t ON t.id = p.team_id").
@@ -86,7 +93,10 @@ type resource struct {
 	tags                  atc.Tags
 	checkSetupError       error
 	checkError            error
+	checkFailures         int
+	nextCheckTime         time.Time
 	webhookToken          string
+	webhookConfig         *atc.WebhookConfig
 	configPinnedVersion   atc.Version
 	apiPinnedVersion      atc.Version
 	pinComment            string
@@ -138,28 +148,31 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 	return configs
 }
 
-func (r *resource) ID() int                          { return r.id }
-func (r *resource) Name() string                     { return r.name }
-func (r *resource) Public() bool                     { return r.public }
-func (r *resource) PipelineID() int                  { return r.pipelineID }
-func (r *resource) PipelineName() string             { return r.pipelineName }
-func (r *resource) TeamName() string                 { return r.teamName }
-func (r *resource) Type() string                     { return r.type_ }
-func (r *resource) Source() atc.Source               { return r.source }
-func (r *resource) CheckEvery() string               { return r.checkEvery }
-func (r *resource) CheckTimeout() string             { return r.checkTimeout }
-func (r *resource) LastCheckStartTime() time.Time    { return r.lastCheckStartTime }
-func (r *resource) LastCheckEndTime() time.Time      { return r.lastCheckEndTime }
-func (r *resource) Tags() atc.Tags                   { return r.tags }
-func (r *resource) CheckSetupError() error           { return r.checkSetupError }
-func (r *resource) CheckError() error                { return r.checkError }
-func (r *resource) WebhookToken() string             { return r.webhookToken }
-func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
-func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
-func (r *resource) PinComment() string               { return r.pinComment }
-func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
-func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
-func (r *resource) Icon() string                     { return r.icon }
+func (r *resource) ID() int                           { return r.id }
+func (r *resource) Name() string                      { return r.name }
+func (r *resource) Public() bool                      { return r.public }
+func (r *resource) PipelineID() int                   { return r.pipelineID }
+func (r *resource) PipelineName() string              { return r.pipelineName }
+func (r *resource) TeamName() string                  { return r.teamName }
+func (r *resource) Type() string                      { return r.type_ }
+func (r *resource) Source() atc.Source                { return r.source }
+func (r *resource) CheckEvery() string                { return r.checkEvery }
+func (r *resource) CheckTimeout() string              { return r.checkTimeout }
+func (r *resource) LastCheckStartTime() time.Time     { return r.lastCheckStartTime }
+func (r *resource) LastCheckEndTime() time.Time       { return r.lastCheckEndTime }
+func (r *resource) Tags() atc.Tags                    { return r.tags }
+func (r *resource) CheckSetupError() error            { return r.checkSetupError }
+func (r *resource) CheckError() error                 { return r.checkError }
+func (r *resource) CheckFailures() int                { return r.checkFailures }
+func (r *resource) NextCheckTime() time.Time          { return r.nextCheckTime }
+func (r *resource) WebhookToken() string              { return r.webhookToken }
+func (r *resource) WebhookConfig() *atc.WebhookConfig { return r.webhookConfig }
+func (r *resource) ConfigPinnedVersion() atc.Version  { return r.configPinnedVersion }
+func (r *resource) APIPinnedVersion() atc.Version     { return r.apiPinnedVersion }
+func (r *resource) PinComment() string                { return r.pinComment }
+func (r *resource) ResourceConfigID() int             { return r.resourceConfigID }
+func (r *resource) ResourceConfigScopeID() int        { return r.resourceConfigScopeID }
+func (r *resource) Icon() string                      { return r.icon }
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -598,20 +611,129 @@ func (r *resource) NotifyScan() error {
 	return r.conn.Bus().Notify(fmt.Sprintf("resource_scan_%d", r.id))
 }
 
+// ErrCheckBuildAlreadyTracked is returned if the tracking lock of a check
+// build which has just been created is somehow already held.
+var ErrCheckBuildAlreadyTracked = errors.New("check build is already tracked")
+
+// checkBuildsRetained is the number of completed check builds kept for each
+// resource; older ones are removed along with their events as new checks are
+// recorded.
+const checkBuildsRetained = 50
+
+// CreateCheckBuild records the start of a check of the resource as a build,
+// to which the check's output can be saved as events.
+//
+// The build's tracking lock is acquired before the build can be seen by
+// anyone else, and must be released once the build has been finished. While
+// it is held, the build is known to be running, so a check build which is
+// left started without it was interrupted, e.g. by its ATC going away.
+func (r *resource) CreateCheckBuild(logger lager.Logger) (Build, lock.Lock, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer Rollback(tx)
+
+	build := &build{conn: r.conn, lockFactory: r.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":        "check",
+		"pipeline_id": r.pipelineID,
+		"team_id":     sq.Expr("(SELECT team_id FROM pipelines WHERE id = ?)", r.pipelineID),
+		"resource_id": r.id,
+		"status":      BuildStatusStarted,
+		"start_time":  sq.Expr("now()"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = build.saveEvent(tx, event.Status{
+		Status: atc.StatusStarted,
+		Time:   build.StartTime().Unix(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = r.pruneCheckBuilds(tx, build.eventsTable())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trackingLock, acquired, err := build.AcquireTrackingLock(logger, time.Minute)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !acquired {
+		return nil, nil, ErrCheckBuildAlreadyTracked
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		_ = trackingLock.Release()
+		return nil, nil, err
+	}
+
+	err = r.conn.Bus().Notify(buildEventsChannel(build.id))
+	if err != nil {
+		_ = trackingLock.Release()
+		return nil, nil, err
+	}
+
+	return build, trackingLock, nil
+}
+
+func (r *resource) pruneCheckBuilds(tx Tx, eventsTable string) error {
+	expired := `
+		SELECT id
+		FROM builds
+		WHERE resource_id = $1
+		AND completed
+		ORDER BY id DESC
+		OFFSET $2
+	`
+
+	_, err := tx.Exec(fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE build_id IN (%s)
+	`, eventsTable, expired), r.id, checkBuildsRetained)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		DELETE FROM builds
+		WHERE id IN (%s)
+	`, expired), r.id, checkBuildsRetained)
+	return err
+}
+
+// CheckBuilds returns the builds recording the resource's checks, most recent
+// first.
+func (r *resource) CheckBuilds(page Page) ([]Build, Pagination, error) {
+	return getBuildsWithPagination(
+		buildsQuery.Where(sq.Eq{"b.resource_id": r.id}), minMaxIdQuery, page, r.conn, r.lockFactory)
+}
+
 func scanResource(r *resource, row scannable) error {
 	var (
 		configBlob                                                                  []byte
 		checkErr, rcsCheckErr, nonce, rcID, rcScopeID, apiPinnedVersion, pinComment sql.NullString
-		lastCheckStartTime, lastCheckEndTime                                        pq.NullTime
+		lastCheckStartTime, lastCheckEndTime, nextCheckTime                         pq.NullTime
+		checkFailures                                                               sql.NullInt64
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment, &checkFailures, &nextCheckTime)
 	if err != nil {
 		return err
 	}
 
 	r.lastCheckStartTime = lastCheckStartTime.Time
 	r.lastCheckEndTime = lastCheckEndTime.Time
+	r.checkFailures = int(checkFailures.Int64)
+	r.nextCheckTime = nextCheckTime.Time
 
 	es := r.conn.EncryptionStrategy()
 
@@ -638,6 +760,7 @@ func scanResource(r *resource, row scannable) error {
 	r.checkTimeout = config.CheckTimeout
 	r.tags = config.Tags
 	r.webhookToken = config.WebhookToken
+	r.webhookConfig = config.Webhook
 	r.configPinnedVersion = config.Version
 	r.icon = config.Icon
 
@@ -77,13 +77,14 @@ func (r *resourceConfig) OriginBaseResourceType() *UsedBaseResourceType {
 
 func (r *resourceConfig) FindResourceConfigScopeByID(resourceConfigScopeID int, resource Resource) (ResourceConfigScope, bool, error) {
 	var (
-		id           int
-		rcID         int
-		rID          sql.NullString
-		checkErrBlob sql.NullString
+		id            int
+		rcID          int
+		rID           sql.NullString
+		checkErrBlob  sql.NullString
+		checkFailures int
 	)
 
-	err := psql.Select("id, resource_id, resource_config_id, check_error").
+	err := psql.Select("id, resource_id, resource_config_id, check_error, check_failures").
 		From("resource_config_scopes").
 		Where(sq.Eq{
 			"id":                 resourceConfigScopeID,
@@ -91,7 +92,7 @@ func (r *resourceConfig) FindResourceConfigScopeByID(resourceConfigScopeID int,
 		}).
 		RunWith(r.conn).
 		QueryRow().
-		Scan(&id, &rID, &rcID, &checkErrBlob)
+		Scan(&id, &rID, &rcID, &checkErrBlob, &checkFailures)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -122,6 +123,7 @@ func (r *resourceConfig) FindResourceConfigScopeByID(resourceConfigScopeID int,
 		resource:       uniqueResource,
 		resourceConfig: r,
 		checkError:     checkErr,
+		checkFailures:  checkFailures,
 		conn:           r.conn,
 		lockFactory:    r.lockFactory}, true, nil
 }
@@ -310,8 +312,9 @@ func findOrCreateResourceConfigScope(tx Tx, conn Conn, lockFactory lock.LockFact
 
 	var scopeID int
 	var checkErr error
+	var checkFailures int
 
-	rows, err := psql.Select("id, check_error").
+	rows, err := psql.Select("id, check_error, check_failures").
 		From("resource_config_scopes").
 		Where(sq.Eq{
 			"resource_id":        resourceID,
@@ -326,7 +329,7 @@ func findOrCreateResourceConfigScope(tx Tx, conn Conn, lockFactory lock.LockFact
 	if rows.Next() {
 		var checkErrBlob sql.NullString
 
-		err = rows.Scan(&scopeID, &checkErrBlob)
+		err = rows.Scan(&scopeID, &checkErrBlob, &checkFailures)
 		if err != nil {
 			return nil, err
 		}
@@ -390,6 +393,7 @@ func findOrCreateResourceConfigScope(tx Tx, conn Conn, lockFactory lock.LockFact
 		resource:       uniqueResource,
 		resourceConfig: resourceConfig,
 		checkError:     checkErr,
+		checkFailures:  checkFailures,
 		conn:           conn,
 		lockFactory:    lockFactory,
 	}, nil
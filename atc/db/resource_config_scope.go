@@ -25,12 +25,15 @@ type ResourceConfigScope interface {
 	Resource() Resource
 	ResourceConfig() ResourceConfig
 	CheckError() error
+	CheckFailures() int
 
 	SaveVersions(versions []atc.Version) error
 	FindVersion(atc.Version) (ResourceConfigVersion, bool, error)
 	LatestVersion() (ResourceConfigVersion, bool, error)
 
 	SetCheckError(error) error
+	ResetCheckFailures() error
+	UpdateNextCheckTime(interval time.Duration) error
 
 	AcquireResourceCheckingLock(
 		logger lager.Logger,
@@ -50,6 +53,7 @@ type resourceConfigScope struct {
 	resource       Resource
 	resourceConfig ResourceConfig
 	checkError     error
+	checkFailures  int
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -59,6 +63,7 @@ func (r *resourceConfigScope) ID() int                        { return r.id }
 func (r *resourceConfigScope) Resource() Resource             { return r.resource }
 func (r *resourceConfigScope) ResourceConfig() ResourceConfig { return r.resourceConfig }
 func (r *resourceConfigScope) CheckError() error              { return r.checkError }
+func (r *resourceConfigScope) CheckFailures() int             { return r.checkFailures }
 
 // SaveVersions stores a list of version in the db for a resource config
 // Each version will also have its check order field updated and the
@@ -159,18 +164,24 @@ func (r *resourceConfigScope) LatestVersion() (ResourceConfigVersion, bool, erro
 	return rcv, true, nil
 }
 
+// SetCheckError records the outcome of a check. Consecutive failures are
+// counted so that checks can be backed off, and the count is reset once a
+// check succeeds.
 func (r *resourceConfigScope) SetCheckError(cause error) error {
 	var err error
 
 	if cause == nil {
 		_, err = psql.Update("resource_config_scopes").
 			Set("check_error", nil).
+			Set("check_failures", 0).
+			Set("next_check_time", nil).
 			Where(sq.Eq{"id": r.id}).
 			RunWith(r.conn).
 			Exec()
 	} else {
 		_, err = psql.Update("resource_config_scopes").
 			Set("check_error", cause.Error()).
+			Set("check_failures", sq.Expr("check_failures + 1")).
 			Where(sq.Eq{"id": r.id}).
 			RunWith(r.conn).
 			Exec()
@@ -179,6 +190,35 @@ func (r *resourceConfigScope) SetCheckError(cause error) error {
 	return err
 }
 
+// ResetCheckFailures forgets any consecutive check failures, e.g. when a
+// check is requested by a user, without clearing the last check error.
+func (r *resourceConfigScope) ResetCheckFailures() error {
+	_, err := psql.Update("resource_config_scopes").
+		Set("check_failures", 0).
+		Set("next_check_time", nil).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	r.checkFailures = 0
+
+	return nil
+}
+
+// UpdateNextCheckTime records when the next check is due relative to the
+// start of the last check, for checks which are being backed off.
+func (r *resourceConfigScope) UpdateNextCheckTime(interval time.Duration) error {
+	_, err := r.conn.Exec(`
+		UPDATE resource_config_scopes
+		SET next_check_time = last_check_start_time + ($2 || ' SECONDS')::INTERVAL
+		WHERE id = $1
+	`, r.id, interval.Seconds())
+	return err
+}
+
 func (r *resourceConfigScope) AcquireResourceCheckingLock(
 	logger lager.Logger,
 	interval time.Duration,
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"errors"
 	"time"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
@@ -299,6 +300,100 @@ var _ = Describe("Resource Config Scope", func() {
 		})
 	})
 
+	Describe("SetCheckError", func() {
+		var (
+			someResource        db.Resource
+			resourceConfigScope db.ResourceConfigScope
+		)
+
+		BeforeEach(func() {
+			var err error
+			var found bool
+
+			someResource, found, err = defaultPipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pipelineResourceTypes, err := defaultPipeline.ResourceTypes()
+			Expect(err).ToNot(HaveOccurred())
+
+			resourceConfigScope, err = someResource.SetResourceConfig(
+				logger,
+				someResource.Source(),
+				creds.NewVersionedResourceTypes(template.StaticVariables{}, pipelineResourceTypes.Deserialize()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(resourceConfigScope.SetCheckError(errors.New("oops"))).To(Succeed())
+			Expect(resourceConfigScope.SetCheckError(errors.New("oops again"))).To(Succeed())
+		})
+
+		It("counts consecutive failures", func() {
+			_, err := someResource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(someResource.CheckError()).To(Equal(errors.New("oops again")))
+			Expect(someResource.CheckFailures()).To(Equal(2))
+		})
+
+		Context("when the next check is backed off", func() {
+			BeforeEach(func() {
+				_, err := resourceConfigScope.UpdateLastCheckStartTime(time.Minute, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resourceConfigScope.UpdateNextCheckTime(4 * time.Minute)).To(Succeed())
+			})
+
+			It("records when the next check is due", func() {
+				_, err := someResource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(someResource.NextCheckTime()).To(BeTemporally("~", someResource.LastCheckStartTime().Add(4*time.Minute), time.Second))
+			})
+
+			Context("when a check succeeds", func() {
+				BeforeEach(func() {
+					Expect(resourceConfigScope.SetCheckError(nil)).To(Succeed())
+				})
+
+				It("resets the failures and next check", func() {
+					_, err := someResource.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(someResource.CheckError()).To(BeNil())
+					Expect(someResource.CheckFailures()).To(BeZero())
+					Expect(someResource.NextCheckTime()).To(BeZero())
+				})
+			})
+
+			Context("when the failures are reset", func() {
+				BeforeEach(func() {
+					Expect(resourceConfigScope.ResetCheckFailures()).To(Succeed())
+				})
+
+				It("resets the failures and next check but keeps the error", func() {
+					Expect(resourceConfigScope.CheckFailures()).To(BeZero())
+
+					_, err := someResource.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(someResource.CheckError()).To(Equal(errors.New("oops again")))
+					Expect(someResource.CheckFailures()).To(BeZero())
+					Expect(someResource.NextCheckTime()).To(BeZero())
+				})
+			})
+		})
+
+		It("is loaded when finding the scope again", func() {
+			pipelineResourceTypes, err := defaultPipeline.ResourceTypes()
+			Expect(err).ToNot(HaveOccurred())
+
+			scope, err := someResource.SetResourceConfig(
+				logger,
+				someResource.Source(),
+				creds.NewVersionedResourceTypes(template.StaticVariables{}, pipelineResourceTypes.Deserialize()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scope.CheckFailures()).To(Equal(2))
+		})
+	})
+
 	Describe("AcquireResourceCheckingLock", func() {
 		var (
 			someResource        db.Resource
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"fmt"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc/db/lock"
 )
@@ -9,6 +12,15 @@ import (
 
 type ResourceFactory interface {
 	VisibleResources([]string) ([]Resource, error)
+
+	// CheckableCount returns the number of resources and resource types
+	// which are periodically checked, i.e. those in unpaused pipelines.
+	CheckableCount() (int, error)
+
+	// HeartbeatChecker records that the ATC with the given ID is periodically
+	// checking resources, and returns the number of ATCs which have done so
+	// within their TTL, including this one. The others are forgotten.
+	HeartbeatChecker(atcID string, ttl time.Duration) (int, error)
 }
 
 type resourceFactory struct {
@@ -55,3 +67,63 @@ func (r *resourceFactory) VisibleResources(teamNames []string) ([]Resource, erro
 
 	return resources, nil
 }
+
+func (r *resourceFactory) CheckableCount() (int, error) {
+	var count int
+	err := r.conn.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM resources r JOIN pipelines p ON p.id = r.pipeline_id WHERE r.active AND NOT p.paused) +
+			(SELECT COUNT(*) FROM resource_types rt JOIN pipelines p ON p.id = rt.pipeline_id WHERE rt.active AND NOT p.paused)
+	`).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *resourceFactory) HeartbeatChecker(atcID string, ttl time.Duration) (int, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("resource_checkers").
+		Where(sq.Expr("expires < now()")).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	expires := sq.Expr(fmt.Sprintf("now() + '%d second'::interval", int(ttl.Seconds())))
+
+	_, err = psql.Insert("resource_checkers").
+		Columns("atc_id", "expires").
+		Values(atcID, expires).
+		Suffix("ON CONFLICT (atc_id) DO UPDATE SET expires = EXCLUDED.expires").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = psql.Select("COUNT(*)").
+		From("resource_checkers").
+		RunWith(tx).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -52,4 +54,73 @@ var _ = Describe("Resource Factory", func() {
 			Expect(visibleResources[1].TeamName()).To(Equal("other-team"))
 		})
 	})
+
+	Describe("CheckableCount", func() {
+		It("counts the resources and resource types of unpaused pipelines", func() {
+			count, err := resourceFactory.CheckableCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		Context("when another pipeline is configured", func() {
+			var otherPipeline db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				otherPipeline, _, err = defaultTeam.SavePipeline("other-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "some-base-resource-type"},
+						{Name: "some-other-resource", Type: "some-base-resource-type"},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("includes its resources", func() {
+				count, err := resourceFactory.CheckableCount()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(4))
+			})
+
+			Context("when the pipeline is paused", func() {
+				BeforeEach(func() {
+					Expect(otherPipeline.Pause()).To(Succeed())
+				})
+
+				It("does not include its resources", func() {
+					count, err := resourceFactory.CheckableCount()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(count).To(Equal(2))
+				})
+			})
+		})
+	})
+	Describe("HeartbeatChecker", func() {
+		It("counts the ATCs which are checking", func() {
+			count, err := resourceFactory.HeartbeatChecker("some-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			count, err = resourceFactory.HeartbeatChecker("other-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+
+			count, err = resourceFactory.HeartbeatChecker("some-atc", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		Context("when an ATC's heartbeat has expired", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`INSERT INTO resource_checkers (atc_id, expires) VALUES ('stale-atc', now() - '1 minute'::interval)`)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("forgets it", func() {
+				count, err := resourceFactory.HeartbeatChecker("some-atc", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+	})
 })
@@ -3,12 +3,15 @@ package db_test
 import (
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -624,6 +627,119 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("CreateCheckBuild", func() {
+		var (
+			resource     db.Resource
+			checkBuild   db.Build
+			trackingLock lock.Lock
+		)
+
+		BeforeEach(func() {
+			var err error
+			resource, _, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			checkBuild, trackingLock, err = resource.CreateCheckBuild(logger)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_ = trackingLock.Release()
+		})
+
+		It("holds the build's tracking lock until it is released", func() {
+			_, acquired, err := checkBuild.AcquireTrackingLock(logger, time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			err = trackingLock.Release()
+			Expect(err).ToNot(HaveOccurred())
+
+			otherLock, acquired, err := checkBuild.AcquireTrackingLock(logger, time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			err = otherLock.Release()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates a started build for the resource", func() {
+			Expect(checkBuild.Name()).To(Equal("check"))
+			Expect(checkBuild.ResourceID()).To(Equal(resource.ID()))
+			Expect(checkBuild.ResourceName()).To(Equal("some-resource"))
+			Expect(checkBuild.PipelineID()).To(Equal(pipeline.ID()))
+			Expect(checkBuild.TeamID()).To(Equal(pipeline.TeamID()))
+			Expect(checkBuild.JobID()).To(BeZero())
+			Expect(checkBuild.Status()).To(Equal(db.BuildStatusStarted))
+		})
+
+		It("saves a start event", func() {
+			events, err := checkBuild.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusStarted,
+				Time:   checkBuild.StartTime().Unix(),
+			})))
+		})
+
+		It("is listed in the resource's check builds", func() {
+			builds, _, err := resource.CheckBuilds(db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(checkBuild.ID()))
+		})
+
+		It("is not listed in the pipeline's builds", func() {
+			builds, _, err := pipeline.Builds(db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		It("is not run by the engine", func() {
+			builds, err := buildFactory.GetAllStartedBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when the resource has more completed check builds than are retained", func() {
+			var oldestBuild db.Build
+
+			BeforeEach(func() {
+				for i := 0; i < 50; i++ {
+					build, buildLock, err := resource.CreateCheckBuild(logger)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = build.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = buildLock.Release()
+					Expect(err).ToNot(HaveOccurred())
+
+					if oldestBuild == nil {
+						oldestBuild = build
+					}
+				}
+			})
+
+			It("removes the oldest", func() {
+				found, err := oldestBuild.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				builds, _, err := resource.CheckBuilds(db.Page{Limit: 100})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(50))
+				Expect(builds[0].ID()).To(Equal(checkBuild.ID()))
+			})
+		})
+	})
+
 	Describe("ResourceConfigVersion", func() {
 		var (
 			resource                   db.Resource
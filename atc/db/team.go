@@ -11,6 +11,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/lib/pq"
@@ -66,6 +67,9 @@ type Team interface {
 	FindWorkerForVolume(handle string) (Worker, bool, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+
+	CredentialManager() *atc.TeamCredentialManager
+	UpdateCredentialManager(*atc.TeamCredentialManager) error
 }
 
 type team struct {
@@ -77,6 +81,8 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	credentialManager *atc.TeamCredentialManager
 }
 
 func (t *team) ID() int      { return t.id }
@@ -85,6 +91,8 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) CredentialManager() *atc.TeamCredentialManager { return t.credentialManager }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -743,17 +751,18 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 
 func (t *team) PrivateAndPublicBuilds(page Page) ([]Build, Pagination, error) {
 	newBuildsQuery := buildsQuery.
-		Where(sq.Or{sq.Eq{"p.public": true}, sq.Eq{"t.id": t.id}})
+		Where(sq.Or{sq.Eq{"p.public": true}, sq.Eq{"t.id": t.id}}).
+		Where(sq.Eq{"b.resource_id": nil})
 
 	return getBuildsWithPagination(newBuildsQuery, minMaxIdQuery, page, t.conn, t.lockFactory)
 }
 
 func (t *team) BuildsWithTime(page Page) ([]Build, Pagination, error) {
-	return getBuildsWithDates(buildsQuery.Where(sq.Eq{"t.id": t.id}), minMaxIdQuery, page, t.conn, t.lockFactory)
+	return getBuildsWithDates(buildsQuery.Where(sq.Eq{"t.id": t.id, "b.resource_id": nil}), minMaxIdQuery, page, t.conn, t.lockFactory)
 }
 
 func (t *team) Builds(page Page) ([]Build, Pagination, error) {
-	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id}), minMaxIdQuery, page, t.conn, t.lockFactory)
+	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id, "b.resource_id": nil}), minMaxIdQuery, page, t.conn, t.lockFactory)
 }
 
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
@@ -803,6 +812,30 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+// UpdateCredentialManager replaces the team's credential manager, which is
+// stored encrypted. A nil credential manager removes it, so that the team
+// uses the credential managers configured for the whole ATC.
+func (t *team) UpdateCredentialManager(credentialManager *atc.TeamCredentialManager) error {
+	encrypted, nonce, err := encryptCredentialManager(t.conn.EncryptionStrategy(), credentialManager)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("credential_manager", encrypted).
+		Set("credential_manager_nonce", nonce).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.credentialManager = credentialManager
+
+	return nil
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, resourceName string, variablesFactory creds.VariablesFactory) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineName)
 	if err != nil {
@@ -1172,3 +1205,45 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 
 	return nil
 }
+
+func encryptCredentialManager(es encryption.Strategy, credentialManager *atc.TeamCredentialManager) (*string, *string, error) {
+	if credentialManager == nil {
+		return nil, nil, nil
+	}
+
+	payload, err := json.Marshal(credentialManager)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encrypted, nonce, err := es.Encrypt(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &encrypted, nonce, nil
+}
+
+func decryptCredentialManager(es encryption.Strategy, encrypted sql.NullString, nonce sql.NullString) (*atc.TeamCredentialManager, error) {
+	if !encrypted.Valid {
+		return nil, nil
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := es.Decrypt(encrypted.String, noncense)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentialManager atc.TeamCredentialManager
+	err = json.Unmarshal(decrypted, &credentialManager)
+	if err != nil {
+		return nil, err
+	}
+
+	return &credentialManager, nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
+const teamColumns = "id, name, admin, auth, credential_manager, credential_manager_nonce"
+
 //go:generate counterfeiter . TeamFactory
 
 type TeamFactory interface {
@@ -50,10 +52,15 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	credentialManager, credentialManagerNonce, err := encryptCredentialManager(factory.conn.EncryptionStrategy(), t.CredentialManager)
+	if err != nil {
+		return nil, err
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, credential_manager, credential_manager_nonce").
+		Values(t.Name, auth, admin, credentialManager, credentialManagerNonce).
+		Suffix("RETURNING " + teamColumns).
 		RunWith(tx).
 		QueryRow()
 
@@ -89,7 +96,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select(teamColumns).
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -108,7 +115,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select(teamColumns).
 		From("teams").
 		OrderBy("id ASC").
 		RunWith(factory.conn).
@@ -166,14 +173,19 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, credentialManager, credentialManagerNonce sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&credentialManager,
+		&credentialManagerNonce,
 	)
+	if err != nil {
+		return err
+	}
 
 	if providerAuth.Valid {
 		err = json.Unmarshal([]byte(providerAuth.String), &t.auth)
@@ -182,5 +194,10 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
-	return err
+	t.credentialManager, err = decryptCredentialManager(factory.conn.EncryptionStrategy(), credentialManager, credentialManagerNonce)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
@@ -845,6 +845,45 @@ var _ = Describe("Team", func() {
 				})
 			})
 		})
+
+		Describe("UpdateCredentialManager", func() {
+			var credentialManager *atc.TeamCredentialManager
+
+			BeforeEach(func() {
+				credentialManager = &atc.TeamCredentialManager{
+					Type: "vault",
+					Config: map[string]interface{}{
+						"url":          "https://vault.example.com",
+						"client-token": "some-token",
+					},
+				}
+			})
+
+			It("saves the credential manager", func() {
+				err := team.UpdateCredentialManager(credentialManager)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.CredentialManager()).To(Equal(credentialManager))
+
+				foundTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundTeam.CredentialManager()).To(Equal(credentialManager))
+			})
+
+			It("removes the credential manager when given nil", func() {
+				err := team.UpdateCredentialManager(credentialManager)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.UpdateCredentialManager(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				foundTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundTeam.CredentialManager()).To(BeNil())
+			})
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -60,6 +60,9 @@ type Worker interface {
 	ExpiresAt() time.Time
 	Ephemeral() bool
 
+	ActiveTasks() (int, error)
+	DecreaseActiveTasks() error
+
 	Reload() (bool, error)
 
 	Land() error
@@ -69,6 +72,7 @@ type Worker interface {
 
 	FindContainerOnWorker(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
+	CreateTaskContainer(owner ContainerOwner, meta ContainerMetadata, maxActiveTasks int) (CreatingContainer, bool, error)
 }
 
 type worker struct {
@@ -242,6 +246,88 @@ func (worker *worker) Delete() error {
 	return err
 }
 
+func (worker *worker) ActiveTasks() (int, error) {
+	return worker.activeTasks(worker.conn)
+}
+
+func (worker *worker) activeTasks(runner sq.BaseRunner) (int, error) {
+	var activeTasks int
+	err := psql.Select("active_tasks").
+		From("workers").
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&activeTasks)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrWorkerNotPresent
+		}
+		return 0, err
+	}
+
+	return activeTasks, nil
+}
+
+// increaseActiveTasks counts another task as active on the worker, unless it
+// already has max active tasks, in which case false is returned. A max of 0
+// means no limit. The limit is checked by the same statement which increases
+// the count, so that concurrent tasks can't both take the last slot.
+func (worker *worker) increaseActiveTasks(tx Tx, max int) (bool, error) {
+	query := psql.Update("workers").
+		Set("active_tasks", sq.Expr("active_tasks + 1")).
+		Where(sq.Eq{"name": worker.name})
+
+	if max > 0 {
+		query = query.Where(sq.Lt{"active_tasks": max})
+	}
+
+	var activeTasks int
+	err := query.
+		Suffix("RETURNING active_tasks").
+		RunWith(tx).
+		QueryRow().
+		Scan(&activeTasks)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return false, err
+		}
+
+		// the worker is either at its limit or gone
+		_, err = worker.activeTasks(tx)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (worker *worker) DecreaseActiveTasks() error {
+	// never go below zero, e.g. if the worker re-registered while a task was
+	// running
+	return worker.updateActiveTasks("GREATEST(active_tasks - 1, 0)")
+}
+
+func (worker *worker) updateActiveTasks(expr string) error {
+	result, err := psql.Update("workers").
+		Set("active_tasks", sq.Expr(expr)).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
 func (worker *worker) ResourceCerts() (*UsedWorkerResourceCerts, bool, error) {
 	if worker.certsPath != nil {
 		wrc := &WorkerResourceCerts{
@@ -272,24 +358,73 @@ func (worker *worker) FindContainerOnWorker(owner ContainerOwner) (CreatingConta
 }
 
 func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
-	handle, err := uuid.NewV4()
+	tx, err := worker.conn.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	var containerID int
-	cols := []interface{}{&containerID}
+	defer Rollback(tx)
 
-	metadata := &ContainerMetadata{}
-	cols = append(cols, metadata.ScanTargets()...)
+	container, err := worker.createContainer(tx, owner, meta)
+	if err != nil {
+		return nil, err
+	}
 
-	tx, err := worker.conn.Begin()
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
+	return container, nil
+}
+
+// CreateTaskContainer creates a task's container and counts the task as active
+// on the worker in the same transaction, so that the active tasks never fall
+// behind the task containers which have been placed. If the worker already
+// has maxActiveTasks active tasks, no container is created and false is
+// returned. A maxActiveTasks of 0 means no limit.
+func (worker *worker) CreateTaskContainer(owner ContainerOwner, meta ContainerMetadata, maxActiveTasks int) (CreatingContainer, bool, error) {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
 	defer Rollback(tx)
 
+	increased, err := worker.increaseActiveTasks(tx, maxActiveTasks)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !increased {
+		return nil, false, nil
+	}
+
+	container, err := worker.createContainer(tx, owner, meta)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return container, true, nil
+}
+
+func (worker *worker) createContainer(tx Tx, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	var containerID int
+	cols := []interface{}{&containerID}
+
+	metadata := &ContainerMetadata{}
+	cols = append(cols, metadata.ScanTargets()...)
+
 	insMap := meta.SQLMap()
 	insMap["worker_name"] = worker.name
 	insMap["handle"] = handle.String()
@@ -317,11 +452,6 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 		return nil, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, err
-	}
-
 	return newCreatingContainer(
 		containerID,
 		handle.String(),
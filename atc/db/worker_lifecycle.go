@@ -14,6 +14,7 @@ type WorkerLifecycle interface {
 	LandFinishedLandingWorkers() ([]string, error)
 	DeleteFinishedRetiringWorkers() ([]string, error)
 	GetWorkerStateByName() (map[string]WorkerState, error)
+	ResetActiveTasks() ([]string, error)
 }
 
 type workerLifecycle struct {
@@ -181,6 +182,37 @@ func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error)
 	return workersAffected(rows)
 }
 
+// ResetActiveTasks corrects the active tasks of any worker which has more than
+// the task containers of builds which are still running, e.g. because an ATC
+// was restarted before a task finished and could no longer count it.
+//
+// A task is counted in the same transaction which creates its container, so
+// the containers (in any state) never fall behind the tasks counted. A
+// finished task's container is kept until its build completes, though, so
+// the count of containers is only used as an upper bound.
+func (lifecycle *workerLifecycle) ResetActiveTasks() ([]string, error) {
+	runningTasks := `(
+		SELECT COUNT(*)
+		FROM containers c
+		JOIN builds b ON b.id = c.build_id
+		WHERE c.worker_name = workers.name
+		AND c.meta_type = $1
+		AND b.status IN ($2, $3)
+	)`
+
+	rows, err := lifecycle.conn.Query(`
+		UPDATE workers
+		SET active_tasks = `+runningTasks+`
+		WHERE active_tasks > `+runningTasks+`
+		RETURNING name
+	`, string(ContainerTypeTask), string(BuildStatusPending), string(BuildStatusStarted))
+	if err != nil {
+		return nil, err
+	}
+
+	return workersAffected(rows)
+}
+
 func (lifecycle *workerLifecycle) GetWorkerStateByName() (map[string]WorkerState, error) {
 	rows, err := psql.Select(`
 		name,
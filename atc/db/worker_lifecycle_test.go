@@ -553,6 +553,71 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("ResetActiveTasks", func() {
+		var (
+			dbWorker db.Worker
+			dbBuild  db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			dbBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, created, err := dbWorker.CreateTaskContainer(db.NewBuildStepContainerOwner(dbBuild.ID(), atc.PlanID("some-plan"), defaultTeam.ID()), db.ContainerMetadata{Type: db.ContainerTypeTask}, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			// as if the ATC running two more tasks went away before they finished
+			_, err = dbConn.Exec("UPDATE workers SET active_tasks = active_tasks + 2 WHERE name = $1", dbWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker has more active tasks than running task containers", func() {
+			It("resets them to the running task containers, including those still being created", func() {
+				resetWorkers, err := workerLifecycle.ResetActiveTasks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resetWorkers).To(Equal([]string{atcWorker.Name}))
+
+				Expect(dbWorker.ActiveTasks()).To(Equal(1))
+			})
+		})
+
+		Context("when the build of the task container has finished", func() {
+			BeforeEach(func() {
+				err := dbBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("resets them to zero", func() {
+				_, err := workerLifecycle.ResetActiveTasks()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(dbWorker.ActiveTasks()).To(Equal(0))
+			})
+		})
+
+		Context("when the worker has no more active tasks than running task containers", func() {
+			BeforeEach(func() {
+				for i := 0; i < 3; i++ {
+					err := dbWorker.DecreaseActiveTasks()
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("leaves the worker alone", func() {
+				resetWorkers, err := workerLifecycle.ResetActiveTasks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resetWorkers).To(BeEmpty())
+
+				Expect(dbWorker.ActiveTasks()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("GetWorkersState", func() {
 
 		JustBeforeEach(func() {
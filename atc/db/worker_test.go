@@ -206,6 +206,97 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("Active tasks", func() {
+		var build Build
+
+		taskOwner := func(planID string) ContainerOwner {
+			return NewBuildStepContainerOwner(build.ID(), atc.PlanID(planID), defaultTeam.ID())
+		}
+
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts with no active tasks", func() {
+			Expect(worker.ActiveTasks()).To(Equal(0))
+		})
+
+		It("counts the task containers which are created and decreases the active tasks", func() {
+			container, created, err := worker.CreateTaskContainer(taskOwner("some-plan"), ContainerMetadata{Type: ContainerTypeTask}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(container.WorkerName()).To(Equal(atcWorker.Name))
+
+			_, created, err = worker.CreateTaskContainer(taskOwner("other-plan"), ContainerMetadata{Type: ContainerTypeTask}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(worker.ActiveTasks()).To(Equal(2))
+
+			Expect(worker.DecreaseActiveTasks()).To(Succeed())
+			Expect(worker.ActiveTasks()).To(Equal(1))
+		})
+
+		It("does not create task containers beyond the max active tasks", func() {
+			for _, planID := range []string{"plan-1", "plan-2"} {
+				_, created, err := worker.CreateTaskContainer(taskOwner(planID), ContainerMetadata{Type: ContainerTypeTask}, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+			}
+
+			container, created, err := worker.CreateTaskContainer(taskOwner("plan-3"), ContainerMetadata{Type: ContainerTypeTask}, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(container).To(BeNil())
+			Expect(worker.ActiveTasks()).To(Equal(2))
+
+			creatingContainer, createdContainer, err := worker.FindContainerOnWorker(taskOwner("plan-3"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(creatingContainer).To(BeNil())
+			Expect(createdContainer).To(BeNil())
+		})
+
+		It("does not count the task if its container can't be created", func() {
+			err := build.Finish(BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Delete()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, _, err = worker.CreateTaskContainer(taskOwner("some-plan"), ContainerMetadata{Type: ContainerTypeTask}, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(worker.ActiveTasks()).To(Equal(0))
+		})
+
+		It("does not decrease the active tasks below zero", func() {
+			Expect(worker.DecreaseActiveTasks()).To(Succeed())
+			Expect(worker.ActiveTasks()).To(Equal(0))
+		})
+
+		Context("when the worker is not present", func() {
+			BeforeEach(func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				_, _, err := worker.CreateTaskContainer(taskOwner("some-plan"), ContainerMetadata{Type: ContainerTypeTask}, 0)
+				Expect(err).To(Equal(ErrWorkerNotPresent))
+
+				_, _, err = worker.CreateTaskContainer(taskOwner("some-plan"), ContainerMetadata{Type: ContainerTypeTask}, 1)
+				Expect(err).To(Equal(ErrWorkerNotPresent))
+
+				_, err = worker.ActiveTasks()
+				Expect(err).To(Equal(ErrWorkerNotPresent))
+			})
+		})
+	})
+
 	Describe("FindContainerOnWorker/CreateContainer", func() {
 		var (
 			containerMetadata ContainerMetadata
@@ -203,6 +203,24 @@ var InputsConfigDecodeHook = func(
 	return data, nil
 }
 
+var InParallelConfigDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(InParallelConfig{}) {
+		return data, nil
+	}
+
+	if srcType.Kind() == reflect.Slice {
+		return map[string]interface{}{
+			"steps": data,
+		}, nil
+	}
+
+	return data, nil
+}
+
 func sanitize(root interface{}) (interface{}, error) {
 	switch rootVal := root.(type) {
 	case map[interface{}]interface{}:
@@ -1,8 +1,12 @@
 package engine
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 )
@@ -21,6 +25,84 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return agg
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("in-parallel")
+
+	steps := []exec.Step{}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		step := build.buildStep(logger, innerPlan)
+		steps = append(steps, step)
+	}
+
+	return exec.InParallel(steps, plan.InParallel.Limit, plan.InParallel.FailFast)
+}
+
+func (build *execBuild) buildAcrossStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("across")
+
+	return exec.Across(
+		plan.Across.Vars,
+		build.localVariables(),
+		func(index int, scope *creds.LocalVariables) (exec.Step, error) {
+			var innerPlan atc.Plan
+			if plan.Across.Step != nil {
+				var err error
+				innerPlan, err = deriveAcrossPlan(*plan.Across.Step, index)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				if index >= len(plan.Across.Steps) {
+					return nil, fmt.Errorf("across plan has no step for combination %d", index)
+				}
+
+				innerPlan = plan.Across.Steps[index].Step
+			}
+
+			innerPlan.Attempts = plan.Attempts
+
+			scoped := *build
+			scoped.localVars = scope
+
+			return scoped.buildStep(logger, innerPlan), nil
+		},
+		plan.Across.FailFast,
+	)
+}
+
+// deriveAcrossPlan copies a plan to be run for one combination of values
+// loaded at runtime, giving each nested plan an ID derived from the original
+// so that the event origins of each combination are distinct.
+func deriveAcrossPlan(plan atc.Plan, index int) (atc.Plan, error) {
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return atc.Plan{}, fmt.Errorf("failed to marshal across plan: %s", err)
+	}
+
+	var derived atc.Plan
+	err = json.Unmarshal(payload, &derived)
+	if err != nil {
+		return atc.Plan{}, fmt.Errorf("failed to unmarshal across plan: %s", err)
+	}
+
+	deriveID := func(id atc.PlanID) atc.PlanID {
+		return atc.PlanID(fmt.Sprintf("%s/%d", id, index))
+	}
+
+	derived.Each(func(p *atc.Plan) {
+		p.ID = deriveID(p.ID)
+
+		if p.Get != nil && p.Get.VersionFrom != nil {
+			versionFrom := deriveID(*p.Get.VersionFrom)
+			p.Get.VersionFrom = &versionFrom
+		}
+	})
+
+	return derived, nil
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("do")
 
@@ -103,6 +185,8 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		logger,
 		plan,
 		build.dbBuild,
+		build.localVariables(),
+		build.runState().Credentials(),
 		containerMetadata,
 		build.delegate.TaskDelegate(plan.ID),
 	)
@@ -123,6 +207,8 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		logger,
 		plan,
 		build.dbBuild,
+		build.localVariables(),
+		build.runState().Credentials(),
 		build.stepMetadata,
 		containerMetadata,
 		build.delegate.GetDelegate(plan.ID),
@@ -144,12 +230,41 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		logger,
 		plan,
 		build.dbBuild,
+		build.localVariables(),
+		build.runState().Credentials(),
 		build.stepMetadata,
 		containerMetadata,
 		build.delegate.PutDelegate(plan.ID),
 	)
 }
 
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.SetPipelineStepDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildLoadVarStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("load-var", lager.Data{
+		"name": plan.LoadVar.Name,
+	})
+
+	return build.factory.LoadVar(
+		logger,
+		plan,
+		build.dbBuild,
+		build.localVariables(),
+		build.delegate.LoadVarStepDelegate(plan.ID),
+	)
+}
+
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("retry")
 
@@ -56,6 +56,52 @@ func (delegate *BuildStepDelegate) Stderr() io.Writer {
 	)
 }
 
+func (delegate *BuildStepDelegate) Initializing(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.Initialize{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-event", err)
+		return
+	}
+
+	logger.Debug("initializing")
+}
+
+func (delegate *BuildStepDelegate) Starting(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.Start{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-event", err)
+		return
+	}
+
+	logger.Debug("starting")
+}
+
+func (delegate *BuildStepDelegate) Finished(logger lager.Logger, succeeded bool) {
+	err := delegate.build.SaveEvent(event.Finish{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:      delegate.clock.Now().Unix(),
+		Succeeded: succeeded,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{"succeeded": succeeded})
+}
+
 func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string) {
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
@@ -40,6 +40,17 @@ type FakeBuildDelegate struct {
 	getDelegateReturnsOnCall map[int]struct {
 		result1 exec.GetDelegate
 	}
+	LoadVarStepDelegateStub        func(atc.PlanID) exec.LoadVarStepDelegate
+	loadVarStepDelegateMutex       sync.RWMutex
+	loadVarStepDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	loadVarStepDelegateReturns struct {
+		result1 exec.LoadVarStepDelegate
+	}
+	loadVarStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.LoadVarStepDelegate
+	}
 	PutDelegateStub        func(atc.PlanID) exec.PutDelegate
 	putDelegateMutex       sync.RWMutex
 	putDelegateArgsForCall []struct {
@@ -51,6 +62,17 @@ type FakeBuildDelegate struct {
 	putDelegateReturnsOnCall map[int]struct {
 		result1 exec.PutDelegate
 	}
+	SetPipelineStepDelegateStub        func(atc.PlanID) exec.SetPipelineStepDelegate
+	setPipelineStepDelegateMutex       sync.RWMutex
+	setPipelineStepDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	setPipelineStepDelegateReturns struct {
+		result1 exec.SetPipelineStepDelegate
+	}
+	setPipelineStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetPipelineStepDelegate
+	}
 	TaskDelegateStub        func(atc.PlanID) exec.TaskDelegate
 	taskDelegateMutex       sync.RWMutex
 	taskDelegateArgsForCall []struct {
@@ -219,6 +241,66 @@ func (fake *FakeBuildDelegate) GetDelegateReturnsOnCall(i int, result1 exec.GetD
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) LoadVarStepDelegate(arg1 atc.PlanID) exec.LoadVarStepDelegate {
+	fake.loadVarStepDelegateMutex.Lock()
+	ret, specificReturn := fake.loadVarStepDelegateReturnsOnCall[len(fake.loadVarStepDelegateArgsForCall)]
+	fake.loadVarStepDelegateArgsForCall = append(fake.loadVarStepDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("LoadVarStepDelegate", []interface{}{arg1})
+	fake.loadVarStepDelegateMutex.Unlock()
+	if fake.LoadVarStepDelegateStub != nil {
+		return fake.LoadVarStepDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.loadVarStepDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) LoadVarStepDelegateCallCount() int {
+	fake.loadVarStepDelegateMutex.RLock()
+	defer fake.loadVarStepDelegateMutex.RUnlock()
+	return len(fake.loadVarStepDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) LoadVarStepDelegateCalls(stub func(atc.PlanID) exec.LoadVarStepDelegate) {
+	fake.loadVarStepDelegateMutex.Lock()
+	defer fake.loadVarStepDelegateMutex.Unlock()
+	fake.LoadVarStepDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) LoadVarStepDelegateArgsForCall(i int) atc.PlanID {
+	fake.loadVarStepDelegateMutex.RLock()
+	defer fake.loadVarStepDelegateMutex.RUnlock()
+	argsForCall := fake.loadVarStepDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) LoadVarStepDelegateReturns(result1 exec.LoadVarStepDelegate) {
+	fake.loadVarStepDelegateMutex.Lock()
+	defer fake.loadVarStepDelegateMutex.Unlock()
+	fake.LoadVarStepDelegateStub = nil
+	fake.loadVarStepDelegateReturns = struct {
+		result1 exec.LoadVarStepDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) LoadVarStepDelegateReturnsOnCall(i int, result1 exec.LoadVarStepDelegate) {
+	fake.loadVarStepDelegateMutex.Lock()
+	defer fake.loadVarStepDelegateMutex.Unlock()
+	fake.LoadVarStepDelegateStub = nil
+	if fake.loadVarStepDelegateReturnsOnCall == nil {
+		fake.loadVarStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.LoadVarStepDelegate
+		})
+	}
+	fake.loadVarStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.LoadVarStepDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) PutDelegate(arg1 atc.PlanID) exec.PutDelegate {
 	fake.putDelegateMutex.Lock()
 	ret, specificReturn := fake.putDelegateReturnsOnCall[len(fake.putDelegateArgsForCall)]
@@ -279,6 +361,66 @@ func (fake *FakeBuildDelegate) PutDelegateReturnsOnCall(i int, result1 exec.PutD
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) SetPipelineStepDelegate(arg1 atc.PlanID) exec.SetPipelineStepDelegate {
+	fake.setPipelineStepDelegateMutex.Lock()
+	ret, specificReturn := fake.setPipelineStepDelegateReturnsOnCall[len(fake.setPipelineStepDelegateArgsForCall)]
+	fake.setPipelineStepDelegateArgsForCall = append(fake.setPipelineStepDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("SetPipelineStepDelegate", []interface{}{arg1})
+	fake.setPipelineStepDelegateMutex.Unlock()
+	if fake.SetPipelineStepDelegateStub != nil {
+		return fake.SetPipelineStepDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPipelineStepDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineStepDelegateCallCount() int {
+	fake.setPipelineStepDelegateMutex.RLock()
+	defer fake.setPipelineStepDelegateMutex.RUnlock()
+	return len(fake.setPipelineStepDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineStepDelegateCalls(stub func(atc.PlanID) exec.SetPipelineStepDelegate) {
+	fake.setPipelineStepDelegateMutex.Lock()
+	defer fake.setPipelineStepDelegateMutex.Unlock()
+	fake.SetPipelineStepDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) SetPipelineStepDelegateArgsForCall(i int) atc.PlanID {
+	fake.setPipelineStepDelegateMutex.RLock()
+	defer fake.setPipelineStepDelegateMutex.RUnlock()
+	argsForCall := fake.setPipelineStepDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineStepDelegateReturns(result1 exec.SetPipelineStepDelegate) {
+	fake.setPipelineStepDelegateMutex.Lock()
+	defer fake.setPipelineStepDelegateMutex.Unlock()
+	fake.SetPipelineStepDelegateStub = nil
+	fake.setPipelineStepDelegateReturns = struct {
+		result1 exec.SetPipelineStepDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineStepDelegateReturnsOnCall(i int, result1 exec.SetPipelineStepDelegate) {
+	fake.setPipelineStepDelegateMutex.Lock()
+	defer fake.setPipelineStepDelegateMutex.Unlock()
+	fake.SetPipelineStepDelegateStub = nil
+	if fake.setPipelineStepDelegateReturnsOnCall == nil {
+		fake.setPipelineStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetPipelineStepDelegate
+		})
+	}
+	fake.setPipelineStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetPipelineStepDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) TaskDelegate(arg1 atc.PlanID) exec.TaskDelegate {
 	fake.taskDelegateMutex.Lock()
 	ret, specificReturn := fake.taskDelegateReturnsOnCall[len(fake.taskDelegateArgsForCall)]
@@ -348,8 +490,12 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishMutex.RUnlock()
 	fake.getDelegateMutex.RLock()
 	defer fake.getDelegateMutex.RUnlock()
+	fake.loadVarStepDelegateMutex.RLock()
+	defer fake.loadVarStepDelegateMutex.RUnlock()
 	fake.putDelegateMutex.RLock()
 	defer fake.putDelegateMutex.RUnlock()
+	fake.setPipelineStepDelegateMutex.RLock()
+	defer fake.setPipelineStepDelegateMutex.RUnlock()
 	fake.taskDelegateMutex.RLock()
 	defer fake.taskDelegateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -10,8 +10,11 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type execMetadata atc.Plan
@@ -54,9 +57,9 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(build),
-		metadata: execMetadata(plan),
+		factory:         engine.factory,
+		delegateFactory: engine.delegateFactory,
+		metadata:        execMetadata(plan),
 
 		ctx:    ctx,
 		cancel: cancel,
@@ -82,9 +85,9 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(build),
-		metadata: metadata,
+		factory:         engine.factory,
+		delegateFactory: engine.delegateFactory,
+		metadata:        metadata,
 
 		ctx:    ctx,
 		cancel: cancel,
@@ -114,7 +117,10 @@ type execBuild struct {
 	dbBuild      db.Build
 	stepMetadata StepMetadata
 
-	factory  exec.Factory
+	factory         exec.Factory
+	delegateFactory BuildDelegateFactory
+
+	// constructed as the build resumes, once its run state is known
 	delegate BuildDelegate
 
 	ctx    context.Context
@@ -124,6 +130,10 @@ type execBuild struct {
 	trackedStates *sync.Map
 
 	metadata execMetadata
+
+	// the scope of local vars for steps being built, e.g. within an across
+	// step; the build's run state is used if not set
+	localVars *creds.LocalVariables
 }
 
 func (build *execBuild) Metadata() string {
@@ -141,12 +151,23 @@ func (build *execBuild) Abort(lager.Logger) error {
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
+	state := build.runState()
+	defer build.clearRunState()
+
+	build.delegate = build.delegateFactory.Delegate(build.dbBuild, state.Credentials())
+
 	step := build.buildStep(logger, atc.Plan(build.metadata))
 
 	runCtx := lagerctx.NewContext(build.ctx, logger)
 
-	state := build.runState()
-	defer build.clearRunState()
+	runCtx, span := tracing.StartSpan(runCtx, "build", tracing.Attrs{
+		"team":     build.stepMetadata.TeamName,
+		"pipeline": build.stepMetadata.PipelineName,
+		"job":      build.stepMetadata.JobName,
+		"build":    build.stepMetadata.BuildName,
+		"build-id": strconv.Itoa(build.stepMetadata.BuildID),
+	})
+	defer span.End()
 
 	done := make(chan error, 1)
 	go func() {
@@ -157,9 +178,19 @@ func (build *execBuild) Resume(logger lager.Logger) {
 		select {
 		case <-build.releaseCh:
 			logger.Info("releasing")
+			span.SetAttributes(attribute.Bool("released", true))
+
+			// the run state is discarded once the build is released, so the
+			// ATC which resumes it resolves its credentials again and could
+			// never revoke these leases
+			state.Credentials().Leases().Revoke(logger.Session("revoke-leases"))
 			return
 		case err := <-done:
+			span.RecordError(err)
+			span.SetAttributes(attribute.Bool("succeeded", step.Succeeded()))
 			build.delegate.Finish(logger.Session("finish"), err, step.Succeeded())
+
+			state.Credentials().Leases().Revoke(logger.Session("revoke-leases"))
 			return
 		}
 	}
@@ -174,11 +205,23 @@ func (build *execBuild) clearRunState() {
 	build.trackedStates.Delete(build.dbBuild.ID())
 }
 
+func (build *execBuild) localVariables() *creds.LocalVariables {
+	if build.localVars != nil {
+		return build.localVars
+	}
+
+	return build.runState().LocalVariables()
+}
+
 func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	if plan.Aggregate != nil {
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -227,6 +270,18 @@ func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.Across != nil {
+		return build.buildAcrossStep(logger, plan)
+	}
+
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
+	if plan.LoadVar != nil {
+		return build.buildLoadVarStep(logger, plan)
+	}
+
 	if plan.ArtifactInput != nil {
 		return build.buildArtifactInputStep(logger, plan)
 	}
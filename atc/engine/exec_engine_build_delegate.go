@@ -2,11 +2,15 @@ package engine
 
 import (
 	"context"
+	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 )
 
@@ -16,6 +20,8 @@ type BuildDelegate interface {
 	GetDelegate(atc.PlanID) exec.GetDelegate
 	PutDelegate(atc.PlanID) exec.PutDelegate
 	TaskDelegate(atc.PlanID) exec.TaskDelegate
+	SetPipelineStepDelegate(atc.PlanID) exec.SetPipelineStepDelegate
+	LoadVarStepDelegate(atc.PlanID) exec.LoadVarStepDelegate
 
 	BuildStepDelegate(atc.PlanID) exec.BuildStepDelegate
 
@@ -25,16 +31,31 @@ type BuildDelegate interface {
 //go:generate counterfeiter . BuildDelegateFactory
 
 type BuildDelegateFactory interface {
-	Delegate(db.Build) BuildDelegate
+	Delegate(db.Build, *creds.BuildCredentials) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	redactSecrets bool
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+// NewBuildDelegateFactory constructs a BuildDelegateFactory. If redactSecrets
+// is true, the credentials resolved for each build are scrubbed from the
+// build's logs.
+func NewBuildDelegateFactory(redactSecrets bool) BuildDelegateFactory {
+	return buildDelegateFactory{
+		redactSecrets: redactSecrets,
+	}
 }
 
-func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
+func (factory buildDelegateFactory) Delegate(build db.Build, credentials *creds.BuildCredentials) BuildDelegate {
+	if factory.redactSecrets {
+		build = &redactingBuild{
+			Build:        build,
+			redactor:     credentials.Redactor(),
+			partialLines: map[event.Origin]event.Log{},
+		}
+	}
+
 	return newBuildDelegate(build)
 }
 
@@ -60,6 +81,14 @@ func (delegate *delegate) TaskDelegate(planID atc.PlanID) exec.TaskDelegate {
 	return NewTaskDelegate(delegate.build, planID, clock.NewClock())
 }
 
+func (delegate *delegate) SetPipelineStepDelegate(planID atc.PlanID) exec.SetPipelineStepDelegate {
+	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) LoadVarStepDelegate(planID atc.PlanID) exec.LoadVarStepDelegate {
+	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
+}
+
 func (delegate *delegate) BuildStepDelegate(planID atc.PlanID) exec.BuildStepDelegate {
 	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
 }
@@ -86,3 +115,82 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 		logger.Error("failed-to-finish-build", err)
 	}
 }
+
+// redactingBuild scrubs credentials from the build's logs before they are
+// saved. Each origin's output is held back until the end of a line, so that
+// credentials split across writes are still scrubbed. Partial lines are saved
+// before any other event, so that they stay in order, and once the build
+// finishes.
+type redactingBuild struct {
+	db.Build
+
+	redactor *creds.Redactor
+
+	partialLinesLock sync.Mutex
+	partialLines     map[event.Origin]event.Log
+}
+
+func (build *redactingBuild) SaveEvent(ev atc.Event) error {
+	build.partialLinesLock.Lock()
+	defer build.partialLinesLock.Unlock()
+
+	log, ok := ev.(event.Log)
+	if !ok {
+		err := build.savePartialLines()
+		if err != nil {
+			return err
+		}
+
+		return build.Build.SaveEvent(ev)
+	}
+
+	if partial, found := build.partialLines[log.Origin]; found {
+		log.Payload = partial.Payload + log.Payload
+		delete(build.partialLines, log.Origin)
+	}
+
+	end := strings.LastIndexAny(log.Payload, "\r\n") + 1
+	if end < len(log.Payload) {
+		partial := log
+		partial.Payload = log.Payload[end:]
+		build.partialLines[log.Origin] = partial
+
+		log.Payload = log.Payload[:end]
+	}
+
+	if log.Payload == "" {
+		return nil
+	}
+
+	return build.saveLog(log)
+}
+
+func (build *redactingBuild) Finish(status db.BuildStatus) error {
+	build.partialLinesLock.Lock()
+	err := build.savePartialLines()
+	build.partialLinesLock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return build.Build.Finish(status)
+}
+
+func (build *redactingBuild) savePartialLines() error {
+	for origin, log := range build.partialLines {
+		delete(build.partialLines, origin)
+
+		err := build.saveLog(log)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (build *redactingBuild) saveLog(log event.Log) error {
+	log.Payload = build.redactor.Redact(log.Payload)
+	return build.Build.SaveEvent(log)
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,23 +22,98 @@ var _ = Describe("BuildDelegate", func() {
 
 		fakeBuild *dbfakes.FakeBuild
 
-		delegate BuildDelegate
+		credentials *creds.BuildCredentials
+		delegate    BuildDelegate
 
 		logger *lagertest.TestLogger
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		factory = NewBuildDelegateFactory(false)
 
 		fakeBuild = new(dbfakes.FakeBuild)
-		delegate = factory.Delegate(fakeBuild)
+		credentials = creds.NewBuildCredentials()
+		credentials.Redactor().Track("some-secret")
 
 		logger = lagertest.NewTestLogger("test")
 	})
 
+	JustBeforeEach(func() {
+		delegate = factory.Delegate(fakeBuild, credentials)
+	})
+
+	Describe("step output", func() {
+		JustBeforeEach(func() {
+			_, err := delegate.TaskDelegate("some-plan-id").Stdout().Write([]byte("password: some-secret\n"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the output as-is", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(HaveLogPayload("password: some-secret\n"))
+		})
+
+		Context("when secrets are redacted", func() {
+			BeforeEach(func() {
+				factory = NewBuildDelegateFactory(true)
+			})
+
+			It("scrubs the build's credentials from the output", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(HaveLogPayload("password: ((redacted))\n"))
+			})
+
+			It("saves other events as-is", func() {
+				delegate.TaskDelegate("some-plan-id").Errored(logger, "some-secret")
+
+				Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Error{
+					Message: "some-secret",
+					Origin:  event.Origin{ID: "some-plan-id"},
+				}))
+			})
+
+			It("scrubs credentials which are split across writes", func() {
+				stdout := delegate.TaskDelegate("some-plan-id").Stdout()
+
+				_, err := stdout.Write([]byte("password: some-"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				_, err = stdout.Write([]byte("secret\n"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+				Expect(fakeBuild.SaveEventArgsForCall(1)).To(HaveLogPayload("password: ((redacted))\n"))
+			})
+
+			Context("when a line is left unfinished", func() {
+				JustBeforeEach(func() {
+					_, err := delegate.TaskDelegate("some-plan-id").Stdout().Write([]byte("some-secret, and then"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				})
+
+				It("saves it before any other event", func() {
+					delegate.TaskDelegate("some-plan-id").Errored(logger, "oh no")
+
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+					Expect(fakeBuild.SaveEventArgsForCall(1)).To(HaveLogPayload("((redacted)), and then"))
+					Expect(fakeBuild.SaveEventArgsForCall(2)).To(BeAssignableToTypeOf(event.Error{}))
+				})
+
+				It("saves it before the build finishes", func() {
+					delegate.Finish(logger, nil, true)
+
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+					Expect(fakeBuild.SaveEventArgsForCall(1)).To(HaveLogPayload("((redacted)), and then"))
+					Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+				})
+			})
+		})
+	})
+
 	Describe("Finish", func() {
 		Context("when build was aborted", func() {
-			BeforeEach(func() {
+			JustBeforeEach(func() {
 				delegate.Finish(logger, context.Canceled, false)
 			})
 
@@ -46,7 +124,7 @@ var _ = Describe("BuildDelegate", func() {
 		})
 
 		Context("when build had error", func() {
-			BeforeEach(func() {
+			JustBeforeEach(func() {
 				delegate.Finish(logger, errors.New("disaster"), false)
 			})
 
@@ -57,3 +135,9 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 })
+
+func HaveLogPayload(payload string) OmegaMatcher {
+	return WithTransform(func(ev atc.Event) string {
+		return ev.(event.Log).Payload
+	}, Equal(payload))
+}
@@ -1,12 +1,19 @@
 package engine_test
 
 import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
+	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -140,7 +147,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
+					logger, plan, build, _, _, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(putPlan))
@@ -156,7 +163,7 @@ var _ = Describe("ExecEngine", func() {
 						BuildName:    "42",
 					}))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
+					logger, plan, build, _, _, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(otherPutPlan))
@@ -234,7 +241,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+				logger, plan, build, _, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := getPlan
@@ -255,7 +262,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(1)
+				logger, plan, build, _, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := getPlan
@@ -280,7 +287,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				logger, plan, build, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := taskPlan
@@ -298,7 +305,7 @@ var _ = Describe("ExecEngine", func() {
 					Attempt:      "2.1",
 				}))
 
-				logger, plan, build, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				logger, plan, build, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan = taskPlan
@@ -318,6 +325,149 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 
+		Context("with an across plan", func() {
+			var (
+				taskPlan   atc.TaskPlan
+				acrossPlan atc.Plan
+			)
+
+			BeforeEach(func() {
+				taskPlan = atc.TaskPlan{
+					Name:       "some-task",
+					ConfigPath: "some-input/build.yml",
+				}
+			})
+
+			Context("when the values are known up front", func() {
+				BeforeEach(func() {
+					acrossPlan = planFactory.NewPlan(atc.AcrossPlan{
+						Vars: []atc.AcrossVar{
+							{Var: "v1", Values: []interface{}{"a", "b"}},
+							{Var: "v2", Values: []interface{}{"x"}},
+						},
+						Steps: []atc.VarScopedPlan{
+							{
+								Step:   planFactory.NewPlan(taskPlan),
+								Values: []interface{}{"a", "x"},
+							},
+							{
+								Step:   planFactory.NewPlan(taskPlan),
+								Values: []interface{}{"b", "x"},
+							},
+						},
+					})
+				})
+
+				It("constructs each planned step with the values in scope", func() {
+					build, err := execEngine.CreateBuild(logger, dbBuild, acrossPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+
+					for i, values := range [][]interface{}{{"a", "x"}, {"b", "x"}} {
+						_, plan, _, localVars, _, _, _ := fakeFactory.TaskArgsForCall(i)
+						Expect(plan).To(Equal(acrossPlan.Across.Steps[i].Step))
+
+						v1, found := localVars.Get("v1")
+						Expect(found).To(BeTrue())
+						Expect(v1).To(Equal(values[0]))
+
+						v2, found := localVars.Get("v2")
+						Expect(found).To(BeTrue())
+						Expect(v2).To(Equal(values[1]))
+					}
+				})
+
+				It("tracks the credentials for every combination with the build's delegate", func() {
+					build, err := execEngine.CreateBuild(logger, dbBuild, acrossPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeDelegateFactory.DelegateCallCount()).To(Equal(1))
+					_, credentials := fakeDelegateFactory.DelegateArgsForCall(0)
+					Expect(credentials).NotTo(BeNil())
+
+					for i := 0; i < fakeFactory.TaskCallCount(); i++ {
+						_, _, _, _, stepCredentials, _, _ := fakeFactory.TaskArgsForCall(i)
+						Expect(stepCredentials).To(BeIdenticalTo(credentials))
+					}
+				})
+
+				Context("when there are more combinations than planned steps", func() {
+					BeforeEach(func() {
+						acrossPlan.Across.Vars[1].Values = []interface{}{"x", "y"}
+					})
+
+					It("finishes the build with an error", func() {
+						build, err := execEngine.CreateBuild(logger, dbBuild, acrossPlan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+						_, finishErr, succeeded := fakeDelegate.FinishArgsForCall(0)
+						Expect(finishErr).To(MatchError("across plan has no step for combination 2"))
+						Expect(succeeded).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when the values are loaded from a local var", func() {
+				var (
+					loadVarPlan atc.Plan
+					stepPlan    atc.Plan
+				)
+
+				BeforeEach(func() {
+					loadVarPlan = planFactory.NewPlan(atc.LoadVarPlan{
+						Name: "versions",
+						File: "some-input/versions.json",
+					})
+
+					stepPlan = planFactory.NewPlan(taskPlan)
+
+					acrossPlan = planFactory.NewPlan(atc.DoPlan{
+						loadVarPlan,
+						planFactory.NewPlan(atc.AcrossPlan{
+							Vars: []atc.AcrossVar{
+								{Var: "version", ValuesFrom: "versions"},
+							},
+							Step: &stepPlan,
+						}),
+					})
+
+					fakeFactory.LoadVarStub = func(_ lager.Logger, _ atc.Plan, _ db.Build, localVars *creds.LocalVariables, _ exec.LoadVarStepDelegate) exec.Step {
+						loadVarStep := new(execfakes.FakeStep)
+						loadVarStep.SucceededReturns(true)
+						loadVarStep.RunStub = func(context.Context, exec.RunState) error {
+							localVars.Set("versions", []interface{}{"1.0", "2.0"})
+							return nil
+						}
+
+						return loadVarStep
+					}
+				})
+
+				It("constructs the step for each value with distinct plan IDs", func() {
+					build, err := execEngine.CreateBuild(logger, dbBuild, acrossPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+
+					for i, value := range []string{"1.0", "2.0"} {
+						_, plan, _, localVars, _, _, _ := fakeFactory.TaskArgsForCall(i)
+						Expect(plan.ID).To(Equal(atc.PlanID(fmt.Sprintf("%s/%d", stepPlan.ID, i))))
+						Expect(plan.Task).To(Equal(&taskPlan))
+
+						version, found := localVars.Get("version")
+						Expect(found).To(BeTrue())
+						Expect(version).To(Equal(value))
+					}
+				})
+			})
+		})
+
 		Context("with a plan where conditional steps are inside retries", func() {
 			var (
 				retryPlan     atc.Plan
@@ -373,19 +523,84 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
+				_, _, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
 			})
 		})
 
+		Context("when the build's steps lease credentials", func() {
+			var fakeLease *credsfakes.FakeLease
+
+			BeforeEach(func() {
+				fakeLease = new(credsfakes.FakeLease)
+
+				inputStep.RunStub = func(_ context.Context, state exec.RunState) error {
+					state.Credentials().Leases().Track(fakeLease)
+					return nil
+				}
+			})
+
+			It("revokes the leases once the build has finished", func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, planFactory.NewPlan(atc.GetPlan{
+					Name:     "some-input",
+					Resource: "some-input-resource",
+					Type:     "get",
+				}))
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+				Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+				Expect(fakeLease.RevokeCallCount()).To(Equal(1))
+			})
+
+			Context("when the build is released", func() {
+				var tracked chan struct{}
+
+				BeforeEach(func() {
+					tracked = make(chan struct{})
+
+					inputStep.RunStub = func(ctx context.Context, state exec.RunState) error {
+						state.Credentials().Leases().Track(fakeLease)
+						close(tracked)
+						<-ctx.Done()
+						return ctx.Err()
+					}
+				})
+
+				It("revokes the leases, as they would otherwise be lost", func() {
+					build, err := execEngine.CreateBuild(logger, dbBuild, planFactory.NewPlan(atc.GetPlan{
+						Name:     "some-input",
+						Resource: "some-input-resource",
+						Type:     "get",
+					}))
+					Expect(err).NotTo(HaveOccurred())
+
+					resumed := make(chan struct{})
+					go func() {
+						defer close(resumed)
+						build.Resume(logger)
+					}()
+
+					Eventually(tracked).Should(BeClosed())
+					execEngine.ReleaseAll(logger)
+					Eventually(resumed).Should(BeClosed())
+
+					Expect(fakeDelegate.FinishCallCount()).To(BeZero())
+					Expect(fakeLease.RevokeCallCount()).To(Equal(1))
+
+					build.Abort(logger)
+				})
+			})
+		})
+
 		Context("with a basic plan", func() {
 			var expectedPlan atc.Plan
 
@@ -410,7 +625,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, plan, dBuild, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+					logger, plan, dBuild, _, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(dBuild).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
@@ -459,7 +674,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-					logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+					logger, plan, build, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
@@ -516,7 +731,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
+					logger, plan, build, _, _, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(putPlan))
@@ -541,7 +756,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+					logger, plan, build, _, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(dependentGetPlan))
@@ -606,7 +821,7 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+				logger, plan, build, _, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				Expect(plan.ID).To(Equal(atc.PlanID("47")))
@@ -44,6 +44,19 @@ func (d *taskDelegate) Initializing(logger lager.Logger, taskConfig atc.TaskConf
 	logger.Debug("initializing")
 }
 
+func (d *taskDelegate) WaitingForWorker(logger lager.Logger) {
+	err := d.build.SaveEvent(event.WaitingForWorker{
+		Origin: d.eventOrigin,
+		Time:   time.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+		return
+	}
+
+	logger.Debug("waiting-for-worker")
+}
+
 func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig) {
 	err := d.build.SaveEvent(event.StartTask{
 		Origin:     d.eventOrigin,
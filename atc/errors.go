@@ -1,6 +1,9 @@
 package atc
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+)
 
 type MalformedConfigError struct {
 	UnmarshalError error
@@ -9,3 +12,18 @@ type MalformedConfigError struct {
 func (malformedConfigError MalformedConfigError) Error() string {
 	return fmt.Sprintf("malformed config: %s", malformedConfigError.UnmarshalError.Error())
 }
+
+type ExtraKeysError struct {
+	ExtraKeys []string
+}
+
+func (eke ExtraKeysError) Error() string {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintln(msg, "unknown/extra keys:")
+	for _, unusedKey := range eke.ExtraKeys {
+		fmt.Fprintf(msg, "  - %s\n", unusedKey)
+	}
+
+	return msg.String()
+}
@@ -35,6 +35,7 @@ func RegisterEvent(e atc.Event) {
 
 func init() {
 	RegisterEvent(InitializeTask{})
+	RegisterEvent(WaitingForWorker{})
 	RegisterEvent(StartTask{})
 	RegisterEvent(FinishTask{})
 	RegisterEvent(InitializeGet{})
@@ -43,6 +44,9 @@ func init() {
 	RegisterEvent(InitializePut{})
 	RegisterEvent(StartPut{})
 	RegisterEvent(FinishPut{})
+	RegisterEvent(Initialize{})
+	RegisterEvent(Start{})
+	RegisterEvent(Finish{})
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
@@ -15,6 +15,9 @@ const (
 	// task initializing (all inputs fetched; fetching image)
 	EventTypeInitializeTask atc.EventType = "initialize-task"
 
+	// task waiting for a worker with capacity to run it
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
 	// task execution finished
 	EventTypeFinishTask atc.EventType = "finish-task"
 
@@ -36,6 +39,15 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// initializing a step which does not run in a container
+	EventTypeInitialize atc.EventType = "initialize"
+
+	// started a step which does not run in a container
+	EventTypeStart atc.EventType = "start"
+
+	// finished a step which does not run in a container
+	EventTypeFinish atc.EventType = "finish"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
@@ -0,0 +1,181 @@
+package eventstore
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/lockrunner"
+)
+
+type archiver struct {
+	buildFactory      db.BuildFactory
+	store             Store
+	batchSize         int
+	gracePeriod       time.Duration
+	drainerConfigured bool
+}
+
+// NewArchiver constructs a task which offloads the events of completed builds
+// from the database to the store, and removes them from the store once the
+// builds' logs have been reaped or the builds have been deleted.
+//
+// Archived events are left in the database for gracePeriod, so that clients
+// which were already streaming them can finish.
+//
+// If a drainer is configured, builds are left in the database until they have
+// been drained.
+func NewArchiver(
+	buildFactory db.BuildFactory,
+	store Store,
+	batchSize int,
+	gracePeriod time.Duration,
+	drainerConfigured bool,
+) lockrunner.Task {
+	return &archiver{
+		buildFactory:      buildFactory,
+		store:             store,
+		batchSize:         batchSize,
+		gracePeriod:       gracePeriod,
+		drainerConfigured: drainerConfigured,
+	}
+}
+
+func (a *archiver) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-event-archiver")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	builds, err := a.buildFactory.GetArchivableBuilds(a.batchSize, a.drainerConfigured)
+	if err != nil {
+		logger.Error("failed-to-get-archivable-builds", err)
+		return err
+	}
+
+	for _, build := range builds {
+		err := a.archive(build)
+		if err != nil {
+			logger.Error("failed-to-archive-build-events", err, lager.Data{"build": build.ID()})
+			continue
+		}
+	}
+
+	archivedBuilds, err := a.buildFactory.GetArchivedBuildsWithEvents(a.gracePeriod, a.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-archived-builds", err)
+		return err
+	}
+
+	for _, build := range archivedBuilds {
+		err := build.DeleteArchivedEvents()
+		if err != nil {
+			logger.Error("failed-to-delete-archived-events-from-database", err, lager.Data{"build": build.ID()})
+			continue
+		}
+	}
+
+	reapedBuilds, err := a.buildFactory.GetReapedArchivedBuilds(a.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-reaped-builds", err)
+		return err
+	}
+
+	for _, build := range reapedBuilds {
+		err := a.store.Delete(build.ID())
+		if err != nil {
+			logger.Error("failed-to-delete-archived-build-events", err, lager.Data{"build": build.ID()})
+			continue
+		}
+
+		err = build.ClearEventsArchived()
+		if err != nil {
+			logger.Error("failed-to-clear-events-archived", err, lager.Data{"build": build.ID()})
+			continue
+		}
+	}
+
+	deletedBuildIDs, err := a.buildFactory.GetDeletedArchivedBuilds(a.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-deleted-builds", err)
+		return err
+	}
+
+	for _, buildID := range deletedBuildIDs {
+		err := a.store.Delete(buildID)
+		if err != nil {
+			logger.Error("failed-to-delete-archived-build-events", err, lager.Data{"build": buildID})
+			continue
+		}
+
+		err = a.buildFactory.ForgetDeletedArchivedBuild(buildID)
+		if err != nil {
+			logger.Error("failed-to-forget-deleted-build", err, lager.Data{"build": buildID})
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (a *archiver) archive(build db.Build) error {
+	events, err := build.Events(0)
+	if err != nil {
+		return err
+	}
+
+	defer events.Close()
+
+	reader, writer := io.Pipe()
+
+	written := make(chan error, 1)
+	go func() {
+		err := writeEvents(writer, events)
+		writer.CloseWithError(err)
+		written <- err
+	}()
+
+	err = a.store.Put(build.ID(), reader)
+
+	// unblock the writer if the store gave up early
+	reader.CloseWithError(io.ErrClosedPipe)
+
+	writeErr := <-written
+	if err != nil {
+		return err
+	}
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	return build.MarkEventsArchived()
+}
+
+func writeEvents(w io.Writer, events db.EventSource) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
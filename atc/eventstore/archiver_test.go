@@ -0,0 +1,216 @@
+package eventstore_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore"
+	"github.com/concourse/concourse/atc/eventstore/eventstorefakes"
+	"github.com/concourse/concourse/atc/lockrunner"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archiver", func() {
+	var (
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeStore        *eventstorefakes.FakeStore
+		fakeBuild        *dbfakes.FakeBuild
+
+		drainerConfigured bool
+		stored            *bytes.Buffer
+
+		archiver lockrunner.Task
+		runErr   error
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeStore = new(eventstorefakes.FakeStore)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.EventsReturns(eventSource(fakeEvent(`{"event":1}`), fakeEvent(`{"event":2}`)), nil)
+
+		fakeBuildFactory.GetArchivableBuildsReturns([]db.Build{fakeBuild}, nil)
+
+		stored = new(bytes.Buffer)
+		fakeStore.PutStub = func(buildID int, events io.Reader) error {
+			_, err := io.Copy(stored, events)
+			return err
+		}
+
+		drainerConfigured = false
+	})
+
+	JustBeforeEach(func() {
+		archiver = eventstore.NewArchiver(fakeBuildFactory, fakeStore, 10, time.Minute, drainerConfigured)
+
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = archiver.Run(ctx)
+	})
+
+	It("archives the events of completed builds", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		limit, drainedOnly := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+		Expect(limit).To(Equal(10))
+		Expect(drainedOnly).To(BeFalse())
+
+		Expect(fakeBuild.EventsArgsForCall(0)).To(BeZero())
+
+		Expect(fakeStore.PutCallCount()).To(Equal(1))
+		buildID, _ := fakeStore.PutArgsForCall(0)
+		Expect(buildID).To(Equal(42))
+
+		Expect(readEvents(ioutil.NopCloser(stored))).To(Equal([]event.Envelope{
+			fakeEvent(`{"event":1}`),
+			fakeEvent(`{"event":2}`),
+		}))
+
+		Expect(fakeBuild.MarkEventsArchivedCallCount()).To(Equal(1))
+	})
+
+	Context("when a drainer is configured", func() {
+		BeforeEach(func() {
+			drainerConfigured = true
+		})
+
+		It("only archives drained builds", func() {
+			_, drainedOnly := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+			Expect(drainedOnly).To(BeTrue())
+		})
+	})
+
+	Context("when the store fails", func() {
+		BeforeEach(func() {
+			fakeStore.PutReturns(errors.New("nope"))
+		})
+
+		It("keeps the events in the database", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeBuild.MarkEventsArchivedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the events fail to be read", func() {
+		BeforeEach(func() {
+			source := new(dbfakes.FakeEventSource)
+			source.NextReturns(event.Envelope{}, errors.New("nope"))
+			fakeBuild.EventsReturns(source, nil)
+		})
+
+		It("keeps the events in the database", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeBuild.MarkEventsArchivedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when builds were archived longer than the grace period ago", func() {
+		var archivedBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, nil)
+
+			archivedBuild = new(dbfakes.FakeBuild)
+			archivedBuild.IDReturns(8)
+			fakeBuildFactory.GetArchivedBuildsWithEventsReturns([]db.Build{archivedBuild}, nil)
+		})
+
+		It("deletes their events from the database", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			gracePeriod, limit := fakeBuildFactory.GetArchivedBuildsWithEventsArgsForCall(0)
+			Expect(gracePeriod).To(Equal(time.Minute))
+			Expect(limit).To(Equal(10))
+
+			Expect(archivedBuild.DeleteArchivedEventsCallCount()).To(Equal(1))
+		})
+
+		It("does not delete them from the store", func() {
+			Expect(fakeStore.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when archived builds have been deleted", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, nil)
+			fakeBuildFactory.GetDeletedArchivedBuildsReturns([]int{9}, nil)
+		})
+
+		It("deletes their events from the store", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(fakeBuildFactory.GetDeletedArchivedBuildsArgsForCall(0)).To(Equal(10))
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(9))
+
+			Expect(fakeBuildFactory.ForgetDeletedArchivedBuildCallCount()).To(Equal(1))
+			Expect(fakeBuildFactory.ForgetDeletedArchivedBuildArgsForCall(0)).To(Equal(9))
+		})
+
+		Context("when deleting fails", func() {
+			BeforeEach(func() {
+				fakeStore.DeleteReturns(errors.New("nope"))
+			})
+
+			It("remembers the build, to try again", func() {
+				Expect(fakeBuildFactory.ForgetDeletedArchivedBuildCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when archived builds have been reaped", func() {
+		var reapedBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, nil)
+
+			reapedBuild = new(dbfakes.FakeBuild)
+			reapedBuild.IDReturns(7)
+			fakeBuildFactory.GetReapedArchivedBuildsReturns([]db.Build{reapedBuild}, nil)
+		})
+
+		It("deletes their events from the store", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(fakeBuildFactory.GetReapedArchivedBuildsArgsForCall(0)).To(Equal(10))
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(7))
+
+			Expect(reapedBuild.ClearEventsArchivedCallCount()).To(Equal(1))
+		})
+
+		Context("when deleting fails", func() {
+			BeforeEach(func() {
+				fakeStore.DeleteReturns(errors.New("nope"))
+			})
+
+			It("leaves the build marked as archived", func() {
+				Expect(reapedBuild.ClearEventsArchivedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
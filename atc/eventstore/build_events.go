@@ -0,0 +1,126 @@
+package eventstore
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+var ErrNoStoreConfigured = errors.New("build events have been archived, but no build event store is configured")
+
+//go:generate counterfeiter . BuildEvents
+
+// BuildEvents reads a build's events from wherever they are kept: the
+// database, or the store once they have been archived.
+type BuildEvents interface {
+	Events(build db.Build, from uint) (db.EventSource, error)
+}
+
+type buildEvents struct {
+	store Store
+}
+
+// NewBuildEvents constructs a BuildEvents reading archived events from the
+// given store, which may be nil if none is configured.
+func NewBuildEvents(store Store) BuildEvents {
+	return &buildEvents{
+		store: store,
+	}
+}
+
+func (b *buildEvents) Events(build db.Build, from uint) (db.EventSource, error) {
+	if !build.EventsArchived() {
+		return build.Events(from)
+	}
+
+	if b.store == nil {
+		return nil, ErrNoStoreConfigured
+	}
+
+	body, err := b.store.Get(build.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := newArchivedEventSource(body)
+	if err != nil {
+		_ = body.Close()
+		return nil, err
+	}
+
+	for i := uint(0); i < from; i++ {
+		_, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			_ = source.Close()
+			return nil, err
+		}
+	}
+
+	return source, nil
+}
+
+type archivedEventSource struct {
+	body    io.ReadCloser
+	gz      *gzip.Reader
+	decoder *json.Decoder
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newArchivedEventSource(body io.ReadCloser) (*archivedEventSource, error) {
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &archivedEventSource{
+		body:    body,
+		gz:      gz,
+		decoder: json.NewDecoder(gz),
+
+		closed: make(chan struct{}),
+	}, nil
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	select {
+	case <-source.closed:
+		return event.Envelope{}, db.ErrBuildEventStreamClosed
+	default:
+	}
+
+	var ev event.Envelope
+	err := source.decoder.Decode(&ev)
+	if err != nil {
+		if err == io.EOF {
+			return event.Envelope{}, db.ErrEndOfBuildEventStream
+		}
+
+		return event.Envelope{}, err
+	}
+
+	return ev, nil
+}
+
+func (source *archivedEventSource) Close() error {
+	var err error
+
+	source.closeOnce.Do(func() {
+		close(source.closed)
+
+		_ = source.gz.Close()
+		err = source.body.Close()
+	})
+
+	return err
+}
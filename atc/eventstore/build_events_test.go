@@ -0,0 +1,169 @@
+package eventstore_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore"
+	"github.com/concourse/concourse/atc/eventstore/eventstorefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func fakeEvent(payload string) event.Envelope {
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   "fake",
+		Version: "42.0",
+	}
+}
+
+func eventSource(events ...event.Envelope) db.EventSource {
+	source := new(dbfakes.FakeEventSource)
+	for i, ev := range events {
+		source.NextReturnsOnCall(i, ev, nil)
+	}
+	source.NextReturnsOnCall(len(events), event.Envelope{}, db.ErrEndOfBuildEventStream)
+	return source
+}
+
+func archive(events ...event.Envelope) io.ReadCloser {
+	buf := new(bytes.Buffer)
+
+	gz := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(gz)
+	for _, ev := range events {
+		Expect(encoder.Encode(ev)).To(Succeed())
+	}
+	Expect(gz.Close()).To(Succeed())
+
+	return ioutil.NopCloser(buf)
+}
+
+func readEvents(archived io.ReadCloser) []event.Envelope {
+	fakeStore := new(eventstorefakes.FakeStore)
+	fakeStore.GetReturns(archived, nil)
+
+	build := new(dbfakes.FakeBuild)
+	build.EventsArchivedReturns(true)
+
+	source, err := eventstore.NewBuildEvents(fakeStore).Events(build, 0)
+	Expect(err).ToNot(HaveOccurred())
+
+	defer source.Close()
+
+	events := []event.Envelope{}
+	for {
+		ev, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			return events
+		}
+
+		Expect(err).ToNot(HaveOccurred())
+		events = append(events, ev)
+	}
+}
+
+var _ = Describe("BuildEvents", func() {
+	var (
+		fakeStore *eventstorefakes.FakeStore
+		fakeBuild *dbfakes.FakeBuild
+
+		buildEvents eventstore.BuildEvents
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(eventstorefakes.FakeStore)
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+
+		buildEvents = eventstore.NewBuildEvents(fakeStore)
+	})
+
+	Context("when the build's events have not been archived", func() {
+		var fakeSource *dbfakes.FakeEventSource
+
+		BeforeEach(func() {
+			fakeSource = new(dbfakes.FakeEventSource)
+			fakeBuild.EventsReturns(fakeSource, nil)
+		})
+
+		It("reads them from the database", func() {
+			source, err := buildEvents.Events(fakeBuild, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source).To(Equal(fakeSource))
+
+			Expect(fakeBuild.EventsArgsForCall(0)).To(Equal(uint(3)))
+			Expect(fakeStore.GetCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build's events have been archived", func() {
+		BeforeEach(func() {
+			fakeBuild.EventsArchivedReturns(true)
+			fakeStore.GetReturns(archive(
+				fakeEvent(`{"event":1}`),
+				fakeEvent(`{"event":2}`),
+				fakeEvent(`{"event":3}`),
+			), nil)
+		})
+
+		It("reads them from the store, starting from the given event", func() {
+			source, err := buildEvents.Events(fakeBuild, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeStore.GetArgsForCall(0)).To(Equal(42))
+			Expect(fakeBuild.EventsCallCount()).To(BeZero())
+
+			Expect(source.Next()).To(Equal(fakeEvent(`{"event":2}`)))
+			Expect(source.Next()).To(Equal(fakeEvent(`{"event":3}`)))
+
+			_, err = source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+
+			Expect(source.Close()).To(Succeed())
+
+			_, err = source.Next()
+			Expect(err).To(Equal(db.ErrBuildEventStreamClosed))
+		})
+
+		It("ends the stream when starting past the end", func() {
+			source, err := buildEvents.Events(fakeBuild, 10)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		Context("when the store fails", func() {
+			BeforeEach(func() {
+				fakeStore.GetReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				_, err := buildEvents.Events(fakeBuild, 0)
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+
+		Context("when no store is configured", func() {
+			BeforeEach(func() {
+				buildEvents = eventstore.NewBuildEvents(nil)
+			})
+
+			It("returns an error", func() {
+				_, err := buildEvents.Events(fakeBuild, 0)
+				Expect(err).To(Equal(eventstore.ErrNoStoreConfigured))
+			})
+		})
+	})
+})
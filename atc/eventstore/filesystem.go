@@ -0,0 +1,68 @@
+package eventstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type filesystemStore struct {
+	dir string
+}
+
+// NewFilesystemStore constructs a Store which keeps each build's events in a
+// file in the given directory.
+func NewFilesystemStore(dir string) Store {
+	return &filesystemStore{
+		dir: dir,
+	}
+}
+
+func (store *filesystemStore) Put(buildID int, events io.Reader) error {
+	tmp, err := ioutil.TempFile(store.dir, "tmp-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, events)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), store.path(buildID))
+}
+
+func (store *filesystemStore) Get(buildID int) (io.ReadCloser, error) {
+	file, err := os.Open(store.path(buildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrEventsNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (store *filesystemStore) Delete(buildID int) error {
+	err := os.Remove(store.path(buildID))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *filesystemStore) path(buildID int) string {
+	return filepath.Join(store.dir, objectName(buildID))
+}
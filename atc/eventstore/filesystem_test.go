@@ -0,0 +1,61 @@
+package eventstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/eventstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilesystemStore", func() {
+	var (
+		dir   string
+		store eventstore.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "build-events")
+		Expect(err).ToNot(HaveOccurred())
+
+		store = eventstore.NewFilesystemStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("stores, returns, and deletes a build's events", func() {
+		Expect(store.Put(42, strings.NewReader("some-events"))).To(Succeed())
+
+		files, err := ioutil.ReadDir(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].Name()).To(Equal("42.json.gz"))
+
+		reader, err := store.Get(42)
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reader.Close()).To(Succeed())
+		Expect(string(contents)).To(Equal("some-events"))
+
+		Expect(store.Delete(42)).To(Succeed())
+		Expect(filepath.Join(dir, "42.json.gz")).ToNot(BeAnExistingFile())
+	})
+
+	It("returns ErrEventsNotFound for unknown builds", func() {
+		_, err := store.Get(42)
+		Expect(err).To(Equal(eventstore.ErrEventsNotFound))
+	})
+
+	It("does not fail to delete unknown builds", func() {
+		Expect(store.Delete(42)).To(Succeed())
+	})
+})
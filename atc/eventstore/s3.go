@@ -0,0 +1,70 @@
+package eventstore
+
+import (
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+type s3Store struct {
+	client   s3iface.S3API
+	uploader *s3manager.Uploader
+
+	bucket string
+	prefix string
+}
+
+// NewS3Store constructs a Store which keeps each build's events in an object
+// in an S3 (or S3-compatible) bucket.
+func NewS3Store(client s3iface.S3API, bucket string, prefix string) Store {
+	return &s3Store{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+func (store *s3Store) Put(buildID int, events io.Reader) error {
+	_, err := store.uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(store.bucket),
+		Key:         aws.String(store.key(buildID)),
+		Body:        events,
+		ContentType: aws.String("application/gzip"),
+	})
+	return err
+}
+
+func (store *s3Store) Get(buildID int) (io.ReadCloser, error) {
+	output, err := store.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.key(buildID)),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrEventsNotFound
+		}
+
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+func (store *s3Store) Delete(buildID int) error {
+	_, err := store.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.key(buildID)),
+	})
+	return err
+}
+
+func (store *s3Store) key(buildID int) string {
+	return path.Join(store.prefix, objectName(buildID))
+}
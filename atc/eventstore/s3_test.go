@@ -0,0 +1,88 @@
+package eventstore_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/concourse/atc/eventstore"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3Store", func() {
+	var (
+		blobstore *ghttp.Server
+		store     eventstore.Store
+	)
+
+	BeforeEach(func() {
+		blobstore = ghttp.NewServer()
+
+		session, err := session.NewSession(&aws.Config{
+			Region:           aws.String("us-east-1"),
+			Endpoint:         aws.String(blobstore.URL()),
+			S3ForcePathStyle: aws.Bool(true),
+			Credentials:      credentials.NewStaticCredentials("some-key", "some-secret", ""),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		store = eventstore.NewS3Store(s3.New(session), "some-bucket", "some/prefix")
+	})
+
+	AfterEach(func() {
+		blobstore.Close()
+	})
+
+	It("uploads a build's events", func() {
+		blobstore.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", "/some-bucket/some/prefix/42.json.gz"),
+			func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(body)).To(Equal("some-events"))
+			},
+		))
+
+		Expect(store.Put(42, strings.NewReader("some-events"))).To(Succeed())
+		Expect(blobstore.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("downloads a build's events", func() {
+		blobstore.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/some-bucket/some/prefix/42.json.gz"),
+			ghttp.RespondWith(http.StatusOK, "some-events"),
+		))
+
+		reader, err := store.Get(42)
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reader.Close()).To(Succeed())
+		Expect(string(contents)).To(Equal("some-events"))
+	})
+
+	It("returns ErrEventsNotFound for unknown builds", func() {
+		blobstore.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+
+		_, err := store.Get(42)
+		Expect(err).To(Equal(eventstore.ErrEventsNotFound))
+	})
+
+	It("deletes a build's events", func() {
+		blobstore.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", "/some-bucket/some/prefix/42.json.gz"),
+			ghttp.RespondWith(http.StatusNoContent, nil),
+		))
+
+		Expect(store.Delete(42)).To(Succeed())
+	})
+})
@@ -0,0 +1,94 @@
+package eventstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/flag"
+)
+
+// ErrEventsNotFound is returned by a Store when it holds no events for the
+// build.
+var ErrEventsNotFound = errors.New("build events not found")
+
+//go:generate counterfeiter . Store
+
+// Store holds the events of completed builds once they have been offloaded
+// from the database. Events are stored as a gzipped stream of JSON-encoded
+// event envelopes.
+type Store interface {
+	Put(buildID int, events io.Reader) error
+	Get(buildID int) (io.ReadCloser, error)
+	Delete(buildID int) error
+}
+
+type Config struct {
+	Dir flag.Dir `long:"dir" description:"Local directory to which the events of completed builds are offloaded from the database."`
+
+	S3 S3Config
+
+	ArchiveInterval    time.Duration `long:"archive-interval"     default:"1m"  description:"Interval on which to offload the events of completed builds."`
+	ArchiveBatchSize   int           `long:"archive-batch-size"   default:"100" description:"Maximum number of builds whose events are offloaded on each interval."`
+	ArchiveGracePeriod time.Duration `long:"archive-grace-period" default:"5m"  description:"How long to keep offloaded events in the database, so that clients which were already streaming them can finish."`
+}
+
+type S3Config struct {
+	Bucket          string `long:"s3-bucket"            description:"S3 bucket to which the events of completed builds are offloaded from the database."`
+	Prefix          string `long:"s3-prefix"            description:"Prefix for the keys of the offloaded build events."`
+	Region          string `long:"s3-region"            default:"us-east-1" description:"AWS region of the S3 bucket."`
+	Endpoint        string `long:"s3-endpoint"          description:"URL of an S3-compatible blob store, e.g. MinIO, to use in place of AWS S3."`
+	ForcePathStyle  bool   `long:"s3-force-path-style"  description:"Use path-style S3 URLs, as required by most S3-compatible blob stores."`
+	AccessKeyID     string `long:"s3-access-key-id"     description:"Access key ID for the S3 bucket."`
+	SecretAccessKey string `long:"s3-secret-access-key" description:"Secret access key for the S3 bucket."`
+	SessionToken    string `long:"s3-session-token"     description:"Session token for the S3 bucket."`
+}
+
+// IsConfigured is true if a store has been configured.
+func (config Config) IsConfigured() bool {
+	return config.Dir != "" || config.S3.Bucket != ""
+}
+
+// NewStore constructs the configured Store. Only one may be configured.
+func (config Config) NewStore() (Store, error) {
+	if config.Dir != "" && config.S3.Bucket != "" {
+		return nil, errors.New("only one of --build-events-dir and --build-events-s3-bucket may be configured")
+	}
+
+	if config.Dir != "" {
+		return NewFilesystemStore(config.Dir.Path()), nil
+	}
+
+	if config.S3.Bucket != "" {
+		awsConfig := &aws.Config{
+			Region:           aws.String(config.S3.Region),
+			S3ForcePathStyle: aws.Bool(config.S3.ForcePathStyle),
+		}
+
+		if config.S3.Endpoint != "" {
+			awsConfig.Endpoint = aws.String(config.S3.Endpoint)
+		}
+
+		if config.S3.AccessKeyID != "" {
+			awsConfig.Credentials = credentials.NewStaticCredentials(config.S3.AccessKeyID, config.S3.SecretAccessKey, config.S3.SessionToken)
+		}
+
+		session, err := session.NewSession(awsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create aws session: %s", err)
+		}
+
+		return NewS3Store(s3.New(session), config.S3.Bucket, config.S3.Prefix), nil
+	}
+
+	return nil, errors.New("no build event store configured")
+}
+
+func objectName(buildID int) string {
+	return fmt.Sprintf("%d.json.gz", buildID)
+}
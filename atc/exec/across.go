@@ -0,0 +1,143 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	atctemplate "github.com/concourse/concourse/atc/template"
+)
+
+// AcrossStepBuilder builds the step to run for the combination of values at
+// the given index. The values are set as local vars in the given scope.
+type AcrossStepBuilder func(index int, scope *creds.LocalVariables) (Step, error)
+
+// AcrossStep runs a step for each combination of its vars' values, each
+// within its own scope of local vars.
+type AcrossStep struct {
+	vars      []atc.AcrossVar
+	localVars *creds.LocalVariables
+	buildStep AcrossStepBuilder
+	failFast  bool
+
+	step Step
+}
+
+// Across constructs an AcrossStep. Values which are loaded from a local var
+// are resolved from the given local vars when the step is run.
+func Across(
+	vars []atc.AcrossVar,
+	localVars *creds.LocalVariables,
+	buildStep AcrossStepBuilder,
+	failFast bool,
+) *AcrossStep {
+	return &AcrossStep{
+		vars:      vars,
+		localVars: localVars,
+		buildStep: buildStep,
+		failFast:  failFast,
+	}
+}
+
+// Run resolves the values for each var and runs the step for every
+// combination of them. Each var's values are iterated over in order, with the
+// first var being the outermost; at most MaxInFlight of a var's values are run
+// at once, defaulting to one at a time.
+//
+// If fail-fast is configured, the first combination to fail or error will
+// cause any running combinations to be canceled and any remaining ones to not
+// be run at all.
+func (step *AcrossStep) Run(ctx context.Context, state RunState) error {
+	values := make([][]interface{}, len(step.vars))
+
+	for i, v := range step.vars {
+		if v.ValuesFrom == "" {
+			values[i] = v.Values
+			continue
+		}
+
+		loaded, err := step.loadValues(v.ValuesFrom, state.Credentials())
+		if err != nil {
+			return err
+		}
+
+		values[i] = loaded
+	}
+
+	index := 0
+	combinations, err := step.buildCombinations(values, nil, &index)
+	if err != nil {
+		return err
+	}
+
+	step.step = combinations
+
+	return step.step.Run(ctx, state)
+}
+
+// Succeeded is true if the step succeeded for every combination of values.
+func (step *AcrossStep) Succeeded() bool {
+	return step.step != nil && step.step.Succeeded()
+}
+
+func (step *AcrossStep) buildCombinations(values [][]interface{}, combination []interface{}, index *int) (Step, error) {
+	depth := len(combination)
+
+	if depth == len(step.vars) {
+		scope := step.localVars.NewScope()
+		for i, v := range step.vars {
+			scope.Set(v.Var, combination[i])
+		}
+
+		leaf, err := step.buildStep(*index, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		*index++
+
+		return leaf, nil
+	}
+
+	steps := []Step{}
+	for _, value := range values[depth] {
+		next := make([]interface{}, depth, depth+1)
+		copy(next, combination)
+
+		combinations, err := step.buildCombinations(values, append(next, value), index)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, combinations)
+	}
+
+	maxInFlight := step.vars[depth].MaxInFlight
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	return InParallel(steps, maxInFlight, step.failFast), nil
+}
+
+func (step *AcrossStep) loadValues(name string, credentials *creds.BuildCredentials) ([]interface{}, error) {
+	variables := creds.NewBuildVariables(boshtemplate.StaticVariables{}, step.localVars, credentials)
+
+	value, found, err := atctemplate.LookupLocalVar(variables, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("undefined local var: %s", name)
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("local var '%s' has type '%T'; expected a list of values to run across", name, value)
+	}
+
+	return values, nil
+}
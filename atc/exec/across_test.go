@@ -0,0 +1,291 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Across", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		vars      []atc.AcrossVar
+		localVars *creds.LocalVariables
+		failFast  bool
+
+		lock      sync.Mutex
+		built     map[int]*execfakes.FakeStep
+		scopes    map[int]*creds.LocalVariables
+		failIndex int
+
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		vars = []atc.AcrossVar{
+			{Var: "v1", Values: []interface{}{"a", "b"}},
+			{Var: "v2", Values: []interface{}{1, 2, 3}},
+		}
+
+		localVars = creds.NewLocalVariables()
+		localVars.Set("some-var", "some-value")
+
+		failFast = false
+
+		built = map[int]*execfakes.FakeStep{}
+		scopes = map[int]*creds.LocalVariables{}
+		failIndex = -1
+
+		state = new(execfakes.FakeRunState)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = Across(vars, localVars, func(index int, scope *creds.LocalVariables) (Step, error) {
+			fakeStep := new(execfakes.FakeStep)
+			fakeStep.SucceededReturns(index != failIndex)
+
+			lock.Lock()
+			built[index] = fakeStep
+			scopes[index] = scope
+			lock.Unlock()
+
+			return fakeStep, nil
+		}, failFast)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	scopedValues := func(index int) []interface{} {
+		var values []interface{}
+		for _, v := range vars {
+			value, found := scopes[index].Get(v.Var)
+			Expect(found).To(BeTrue())
+			values = append(values, value)
+		}
+
+		return values
+	}
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("builds and runs a step for each combination of values", func() {
+		Expect(built).To(HaveLen(6))
+
+		for _, s := range built {
+			Expect(s.RunCallCount()).To(Equal(1))
+		}
+	})
+
+	It("sets the values in a scope for each combination, in order", func() {
+		Expect(scopedValues(0)).To(Equal([]interface{}{"a", 1}))
+		Expect(scopedValues(1)).To(Equal([]interface{}{"a", 2}))
+		Expect(scopedValues(2)).To(Equal([]interface{}{"a", 3}))
+		Expect(scopedValues(3)).To(Equal([]interface{}{"b", 1}))
+		Expect(scopedValues(4)).To(Equal([]interface{}{"b", 2}))
+		Expect(scopedValues(5)).To(Equal([]interface{}{"b", 3}))
+	})
+
+	It("leaves the parent scope's vars visible but untouched", func() {
+		value, found := scopes[0].Get("some-var")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-value"))
+
+		_, found = localVars.Get("v1")
+		Expect(found).To(BeFalse())
+	})
+
+	Describe("concurrency", func() {
+		var running, maxRunning int
+
+		BeforeEach(func() {
+			running, maxRunning = 0, 0
+
+			vars = []atc.AcrossVar{
+				{Var: "v1", Values: []interface{}{"a", "b", "c", "d"}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			step = Across(vars, localVars, func(index int, scope *creds.LocalVariables) (Step, error) {
+				fakeStep := new(execfakes.FakeStep)
+				fakeStep.SucceededReturns(true)
+				fakeStep.RunStub = func(context.Context, RunState) error {
+					lock.Lock()
+					running++
+					if running > maxRunning {
+						maxRunning = running
+					}
+					lock.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					lock.Lock()
+					running--
+					lock.Unlock()
+
+					return nil
+				}
+
+				return fakeStep, nil
+			}, failFast)
+
+			stepErr = step.Run(ctx, state)
+		})
+
+		Context("when no max_in_flight is given", func() {
+			It("runs one combination at a time", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(maxRunning).To(Equal(1))
+			})
+		})
+
+		Context("when max_in_flight is given", func() {
+			BeforeEach(func() {
+				vars[0].MaxInFlight = 2
+			})
+
+			It("runs no more than max_in_flight at once", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(maxRunning).To(BeNumerically("<=", 2))
+			})
+		})
+	})
+
+	Context("when a combination fails", func() {
+		BeforeEach(func() {
+			failIndex = 1
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("still runs the remaining combinations", func() {
+			Expect(built).To(HaveLen(6))
+		})
+
+		Context("when fail_fast is set", func() {
+			BeforeEach(func() {
+				failFast = true
+			})
+
+			It("does not run any remaining combinations", func() {
+				Expect(built[0].RunCallCount()).To(Equal(1))
+				Expect(built[1].RunCallCount()).To(Equal(1))
+
+				for i := 2; i < 6; i++ {
+					Expect(built[i].RunCallCount()).To(BeZero())
+				}
+			})
+
+			It("does not succeed", func() {
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the values are loaded from a local var", func() {
+		BeforeEach(func() {
+			localVars.Set("meta", map[string]interface{}{
+				"versions": []interface{}{"1.0", "2.0"},
+			})
+
+			vars = []atc.AcrossVar{
+				{Var: "version", ValuesFrom: "meta.versions"},
+			}
+		})
+
+		It("runs across the loaded values", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(built).To(HaveLen(2))
+
+			Expect(scopedValues(0)).To(Equal([]interface{}{"1.0"}))
+			Expect(scopedValues(1)).To(Equal([]interface{}{"2.0"}))
+		})
+
+		Context("when the local var is not set", func() {
+			BeforeEach(func() {
+				vars = []atc.AcrossVar{
+					{Var: "version", ValuesFrom: "bogus"},
+				}
+			})
+
+			It("errors", func() {
+				Expect(stepErr).To(MatchError("undefined local var: bogus"))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when the local var is not a list", func() {
+			BeforeEach(func() {
+				vars = []atc.AcrossVar{
+					{Var: "version", ValuesFrom: "some-var"},
+				}
+			})
+
+			It("errors", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("expected a list of values")))
+			})
+		})
+	})
+
+	Context("when a step errors", func() {
+		disaster := errors.New("nope")
+
+		JustBeforeEach(func() {
+			step = Across(vars, localVars, func(index int, scope *creds.LocalVariables) (Step, error) {
+				fakeStep := new(execfakes.FakeStep)
+				fakeStep.RunReturns(disaster)
+				return fakeStep, nil
+			}, failFast)
+
+			stepErr = step.Run(ctx, state)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("nope"))
+		})
+	})
+	Context("when a step cannot be built", func() {
+		disaster := errors.New("nope")
+
+		JustBeforeEach(func() {
+			step = Across(vars, localVars, func(index int, scope *creds.LocalVariables) (Step, error) {
+				return nil, disaster
+			}, failFast)
+
+			stepErr = step.Run(ctx, state)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
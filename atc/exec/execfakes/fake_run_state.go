@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 )
@@ -20,6 +21,26 @@ type FakeRunState struct {
 	artifactsReturnsOnCall map[int]struct {
 		result1 *artifact.Repository
 	}
+	CredentialsStub        func() *creds.BuildCredentials
+	credentialsMutex       sync.RWMutex
+	credentialsArgsForCall []struct {
+	}
+	credentialsReturns struct {
+		result1 *creds.BuildCredentials
+	}
+	credentialsReturnsOnCall map[int]struct {
+		result1 *creds.BuildCredentials
+	}
+	LocalVariablesStub        func() *creds.LocalVariables
+	localVariablesMutex       sync.RWMutex
+	localVariablesArgsForCall []struct {
+	}
+	localVariablesReturns struct {
+		result1 *creds.LocalVariables
+	}
+	localVariablesReturnsOnCall map[int]struct {
+		result1 *creds.LocalVariables
+	}
 	ResultStub        func(atc.PlanID, interface{}) bool
 	resultMutex       sync.RWMutex
 	resultArgsForCall []struct {
@@ -94,6 +115,110 @@ func (fake *FakeRunState) ArtifactsReturnsOnCall(i int, result1 *artifact.Reposi
 	}{result1}
 }
 
+func (fake *FakeRunState) Credentials() *creds.BuildCredentials {
+	fake.credentialsMutex.Lock()
+	ret, specificReturn := fake.credentialsReturnsOnCall[len(fake.credentialsArgsForCall)]
+	fake.credentialsArgsForCall = append(fake.credentialsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Credentials", []interface{}{})
+	fake.credentialsMutex.Unlock()
+	if fake.CredentialsStub != nil {
+		return fake.CredentialsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.credentialsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) CredentialsCallCount() int {
+	fake.credentialsMutex.RLock()
+	defer fake.credentialsMutex.RUnlock()
+	return len(fake.credentialsArgsForCall)
+}
+
+func (fake *FakeRunState) CredentialsCalls(stub func() *creds.BuildCredentials) {
+	fake.credentialsMutex.Lock()
+	defer fake.credentialsMutex.Unlock()
+	fake.CredentialsStub = stub
+}
+
+func (fake *FakeRunState) CredentialsReturns(result1 *creds.BuildCredentials) {
+	fake.credentialsMutex.Lock()
+	defer fake.credentialsMutex.Unlock()
+	fake.CredentialsStub = nil
+	fake.credentialsReturns = struct {
+		result1 *creds.BuildCredentials
+	}{result1}
+}
+
+func (fake *FakeRunState) CredentialsReturnsOnCall(i int, result1 *creds.BuildCredentials) {
+	fake.credentialsMutex.Lock()
+	defer fake.credentialsMutex.Unlock()
+	fake.CredentialsStub = nil
+	if fake.credentialsReturnsOnCall == nil {
+		fake.credentialsReturnsOnCall = make(map[int]struct {
+			result1 *creds.BuildCredentials
+		})
+	}
+	fake.credentialsReturnsOnCall[i] = struct {
+		result1 *creds.BuildCredentials
+	}{result1}
+}
+
+func (fake *FakeRunState) LocalVariables() *creds.LocalVariables {
+	fake.localVariablesMutex.Lock()
+	ret, specificReturn := fake.localVariablesReturnsOnCall[len(fake.localVariablesArgsForCall)]
+	fake.localVariablesArgsForCall = append(fake.localVariablesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LocalVariables", []interface{}{})
+	fake.localVariablesMutex.Unlock()
+	if fake.LocalVariablesStub != nil {
+		return fake.LocalVariablesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.localVariablesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) LocalVariablesCallCount() int {
+	fake.localVariablesMutex.RLock()
+	defer fake.localVariablesMutex.RUnlock()
+	return len(fake.localVariablesArgsForCall)
+}
+
+func (fake *FakeRunState) LocalVariablesCalls(stub func() *creds.LocalVariables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = stub
+}
+
+func (fake *FakeRunState) LocalVariablesReturns(result1 *creds.LocalVariables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = nil
+	fake.localVariablesReturns = struct {
+		result1 *creds.LocalVariables
+	}{result1}
+}
+
+func (fake *FakeRunState) LocalVariablesReturnsOnCall(i int, result1 *creds.LocalVariables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = nil
+	if fake.localVariablesReturnsOnCall == nil {
+		fake.localVariablesReturnsOnCall = make(map[int]struct {
+			result1 *creds.LocalVariables
+		})
+	}
+	fake.localVariablesReturnsOnCall[i] = struct {
+		result1 *creds.LocalVariables
+	}{result1}
+}
+
 func (fake *FakeRunState) Result(arg1 atc.PlanID, arg2 interface{}) bool {
 	fake.resultMutex.Lock()
 	ret, specificReturn := fake.resultReturnsOnCall[len(fake.resultArgsForCall)]
@@ -192,6 +317,10 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.credentialsMutex.RLock()
+	defer fake.credentialsMutex.RUnlock()
+	fake.localVariablesMutex.RLock()
+	defer fake.localVariablesMutex.RUnlock()
 	fake.resultMutex.RLock()
 	defer fake.resultMutex.RUnlock()
 	fake.storeResultMutex.RLock()
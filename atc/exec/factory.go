@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -17,6 +18,8 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*creds.LocalVariables,
+		*creds.BuildCredentials,
 		StepMetadata,
 		db.ContainerMetadata,
 		GetDelegate,
@@ -27,6 +30,8 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*creds.LocalVariables,
+		*creds.BuildCredentials,
 		StepMetadata,
 		db.ContainerMetadata,
 		PutDelegate,
@@ -37,6 +42,8 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*creds.LocalVariables,
+		*creds.BuildCredentials,
 		db.ContainerMetadata,
 		TaskDelegate,
 	) Step
@@ -54,6 +61,23 @@ type Factory interface {
 		db.Build,
 		BuildStepDelegate,
 	) Step
+
+	// SetPipeline constructs a SetPipeline step.
+	SetPipeline(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		SetPipelineStepDelegate,
+	) Step
+
+	// LoadVar constructs a LoadVar step.
+	LoadVar(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		*creds.LocalVariables,
+		LoadVarStepDelegate,
+	) Step
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/tracing"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -25,6 +26,8 @@ type gardenFactory struct {
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	resourceFactory       resource.ResourceFactory
+	teamFactory           db.TeamFactory
+	credsManagers         creds.Managers
 }
 
 func NewGardenFactory(
@@ -37,6 +40,8 @@ func NewGardenFactory(
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
+	teamFactory db.TeamFactory,
+	credsManagers creds.Managers,
 ) Factory {
 	return &gardenFactory{
 		pool:                  pool,
@@ -48,6 +53,8 @@ func NewGardenFactory(
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		resourceFactory:       resourceFactory,
+		teamFactory:           teamFactory,
+		credsManagers:         credsManagers,
 	}
 }
 
@@ -55,13 +62,15 @@ func (factory *gardenFactory) Get(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	localVars *creds.LocalVariables,
+	credentials *creds.BuildCredentials,
 	stepMetadata StepMetadata,
 	workerMetadata db.ContainerMetadata,
 	delegate GetDelegate,
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := factory.buildVariables(build, localVars, credentials)
 
 	getStep := NewGetStep(
 		build,
@@ -89,20 +98,22 @@ func (factory *gardenFactory) Get(
 		factory.pool,
 	)
 
-	return LogError(getStep, delegate)
+	return Trace(LogError(getStep, delegate), "get", tracing.Attrs{"name": plan.Get.Name})
 }
 
 func (factory *gardenFactory) Put(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	localVars *creds.LocalVariables,
+	credentials *creds.BuildCredentials,
 	stepMetadata StepMetadata,
 	workerMetadata db.ContainerMetadata,
 	delegate PutDelegate,
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := factory.buildVariables(build, localVars, credentials)
 
 	var putInputs PutInputs
 	if plan.Put.Inputs == nil {
@@ -141,20 +152,22 @@ func (factory *gardenFactory) Put(
 		factory.resourceFactory,
 	)
 
-	return LogError(putStep, delegate)
+	return Trace(LogError(putStep, delegate), "put", tracing.Attrs{"name": plan.Put.Name})
 }
 
 func (factory *gardenFactory) Task(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	localVars *creds.LocalVariables,
+	credentials *creds.BuildCredentials,
 	containerMetadata db.ContainerMetadata,
 	delegate TaskDelegate,
 ) Step {
 	workingDirectory := factory.taskWorkingDirectory(artifact.Name(plan.Task.Name))
 	containerMetadata.WorkingDirectory = workingDirectory
 
-	credMgrVariables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	credMgrVariables := factory.buildVariables(build, localVars, credentials)
 
 	var taskConfigSource TaskConfigSource
 	var taskVars []boshtemplate.Variables
@@ -206,7 +219,7 @@ func (factory *gardenFactory) Task(
 		factory.strategy,
 	)
 
-	return LogError(taskStep, delegate)
+	return Trace(LogError(taskStep, delegate), "task", tracing.Attrs{"name": plan.Task.Name})
 }
 
 func (factory *gardenFactory) ArtifactInputStep(
@@ -227,6 +240,52 @@ func (factory *gardenFactory) ArtifactOutputStep(
 	return NewArtifactOutputStep(plan, build, factory.client, delegate)
 }
 
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate SetPipelineStepDelegate,
+) Step {
+	setPipelineStep := NewSetPipelineStep(
+		plan.ID,
+		*plan.SetPipeline,
+		build,
+		delegate,
+		factory.teamFactory,
+		factory.credsManagers,
+	)
+
+	return Trace(LogError(setPipelineStep, delegate), "set_pipeline", tracing.Attrs{"name": plan.SetPipeline.Name})
+}
+
+func (factory *gardenFactory) LoadVar(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	localVars *creds.LocalVariables,
+	delegate LoadVarStepDelegate,
+) Step {
+	loadVarStep := NewLoadVarStep(
+		plan.ID,
+		*plan.LoadVar,
+		localVars,
+		delegate,
+	)
+
+	return Trace(LogError(loadVarStep, delegate), "load_var", tracing.Attrs{"name": plan.LoadVar.Name})
+}
+
+// buildVariables layers the build's local vars over the credential manager
+// variables for the build's pipeline, tracking the credentials resolved from
+// them for the build.
+func (factory *gardenFactory) buildVariables(build db.Build, localVars *creds.LocalVariables, credentials *creds.BuildCredentials) creds.Variables {
+	return creds.NewBuildVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		localVars,
+		credentials,
+	)
+}
+
 func (factory *gardenFactory) taskWorkingDirectory(sourceName artifact.Name) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
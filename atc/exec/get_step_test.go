@@ -44,7 +44,11 @@ var _ = Describe("GetStep", func() {
 		fakeResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeVariablesFactory      *credsfakes.FakeVariablesFactory
+		fakeTeamFactory           *dbfakes.FakeTeamFactory
 		variables                 creds.Variables
+		localVars                 *creds.LocalVariables
+		credentials               *creds.BuildCredentials
+		buildVariables            creds.Variables
 		fakeBuild                 *dbfakes.FakeBuild
 		fakeDelegate              *execfakes.FakeGetDelegate
 		getPlan                   *atc.GetPlan
@@ -82,6 +86,7 @@ var _ = Describe("GetStep", func() {
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
 
 		fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
 		variables = template.StaticVariables{
@@ -89,6 +94,10 @@ var _ = Describe("GetStep", func() {
 		}
 		fakeVariablesFactory.NewVariablesReturns(variables)
 
+		localVars = creds.NewLocalVariables()
+		credentials = creds.NewBuildCredentials()
+		buildVariables = creds.NewBuildVariables(variables, localVars, credentials)
+
 		artifactRepository = artifact.NewRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(artifactRepository)
@@ -122,7 +131,7 @@ var _ = Describe("GetStep", func() {
 			VersionedResourceTypes: resourceTypes,
 		}
 
-		factory = exec.NewGardenFactory(fakePool, fakeClient, fakeResourceFetcher, fakeResourceCacheFactory, fakeResourceConfigFactory, fakeVariablesFactory, atc.ContainerLimits{}, fakeStrategy, fakeResourceFactory)
+		factory = exec.NewGardenFactory(fakePool, fakeClient, fakeResourceFetcher, fakeResourceCacheFactory, fakeResourceConfigFactory, fakeVariablesFactory, atc.ContainerLimits{}, fakeStrategy, fakeResourceFactory, fakeTeamFactory, creds.Managers{})
 
 		fakeDelegate = new(execfakes.FakeGetDelegate)
 	})
@@ -140,6 +149,8 @@ var _ = Describe("GetStep", func() {
 				Get: getPlan,
 			},
 			fakeBuild,
+			localVars,
+			credentials,
 			stepMetadata,
 			containerMetadata,
 			fakeDelegate,
@@ -163,7 +174,7 @@ var _ = Describe("GetStep", func() {
 			ResourceType:  "some-resource-type",
 			Tags:          atc.Tags{"some", "tags"},
 			TeamID:        teamID,
-			ResourceTypes: creds.NewVersionedResourceTypes(variables, resourceTypes),
+			ResourceTypes: creds.NewVersionedResourceTypes(buildVariables, resourceTypes),
 		}))
 		Expect(strategy).To(Equal(fakeStrategy))
 	})
@@ -201,11 +212,11 @@ var _ = Describe("GetStep", func() {
 				atc.Version{"some-version": "some-value"},
 				atc.Source{"some": "super-secret-source"},
 				atc.Params{"some-param": "some-value"},
-				creds.NewVersionedResourceTypes(variables, resourceTypes),
+				creds.NewVersionedResourceTypes(buildVariables, resourceTypes),
 				nil,
 				db.NewBuildStepContainerOwner(buildID, atc.PlanID(planID), teamID),
 			)))
-			Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, resourceTypes)))
+			Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(buildVariables, resourceTypes)))
 			Expect(delegate).To(Equal(fakeDelegate))
 			expectedLockName := fmt.Sprintf("%x",
 				sha256.Sum256([]byte(
@@ -216,6 +227,18 @@ var _ = Describe("GetStep", func() {
 			Expect(resourceInstance.LockName("fake-worker")).To(Equal(expectedLockName))
 		})
 
+		Context("when the params refer to a local var", func() {
+			BeforeEach(func() {
+				getPlan.Params = atc.Params{"some-param": "((.:some-var))"}
+				localVars.Set("some-var", "some-local-value")
+			})
+
+			It("fetches the resource with the local var's value", func() {
+				_, _, _, _, _, _, resourceInstance, _ := fakeResourceFetcher.FetchArgsForCall(0)
+				Expect(resourceInstance.Params()).To(Equal(atc.Params{"some-param": "some-local-value"}))
+			})
+		})
+
 		Context("when fetching resource succeeds", func() {
 			BeforeEach(func() {
 				fakeVersionedSource.VersionReturns(atc.Version{"some": "version"})
@@ -284,7 +307,7 @@ var _ = Describe("GetStep", func() {
 							Expect(version).To(Equal(atc.Version{"some": "version"}))
 							Expect(metadata).To(Equal(db.NewResourceConfigMetadataFields([]atc.MetadataField{{"some", "metadata"}})))
 							Expect(resourceConfig).To(Equal(fakeResourceConfig))
-							Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, resourceTypes)))
+							Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(buildVariables, resourceTypes)))
 						})
 
 						Context("when it fails to save the version", func() {
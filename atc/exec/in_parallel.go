@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// InParallelStep is a step of steps to run in parallel, with an optional limit
+// on how many may run at once.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+}
+
+// InParallel constructs an InParallelStep. A limit of zero (or a limit
+// greater than the number of steps) runs every step at once.
+func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
+	if limit < 1 || limit > len(steps) {
+		limit = len(steps)
+	}
+
+	return InParallelStep{
+		steps:    steps,
+		limit:    limit,
+		failFast: failFast,
+	}
+}
+
+// Run executes the steps in parallel, running at most the configured limit of
+// steps at any given time. Steps are started in the order they were given.
+//
+// If fail-fast is configured, the first step to fail or error will cause any
+// running steps to be canceled and any remaining steps to not be run at all.
+// Otherwise, it will wait for all steps to exit, even if one step fails or
+// errors.
+//
+// After all started steps finish, their errors (if any) will be aggregated and
+// returned as a single error. Cancelation errors caused by fail-fast are not
+// included.
+func (step InParallelStep) Run(ctx context.Context, state RunState) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(step.steps))
+	sem := make(chan struct{}, step.limit)
+
+	started := 0
+
+dispatch:
+	for _, s := range step.steps {
+		select {
+		case sem <- struct{}{}:
+		case <-runCtx.Done():
+			break dispatch
+		}
+
+		if runCtx.Err() != nil {
+			<-sem
+			break
+		}
+
+		started++
+
+		s := s
+		go func() {
+			defer func() { <-sem }()
+
+			err := s.Run(runCtx, state)
+			if step.failFast && (err != nil || !s.Succeeded()) {
+				cancel()
+			}
+
+			errs <- err
+		}()
+	}
+
+	var errorMessages []string
+	for i := 0; i < started; i++ {
+		err := <-errs
+		if err != nil && err != context.Canceled {
+			errorMessages = append(errorMessages, err.Error())
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("one or more parallel steps errored:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// Succeeded is true if all of the steps' Succeeded is true. Steps that were
+// never started due to fail-fast are not considered to have succeeded.
+func (step InParallelStep) Succeeded() bool {
+	for _, s := range step.steps {
+		if !s.Succeeded() {
+			return false
+		}
+	}
+
+	return true
+}
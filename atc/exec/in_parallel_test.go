@@ -0,0 +1,243 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStepA *execfakes.FakeStep
+		fakeStepB *execfakes.FakeStep
+		fakeStepC *execfakes.FakeStep
+
+		limit    int
+		failFast bool
+
+		repo  *artifact.Repository
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStepA = new(execfakes.FakeStep)
+		fakeStepB = new(execfakes.FakeStep)
+		fakeStepC = new(execfakes.FakeStep)
+
+		fakeStepA.SucceededReturns(true)
+		fakeStepB.SucceededReturns(true)
+		fakeStepC.SucceededReturns(true)
+
+		limit = 0
+		failFast = false
+
+		repo = artifact.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = InParallel([]Step{fakeStepA, fakeStepB, fakeStepC}, limit, failFast)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("passes the run state to all steps", func() {
+		Expect(fakeStepA.RunCallCount()).To(Equal(1))
+		_, s := fakeStepA.RunArgsForCall(0)
+		Expect(s).To(Equal(state))
+
+		Expect(fakeStepB.RunCallCount()).To(Equal(1))
+		_, s = fakeStepB.RunArgsForCall(0)
+		Expect(s).To(Equal(state))
+
+		Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		_, s = fakeStepC.RunArgsForCall(0)
+		Expect(s).To(Equal(state))
+	})
+
+	Context("when no limit is given", func() {
+		BeforeEach(func() {
+			wg := new(sync.WaitGroup)
+			wg.Add(3)
+
+			run := func(context.Context, RunState) error {
+				wg.Done()
+				wg.Wait()
+				return nil
+			}
+
+			fakeStepA.RunStub = run
+			fakeStepB.RunStub = run
+			fakeStepC.RunStub = run
+		})
+
+		It("runs every step concurrently", func() {
+			Expect(fakeStepA.RunCallCount()).To(Equal(1))
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a limit is given", func() {
+		var (
+			lock    *sync.Mutex
+			running int
+			maxSeen int
+		)
+
+		BeforeEach(func() {
+			limit = 2
+
+			lock = new(sync.Mutex)
+			running = 0
+			maxSeen = 0
+
+			run := func(context.Context, RunState) error {
+				lock.Lock()
+				running++
+				if running > maxSeen {
+					maxSeen = running
+				}
+				lock.Unlock()
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+
+				return nil
+			}
+
+			fakeStepA.RunStub = run
+			fakeStepB.RunStub = run
+			fakeStepC.RunStub = run
+		})
+
+		It("never runs more than the limit at once", func() {
+			Expect(maxSeen).To(BeNumerically("<=", 2))
+		})
+
+		It("still runs every step", func() {
+			Expect(fakeStepA.RunCallCount()).To(Equal(1))
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("canceling", func() {
+		BeforeEach(func() {
+			cancel()
+		})
+
+		It("returns ctx.Err()", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+		})
+	})
+
+	Context("when steps error", func() {
+		BeforeEach(func() {
+			fakeStepA.RunReturns(errors.New("nope A"))
+			fakeStepB.RunReturns(errors.New("nope B"))
+		})
+
+		It("exits with an error including the original messages", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("nope A"))
+			Expect(stepErr.Error()).To(ContainSubstring("nope B"))
+		})
+
+		It("still runs the remaining steps", func() {
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			limit = 1
+			fakeStepA.SucceededReturns(false)
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		Context("without fail-fast", func() {
+			It("runs the remaining steps", func() {
+				Expect(fakeStepB.RunCallCount()).To(Equal(1))
+				Expect(fakeStepC.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("with fail-fast", func() {
+			BeforeEach(func() {
+				failFast = true
+			})
+
+			It("does not run the remaining steps", func() {
+				Expect(fakeStepA.RunCallCount()).To(Equal(1))
+				Expect(fakeStepB.RunCallCount()).To(Equal(0))
+				Expect(fakeStepC.RunCallCount()).To(Equal(0))
+			})
+
+			It("does not error", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when fail-fast is set and a step fails while others are running", func() {
+		BeforeEach(func() {
+			failFast = true
+
+			fakeStepA.RunStub = func(ctx context.Context, _ RunState) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			fakeStepB.RunStub = func(ctx context.Context, _ RunState) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			fakeStepC.SucceededReturns(false)
+		})
+
+		It("cancels the running steps", func() {
+			runCtx, _ := fakeStepA.RunArgsForCall(0)
+			Expect(runCtx.Err()).To(Equal(context.Canceled))
+
+			runCtx, _ = fakeStepB.RunArgsForCall(0)
+			Expect(runCtx.Err()).To(Equal(context.Canceled))
+		})
+
+		It("does not report the cancellation as an error", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,137 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . LoadVarStepDelegate
+
+type LoadVarStepDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger)
+	Finished(lager.Logger, bool)
+}
+
+// LoadVarStep reads a file out of the artifact.Repository and sets its
+// contents as a local var, which may be referred to as ((.:name)) by any
+// subsequent steps in the same scope.
+type LoadVarStep struct {
+	planID    atc.PlanID
+	plan      atc.LoadVarPlan
+	localVars *creds.LocalVariables
+	delegate  LoadVarStepDelegate
+
+	succeeded bool
+}
+
+func NewLoadVarStep(
+	planID atc.PlanID,
+	plan atc.LoadVarPlan,
+	localVars *creds.LocalVariables,
+	delegate LoadVarStepDelegate,
+) Step {
+	return &LoadVarStep{
+		planID:    planID,
+		plan:      plan,
+		localVars: localVars,
+		delegate:  delegate,
+	}
+}
+
+// Run reads the file and parses it according to the plan's format, or its
+// file extension if no format is given. The parsed value is then set as a
+// local var.
+func (step *LoadVarStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id": step.planID,
+		"var":     step.plan.Name,
+	})
+
+	step.delegate.Initializing(logger)
+
+	content, err := readArtifactFile(logger, state.Artifacts(), step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	step.delegate.Starting(logger)
+
+	format := step.format()
+
+	value, err := parseLoadVarContent(content, format)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s as %s: %s", step.plan.File, format, err)
+	}
+
+	step.localVars.Set(step.plan.Name, value)
+
+	logger.Info("loaded-var", lager.Data{"format": format})
+
+	step.succeeded = true
+	step.delegate.Finished(logger, true)
+
+	return nil
+}
+
+// Succeeded is true if the file was read and parsed successfully.
+func (step *LoadVarStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *LoadVarStep) format() string {
+	if step.plan.Format != "" {
+		return step.plan.Format
+	}
+
+	switch strings.ToLower(filepath.Ext(step.plan.File)) {
+	case ".json":
+		return atc.LoadVarFormatJSON
+	case ".yml", ".yaml":
+		return atc.LoadVarFormatYAML
+	default:
+		return atc.LoadVarFormatTrim
+	}
+}
+
+func parseLoadVarContent(content []byte, format string) (interface{}, error) {
+	switch format {
+	case atc.LoadVarFormatRaw:
+		return string(content), nil
+
+	case atc.LoadVarFormatTrim:
+		return strings.TrimSpace(string(content)), nil
+
+	case atc.LoadVarFormatJSON:
+		var value interface{}
+		err := json.Unmarshal(content, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+
+	case atc.LoadVarFormatYAML, atc.LoadVarFormatYML:
+		var value interface{}
+		err := yaml.Unmarshal(content, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
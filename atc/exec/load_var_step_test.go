@@ -0,0 +1,185 @@
+package exec_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("LoadVarStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate       *execfakes.FakeLoadVarStepDelegate
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+		files              map[string]string
+
+		state     exec.RunState
+		localVars *creds.LocalVariables
+
+		plan atc.LoadVarPlan
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeDelegate = new(execfakes.FakeLoadVarStepDelegate)
+		fakeDelegate.StdoutReturns(ioutil.Discard)
+		fakeDelegate.StderrReturns(ioutil.Discard)
+
+		files = map[string]string{
+			"version":     "1.2.3\n",
+			"meta.json":   `{"ref": "abcdef", "tags": ["a", "b"]}`,
+			"meta.yml":    "ref: abcdef\ntags: [a, b]\n",
+			"bogus.json":  "{",
+			"version.txt": " 4.5.6 \n",
+		}
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			contents, found := files[path]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return ioutil.NopCloser(gbytes.BufferWithBytes([]byte(contents))), nil
+		}
+
+		state = exec.NewRunState()
+		localVars = creds.NewLocalVariables()
+		state.Artifacts().RegisterSource("some-source", fakeArtifactSource)
+
+		plan = atc.LoadVarPlan{
+			Name: "some-var",
+			File: "some-source/version",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewLoadVarStep("some-plan-id", plan, localVars, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	loadedVar := func() interface{} {
+		value, found := localVars.Get("some-var")
+		Expect(found).To(BeTrue())
+		return value
+	}
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("emits initializing, starting, and finished events", func() {
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when no format is given", func() {
+		It("trims the file's content by default", func() {
+			Expect(loadedVar()).To(Equal("1.2.3"))
+		})
+
+		Context("when the file has a .json extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-source/meta.json"
+			})
+
+			It("parses it as JSON", func() {
+				Expect(loadedVar()).To(Equal(map[string]interface{}{
+					"ref":  "abcdef",
+					"tags": []interface{}{"a", "b"},
+				}))
+			})
+		})
+
+		Context("when the file has a .yml extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-source/meta.yml"
+			})
+
+			It("parses it as YAML", func() {
+				Expect(loadedVar()).To(Equal(map[interface{}]interface{}{
+					"ref":  "abcdef",
+					"tags": []interface{}{"a", "b"},
+				}))
+			})
+		})
+	})
+
+	Context("when the format is raw", func() {
+		BeforeEach(func() {
+			plan.File = "some-source/version.txt"
+			plan.Format = "raw"
+		})
+
+		It("loads the file's content verbatim", func() {
+			Expect(loadedVar()).To(Equal(" 4.5.6 \n"))
+		})
+	})
+
+	Context("when the format is trim", func() {
+		BeforeEach(func() {
+			plan.File = "some-source/meta.json"
+			plan.Format = "trim"
+		})
+
+		It("does not parse the file", func() {
+			Expect(loadedVar()).To(Equal(`{"ref": "abcdef", "tags": ["a", "b"]}`))
+		})
+	})
+
+	Context("when the file cannot be parsed", func() {
+		BeforeEach(func() {
+			plan.File = "some-source/bogus.json"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("failed to parse some-source/bogus.json as json"))
+		})
+
+		It("does not set the var", func() {
+			_, found := localVars.Get("some-var")
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "some-source/bogus"
+		})
+
+		It("returns a FileNotFoundError", func() {
+			Expect(stepErr).To(Equal(exec.FileNotFoundError{Path: "some-source/bogus"}))
+		})
+	})
+})
@@ -5,18 +5,23 @@ import (
 	"sync"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/exec/artifact"
 )
 
 type runState struct {
-	artifacts *artifact.Repository
-	results   *sync.Map
+	artifacts   *artifact.Repository
+	results     *sync.Map
+	localVars   *creds.LocalVariables
+	credentials *creds.BuildCredentials
 }
 
 func NewRunState() RunState {
 	return &runState{
-		artifacts: artifact.NewRepository(),
-		results:   &sync.Map{},
+		artifacts:   artifact.NewRepository(),
+		results:     &sync.Map{},
+		localVars:   creds.NewLocalVariables(),
+		credentials: creds.NewBuildCredentials(),
 	}
 }
 
@@ -24,6 +29,14 @@ func (state *runState) Artifacts() *artifact.Repository {
 	return state.artifacts
 }
 
+func (state *runState) LocalVariables() *creds.LocalVariables {
+	return state.localVars
+}
+
+func (state *runState) Credentials() *creds.BuildCredentials {
+	return state.credentials
+}
+
 func (state *runState) Result(id atc.PlanID, to interface{}) bool {
 	val, ok := state.results.Load(id)
 	if !ok {
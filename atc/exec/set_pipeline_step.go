@@ -0,0 +1,218 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/template"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . SetPipelineStepDelegate
+
+type SetPipelineStepDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger)
+	Finished(lager.Logger, bool)
+}
+
+// SetPipelineStep configures a pipeline within the build's team, using a
+// pipeline config file fetched from the artifact.Repository.
+type SetPipelineStep struct {
+	planID        atc.PlanID
+	plan          atc.SetPipelinePlan
+	build         db.Build
+	delegate      SetPipelineStepDelegate
+	teamFactory   db.TeamFactory
+	credsManagers creds.Managers
+
+	succeeded bool
+}
+
+func NewSetPipelineStep(
+	planID atc.PlanID,
+	plan atc.SetPipelinePlan,
+	build db.Build,
+	delegate SetPipelineStepDelegate,
+	teamFactory db.TeamFactory,
+	credsManagers creds.Managers,
+) Step {
+	return &SetPipelineStep{
+		planID:        planID,
+		plan:          plan,
+		build:         build,
+		delegate:      delegate,
+		teamFactory:   teamFactory,
+		credsManagers: credsManagers,
+	}
+}
+
+// Run reads the pipeline config and any var files out of the
+// artifact.Repository, interpolates the given vars into the config, and
+// validates it in the same way as configs submitted through the API.
+//
+// If the config is invalid, the validation errors are written to stderr and
+// the step fails. Otherwise the config is saved for the build's team. New
+// pipelines are created paused, as with `fly set-pipeline`.
+func (step *SetPipelineStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id":  step.planID,
+		"pipeline": step.plan.Name,
+	})
+
+	step.delegate.Initializing(logger)
+
+	stdout := step.delegate.Stdout()
+	stderr := step.delegate.Stderr()
+
+	config, err := step.fetchConfig(logger, state.Artifacts())
+	if err != nil {
+		return err
+	}
+
+	step.delegate.Starting(logger)
+
+	warnings, errorMessages := config.Validate()
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	team := step.teamFactory.GetByID(step.build.TeamID())
+
+	varSourceErrors, err := step.credsManagers.ValidateVarSources(config, team.CredentialManager())
+	if err != nil {
+		return err
+	}
+
+	errorMessages = append(errorMessages, varSourceErrors...)
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintln(stderr, "invalid pipeline:")
+
+		for _, message := range errorMessages {
+			fmt.Fprintf(stderr, "- %s\n", message)
+		}
+
+		step.delegate.Finished(logger, false)
+		return nil
+	}
+
+	var fromVersion db.ConfigVersion
+
+	pipeline, found, err := team.Pipeline(step.plan.Name)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		fromVersion = pipeline.ConfigVersion()
+	}
+
+	fmt.Fprintf(stdout, "setting pipeline: %s\n", step.plan.Name)
+
+	_, created, err := team.SavePipeline(step.plan.Name, config, fromVersion, db.PipelineNoChange)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Fprintln(stdout, "pipeline created (paused)")
+	} else {
+		fmt.Fprintln(stdout, "configuration updated")
+	}
+
+	logger.Info("saved-pipeline", lager.Data{"created": created})
+
+	step.succeeded = true
+	step.delegate.Finished(logger, true)
+
+	return nil
+}
+
+// Succeeded is true if the pipeline config was valid and was saved.
+func (step *SetPipelineStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *SetPipelineStep) fetchConfig(logger lager.Logger, repo *artifact.Repository) (atc.Config, error) {
+	configBytes, err := readArtifactFile(logger, repo, step.plan.File)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	// explicitly specified vars take precedence over var files, and var files
+	// specified later take precedence over those specified earlier
+	params := []boshtemplate.Variables{boshtemplate.StaticVariables(step.plan.Vars)}
+	for i := len(step.plan.VarFiles) - 1; i >= 0; i-- {
+		path := step.plan.VarFiles[i]
+
+		varsBytes, err := readArtifactFile(logger, repo, path)
+		if err != nil {
+			return atc.Config{}, err
+		}
+
+		var staticVars boshtemplate.StaticVariables
+		err = yaml.Unmarshal(varsBytes, &staticVars)
+		if err != nil {
+			return atc.Config{}, fmt.Errorf("failed to parse vars file '%s': %s", path, err)
+		}
+
+		params = append(params, staticVars)
+	}
+
+	// credential manager vars are left in place to be resolved when the
+	// pipeline's own builds run
+	configBytes, err = template.NewTemplateResolver(configBytes, params).Resolve(false, false)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to interpolate pipeline config '%s': %s", step.plan.File, err)
+	}
+
+	config, err := atc.NewConfig(configBytes)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to parse pipeline config '%s': %s", step.plan.File, err)
+	}
+
+	return config, nil
+}
+
+// readArtifactFile reads a file out of the artifact.Repository. The path must
+// be in the format SOURCE_NAME/FILE/PATH, in the same way as task config files.
+func readArtifactFile(logger lager.Logger, repo *artifact.Repository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := artifact.Name(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName, path}
+	}
+
+	stream, err := source.StreamFile(logger, filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, FileNotFoundError{Path: path}
+		}
+
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
@@ -0,0 +1,386 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeBuild       *dbfakes.FakeBuild
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakePipeline    *dbfakes.FakePipeline
+		fakeDelegate    *execfakes.FakeSetPipelineStepDelegate
+		fakeManager     *credsfakes.FakeManager
+
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+		files              map[string]string
+
+		repo  *artifact.Repository
+		state *execfakes.FakeRunState
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		plan atc.SetPipelinePlan
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.TeamIDReturns(123)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.ConfigVersionReturns(db.ConfigVersion(42))
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.SavePipelineReturns(fakePipeline, false, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeSetPipelineStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		fakeManager = new(credsfakes.FakeManager)
+		fakeManager.IsConfiguredReturns(true)
+
+		files = map[string]string{
+			"pipeline.yml": `
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    config:
+      platform: linux
+      run: {path: ((path))}
+`,
+		}
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			contents, found := files[path]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return ioutil.NopCloser(gbytes.BufferWithBytes([]byte(contents))), nil
+		}
+
+		repo = artifact.NewRepository()
+		repo.RegisterSource("some-source", fakeArtifactSource)
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-pipeline",
+			File: "some-source/pipeline.yml",
+			Vars: atc.Params{"path": "echo"},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewSetPipelineStep(
+			"some-plan-id",
+			plan,
+			fakeBuild,
+			fakeDelegate,
+			fakeTeamFactory,
+			creds.Managers{"vault": fakeManager},
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	savedConfig := func() atc.Config {
+		Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+		_, config, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+		return config
+	}
+
+	It("saves the interpolated config in the build's team", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+
+		Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(123))
+
+		name, config, _, paused := fakeTeam.SavePipelineArgsForCall(0)
+		Expect(name).To(Equal("some-pipeline"))
+		Expect(paused).To(Equal(db.PipelineNoChange))
+		Expect(config.Jobs[0].Name).To(Equal("some-job"))
+		Expect(config.Jobs[0].Plan[0].TaskConfig.Run.Path).To(Equal("echo"))
+	})
+
+	It("succeeds", func() {
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("emits initializing, starting, and finished events", func() {
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when the pipeline does not exist yet", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, nil)
+			fakeTeam.SavePipelineReturns(fakePipeline, true, nil)
+		})
+
+		It("saves it from the zero config version", func() {
+			_, _, fromVersion, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(fromVersion).To(BeZero())
+		})
+
+		It("reports that it was created paused", func() {
+			Expect(stdout).To(gbytes.Say("pipeline created \\(paused\\)"))
+		})
+	})
+
+	Context("when the pipeline already exists", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(fakePipeline, true, nil)
+		})
+
+		It("saves it from the current config version", func() {
+			_, _, fromVersion, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(fromVersion).To(Equal(db.ConfigVersion(42)))
+		})
+
+		It("reports that it was updated", func() {
+			Expect(stdout).To(gbytes.Say("configuration updated"))
+		})
+	})
+
+	Context("when var files are given", func() {
+		BeforeEach(func() {
+			files["vars-1.yml"] = "path: from-file-1\nimage: from-file-1\n"
+			files["vars-2.yml"] = "path: from-file-2\n"
+
+			files["pipeline.yml"] = `
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    config:
+      platform: linux
+      image_resource: {type: docker, source: {repository: ((image))}}
+      run: {path: ((path))}
+`
+
+			plan.Vars = nil
+			plan.VarFiles = []string{"some-source/vars-1.yml", "some-source/vars-2.yml"}
+		})
+
+		It("gives precedence to later var files", func() {
+			config := savedConfig()
+			Expect(config.Jobs[0].Plan[0].TaskConfig.Run.Path).To(Equal("from-file-2"))
+			Expect(config.Jobs[0].Plan[0].TaskConfig.ImageResource.Source).To(Equal(atc.Source{"repository": "from-file-1"}))
+		})
+
+		Context("when vars are also given", func() {
+			BeforeEach(func() {
+				plan.Vars = atc.Params{"path": "from-vars"}
+			})
+
+			It("gives precedence to the vars", func() {
+				config := savedConfig()
+				Expect(config.Jobs[0].Plan[0].TaskConfig.Run.Path).To(Equal("from-vars"))
+			})
+		})
+
+		Context("when a var file cannot be parsed", func() {
+			BeforeEach(func() {
+				files["vars-2.yml"] = "- bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(stepErr).To(HaveOccurred())
+				Expect(stepErr.Error()).To(ContainSubstring("failed to parse vars file 'some-source/vars-2.yml'"))
+			})
+		})
+	})
+
+	Context("when vars are left unresolved", func() {
+		BeforeEach(func() {
+			plan.Vars = nil
+		})
+
+		It("leaves them to be resolved by the pipeline's builds", func() {
+			config := savedConfig()
+			Expect(config.Jobs[0].Plan[0].TaskConfig.Run.Path).To(Equal("((path))"))
+		})
+	})
+
+	Context("when the config file does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "some-source/bogus.yml"
+		})
+
+		It("returns a FileNotFoundError", func() {
+			Expect(stepErr).To(Equal(exec.FileNotFoundError{Path: "some-source/bogus.yml"}))
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the config file's artifact source is unknown", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-source/pipeline.yml"
+		})
+
+		It("returns an UnknownArtifactSourceError", func() {
+			Expect(stepErr).To(Equal(exec.UnknownArtifactSourceError{"bogus-source", "bogus-source/pipeline.yml"}))
+		})
+	})
+
+	Context("when the config file path does not specify an artifact source", func() {
+		BeforeEach(func() {
+			plan.File = "pipeline.yml"
+		})
+
+		It("returns an UnspecifiedArtifactSourceError", func() {
+			Expect(stepErr).To(Equal(exec.UnspecifiedArtifactSourceError{"pipeline.yml"}))
+		})
+	})
+
+	Context("when the config has extra keys", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = "jobs: [{name: some-job, plan: [], bogus: true}]"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("failed to parse pipeline config 'some-source/pipeline.yml'"))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("does not error", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("writes the validation errors to stderr", func() {
+			Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+			Expect(stderr).To(gbytes.Say("refers to a resource that does not exist"))
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+
+		It("finishes as failed", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the config refers to an unknown credential manager", func() {
+		BeforeEach(func() {
+			plan.Vars["path"] = "((vault:some/path))-((bogus:some/path))"
+		})
+
+		It("does not succeed", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("writes the validation error to stderr", func() {
+			Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+			Expect(stderr).To(gbytes.Say("- unknown credential manager 'bogus'"))
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the config refers to a manager other than the team's own", func() {
+		BeforeEach(func() {
+			plan.Vars["path"] = "((vault:some/path))-((bogus:some/path))"
+
+			fakeTeam.CredentialManagerReturns(&atc.TeamCredentialManager{
+				Type:   "bogus",
+				Config: map[string]interface{}{"url": "https://bogus.example.com"},
+			})
+		})
+
+		It("writes the validation error to stderr", func() {
+			Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+			Expect(stderr).To(gbytes.Say("- unknown credential manager 'vault'"))
+			Expect(stderr).ToNot(gbytes.Say("'bogus'"))
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when saving the pipeline fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeam.SavePipelineReturns(nil, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
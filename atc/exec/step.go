@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/exec/artifact"
 )
 
@@ -37,6 +38,14 @@ type OutputHandler func(io.Writer) error
 type RunState interface {
 	Artifacts() *artifact.Repository
 
+	// LocalVariables are the vars local to the build, e.g. those set by a
+	// load_var step.
+	LocalVariables() *creds.LocalVariables
+
+	// Credentials tracks the credentials resolved for the build, so that they
+	// can be redacted from its output and any leases on them revoked.
+	Credentials() *creds.BuildCredentials
+
 	Result(atc.PlanID, interface{}) bool
 	StoreResult(atc.PlanID, interface{})
 }
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
@@ -18,12 +19,17 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/tracing"
 	"github.com/concourse/concourse/atc/worker"
 )
 
 const taskProcessID = "task"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// WorkerAvailabilityPollingInterval is how often a task step which is waiting
+// for a worker with capacity to run it tries again.
+var WorkerAvailabilityPollingInterval = 5 * time.Second
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -59,6 +65,7 @@ type TaskDelegate interface {
 	BuildStepDelegate
 
 	Initializing(lager.Logger, atc.TaskConfig)
+	WaitingForWorker(lager.Logger)
 	Starting(lager.Logger, atc.TaskConfig)
 	Finished(lager.Logger, ExitStatus)
 }
@@ -185,22 +192,20 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 	}
 
 	owner := db.NewBuildStepContainerOwner(action.buildID, action.planID, action.teamID)
-	chosenWorker, err := action.workerPool.FindOrChooseWorkerForContainer(logger, owner, containerSpec, workerSpec, action.strategy)
+	chosenWorker, container, err := action.findOrCreateContainer(ctx, logger, owner, containerSpec, workerSpec)
 	if err != nil {
 		return err
 	}
 
-	container, err := chosenWorker.FindOrCreateContainer(
-		ctx,
-		logger,
-		action.delegate,
-		owner,
-		action.containerMetadata,
-		containerSpec,
-		action.resourceTypes,
-	)
-	if err != nil {
-		return err
+	// the task was counted on the worker when its container was created, even
+	// if that was by an earlier attempt at this step, so it is released here
+	if action.strategy.ModifiesActiveTasks() {
+		defer func() {
+			err := chosenWorker.DecreaseActiveTasks()
+			if err != nil {
+				logger.Error("failed-to-decrease-active-tasks", err)
+			}
+		}()
 	}
 
 	exitStatusProp, err := container.Property(taskExitStatusPropertyName)
@@ -244,6 +249,10 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 				Dir: path.Join(action.artifactsRoot, config.Run.Dir),
 
+				// propagate the trace context so that the task can add its own
+				// spans to the build's trace
+				Env: tracing.Env(ctx),
+
 				// Guardian sets the default TTY window size to width: 80, height: 24,
 				// which creates ANSI control sequences that do not work with other window sizes
 				TTY: &garden.TTYSpec{
@@ -311,6 +320,59 @@ func (action *TaskStep) Succeeded() bool {
 	return action.succeeded
 }
 
+// findOrCreateContainer waits until the placement strategy finds a worker
+// with capacity to run the task, emitting an event the first time it has to
+// wait, and finds or creates the task's container on it.
+//
+// If the strategy keeps track of active tasks, the task is counted on the
+// worker when its container is created. Another task may have taken the
+// worker's last slot since it was chosen, in which case the worker is
+// treated as busy and another is chosen.
+func (action *TaskStep) findOrCreateContainer(
+	ctx context.Context,
+	logger lager.Logger,
+	owner db.ContainerOwner,
+	containerSpec worker.ContainerSpec,
+	workerSpec worker.WorkerSpec,
+) (worker.Worker, worker.Container, error) {
+	waiting := false
+
+	for {
+		chosenWorker, err := action.workerPool.FindOrChooseWorkerForContainer(logger, owner, containerSpec, workerSpec, action.strategy)
+		if err == nil {
+			var container worker.Container
+			container, err = chosenWorker.FindOrCreateContainer(
+				ctx,
+				logger,
+				action.delegate,
+				owner,
+				action.containerMetadata,
+				containerSpec,
+				action.resourceTypes,
+			)
+			if err == nil {
+				return chosenWorker, container, nil
+			}
+		}
+
+		if err != worker.ErrAllWorkersBusy {
+			return nil, nil, err
+		}
+
+		if !waiting {
+			logger.Info("waiting-for-worker")
+			action.delegate.WaitingForWorker(logger)
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(WorkerAvailabilityPollingInterval):
+		}
+	}
+}
+
 func (action *TaskStep) imageSpec(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig) (worker.ImageSpec, error) {
 	imageSpec := worker.ImageSpec{
 		Privileged: bool(action.privileged),
@@ -398,6 +460,10 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *artifact.
 		User:      config.Run.User,
 		Dir:       action.artifactsRoot,
 		Env:       action.envForParams(config.Params),
+		Type:      db.ContainerTypeTask,
+
+		CountActiveTasks: action.strategy.ModifiesActiveTasks(),
+		MaxActiveTasks:   action.strategy.MaxActiveTasks(),
 
 		Inputs:  []worker.InputSource{},
 		Outputs: worker.OutputPaths{},
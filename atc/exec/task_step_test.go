@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -20,11 +21,13 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/tracing"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 var _ = Describe("TaskStep", func() {
@@ -204,6 +207,7 @@ var _ = Describe("TaskStep", func() {
 						CPU:    &cpu,
 						Memory: &memory,
 					},
+					Type:    db.ContainerTypeTask,
 					Dir:     "some-artifact-root",
 					Env:     []string{"SECURE=secret-task-param"},
 					Inputs:  []worker.InputSource{},
@@ -275,6 +279,7 @@ var _ = Describe("TaskStep", func() {
 							CPU:    &cpu,
 							Memory: &memory,
 						},
+						Type:    db.ContainerTypeTask,
 						Dir:     "some-artifact-root",
 						Env:     []string{"SECURE=secret-task-param"},
 						Inputs:  []worker.InputSource{},
@@ -318,6 +323,7 @@ var _ = Describe("TaskStep", func() {
 								ImageURL:   "some-image",
 								Privileged: false,
 							},
+							Type:    db.ContainerTypeTask,
 							Dir:     "some-artifact-root",
 							Env:     []string{"SOME=params"},
 							Inputs:  []worker.InputSource{},
@@ -508,6 +514,21 @@ var _ = Describe("TaskStep", func() {
 						Expect(containerSpec.TTY).To(Equal(&garden.TTYSpec{WindowSize: &garden.WindowSize{Columns: 500, Rows: 500}}))
 					})
 
+					Context("when running within a trace", func() {
+						BeforeEach(func() {
+							ctx = tracing.Propagator.Extract(ctx, propagation.MapCarrier{
+								"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+							})
+						})
+
+						It("propagates the trace context into the process", func() {
+							containerSpec, _ := fakeContainer.RunArgsForCall(0)
+							Expect(containerSpec.Env).To(Equal([]string{
+								"TRACEPARENT=00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+							}))
+						})
+					})
+
 					It("directs the process's stdout/stderr to the io config", func() {
 						Expect(fakeContainer.RunCallCount()).To(Equal(1))
 
@@ -1640,6 +1661,117 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the placement strategy modifies active tasks", func() {
+			var fakeContainer *workerfakes.FakeContainer
+
+			BeforeEach(func() {
+				fakeStrategy.ModifiesActiveTasksReturns(true)
+				fakeStrategy.MaxActiveTasksReturns(2)
+				fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+			})
+
+			It("counts the task on the worker within the strategy's limit when creating the container", func() {
+				Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+				_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+				Expect(containerSpec.CountActiveTasks).To(BeTrue())
+				Expect(containerSpec.MaxActiveTasks).To(Equal(2))
+			})
+
+			It("decreases the worker's active tasks once done", func() {
+				Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(Equal(1))
+			})
+
+			Context("when the chosen worker has reached the limit in the meantime", func() {
+				var savedInterval time.Duration
+
+				BeforeEach(func() {
+					savedInterval = exec.WorkerAvailabilityPollingInterval
+					exec.WorkerAvailabilityPollingInterval = 10 * time.Millisecond
+
+					fakeWorker.FindOrCreateContainerReturnsOnCall(0, nil, worker.ErrAllWorkersBusy)
+					fakeWorker.FindOrCreateContainerReturnsOnCall(1, fakeContainer, nil)
+				})
+
+				AfterEach(func() {
+					exec.WorkerAvailabilityPollingInterval = savedInterval
+				})
+
+				It("waits and chooses a worker again", func() {
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(2))
+					Expect(fakeDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(2))
+				})
+
+				It("only decreases the active tasks of the container it created", func() {
+					Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when finding or creating the container fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeWorker.FindOrCreateContainerReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(Equal(disaster))
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
+				})
+
+				It("does not decrease the worker's active tasks", func() {
+					Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when every worker is busy", func() {
+				var savedInterval time.Duration
+
+				BeforeEach(func() {
+					savedInterval = exec.WorkerAvailabilityPollingInterval
+					exec.WorkerAvailabilityPollingInterval = 10 * time.Millisecond
+
+					fakePool.FindOrChooseWorkerForContainerReturnsOnCall(0, nil, worker.ErrAllWorkersBusy)
+					fakePool.FindOrChooseWorkerForContainerReturnsOnCall(1, nil, worker.ErrAllWorkersBusy)
+					fakePool.FindOrChooseWorkerForContainerReturnsOnCall(2, fakeWorker, nil)
+				})
+
+				AfterEach(func() {
+					exec.WorkerAvailabilityPollingInterval = savedInterval
+				})
+
+				It("waits until a worker has capacity", func() {
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(3))
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+				})
+
+				It("tells the delegate it is waiting, once", func() {
+					Expect(fakeDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+				})
+
+				Context("when canceled while waiting", func() {
+					BeforeEach(func() {
+						fakePool.FindOrChooseWorkerForContainerStub = func(lager.Logger, db.ContainerOwner, worker.ContainerSpec, worker.WorkerSpec, worker.ContainerPlacementStrategy) (worker.Worker, error) {
+							cancel()
+							return nil, worker.ErrAllWorkersBusy
+						}
+					})
+
+					It("returns the context's error", func() {
+						Expect(stepErr).To(Equal(context.Canceled))
+					})
+
+					It("does not touch the worker", func() {
+						Expect(fakeWorker.FindOrCreateContainerCallCount()).To(BeZero())
+						Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
 		Context("when finding or choosing the worker fails", func() {
 			disaster := errors.New("nope")
 
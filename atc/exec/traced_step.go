@@ -0,0 +1,38 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TracedStep runs its step within a span, which is passed on to the step
+// through its context.
+type TracedStep struct {
+	Step
+
+	name  string
+	attrs tracing.Attrs
+}
+
+func Trace(step Step, name string, attrs tracing.Attrs) Step {
+	return TracedStep{
+		Step: step,
+
+		name:  name,
+		attrs: attrs,
+	}
+}
+
+func (step TracedStep) Run(ctx context.Context, state RunState) error {
+	ctx, span := tracing.StartSpan(ctx, step.name, step.attrs)
+	defer span.End()
+
+	err := step.Step.Run(ctx, state)
+
+	span.RecordError(err)
+	span.SetAttributes(attribute.Bool("succeeded", step.Step.Succeeded()))
+
+	return err
+}
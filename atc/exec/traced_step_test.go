@@ -0,0 +1,81 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracedStep", func() {
+	var (
+		ctx context.Context
+
+		fakeStep *execfakes.FakeStep
+		recorder *tracetest.SpanRecorder
+
+		state *execfakes.FakeRunState
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+
+		recorder = tracetest.NewSpanRecorder()
+		tracing.ConfigureTraceProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+		state = new(execfakes.FakeRunState)
+
+		step = Trace(fakeStep, "task", tracing.Attrs{"name": "some-task"})
+	})
+
+	AfterEach(func() {
+		tracing.ConfigureTraceProvider(nil)
+	})
+
+	It("runs the step within a span", func() {
+		ctx, parent := tracing.StartSpan(ctx, "build", nil)
+
+		var stepSpan trace.SpanContext
+		fakeStep.RunStub = func(ctx context.Context, state RunState) error {
+			stepSpan = trace.SpanContextFromContext(ctx)
+			return nil
+		}
+		fakeStep.SucceededReturns(true)
+
+		Expect(step.Run(ctx, state)).To(Succeed())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("task"))
+		Expect(spans[0].SpanContext()).To(Equal(stepSpan))
+		Expect(spans[0].Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(spans[0].Attributes()).To(ConsistOf(
+			attribute.String("name", "some-task"),
+			attribute.Bool("succeeded", true),
+		))
+	})
+
+	It("records the step's error", func() {
+		fakeStep.RunReturns(errors.New("nope"))
+
+		Expect(step.Run(ctx, state)).To(MatchError("nope"))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Events()).To(HaveLen(1))
+		Expect(spans[0].Events()[0].Attributes).To(ContainElement(attribute.String("exception.message", "nope")))
+	})
+})
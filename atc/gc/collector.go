@@ -2,8 +2,10 @@ package gc
 
 import (
 	"context"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/metric"
 )
 
 //go:generate counterfeiter . Collector
@@ -53,50 +55,63 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 
 	var err error
 
-	err = c.buildCollector.Run(ctx)
+	err = c.run(ctx, "build", c.buildCollector)
 	if err != nil {
 		logger.Error("failed-to-run-build-collector", err)
 	}
 
-	err = c.workerCollector.Run(ctx)
+	err = c.run(ctx, "worker", c.workerCollector)
 	if err != nil {
 		logger.Error("failed-to-run-worker-collector", err)
 	}
 
-	err = c.resourceCacheUseCollector.Run(ctx)
+	err = c.run(ctx, "resource-cache-use", c.resourceCacheUseCollector)
 	if err != nil {
 		logger.Error("failed-to-run-resource-cache-use-collector", err)
 	}
 
-	err = c.resourceConfigCollector.Run(ctx)
+	err = c.run(ctx, "resource-config", c.resourceConfigCollector)
 	if err != nil {
 		logger.Error("failed-to-run-resource-config-collector", err)
 	}
 
-	err = c.resourceCacheCollector.Run(ctx)
+	err = c.run(ctx, "resource-cache", c.resourceCacheCollector)
 	if err != nil {
 		logger.Error("failed-to-run-resource-cache-collector", err)
 	}
 
-	err = c.resourceConfigCheckSessionCollector.Run(ctx)
+	err = c.run(ctx, "resource-config-check-session", c.resourceConfigCheckSessionCollector)
 	if err != nil {
 		logger.Error("resource-config-check-session-collector", err)
 	}
 
-	err = c.artifactCollector.Run(ctx)
+	err = c.run(ctx, "artifact", c.artifactCollector)
 	if err != nil {
 		logger.Error("artifact-collector", err)
 	}
 
-	err = c.containerCollector.Run(ctx)
+	err = c.run(ctx, "container", c.containerCollector)
 	if err != nil {
 		logger.Error("container-collector", err)
 	}
 
-	err = c.volumeCollector.Run(ctx)
+	err = c.run(ctx, "volume", c.volumeCollector)
 	if err != nil {
 		logger.Error("volume-collector", err)
 	}
 
 	return nil
 }
+
+func (c *aggregateCollector) run(ctx context.Context, name string, collector Collector) error {
+	start := time.Now()
+
+	err := collector.Run(ctx)
+
+	metric.GarbageCollectionCollectorDuration{
+		Collector: name,
+		Duration:  time.Since(start),
+	}.Emit(lagerctx.FromContext(ctx))
+
+	return err
+}
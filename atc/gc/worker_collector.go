@@ -65,6 +65,16 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 		logger.Info("marked-workers-as-landed", lager.Data{"count": len(affected), "workers": affected})
 	}
 
+	affected, err = wc.workerLifecycle.ResetActiveTasks()
+	if err != nil {
+		logger.Error("failed-to-reset-active-tasks", err)
+		return err
+	}
+
+	if len(affected) > 0 {
+		logger.Info("reset-active-tasks", lager.Data{"count": len(affected), "workers": affected})
+	}
+
 	workerStateByName, err := wc.workerLifecycle.GetWorkerStateByName()
 
 	if err != nil {
@@ -43,3 +43,54 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+// JobExplanation describes whether a job would have a build scheduled, and
+// if not, what is preventing it.
+type JobExplanation struct {
+	Job string `json:"job"`
+
+	PausedPipeline bool `json:"paused_pipeline,omitempty"`
+	PausedJob      bool `json:"paused_job,omitempty"`
+
+	MaxInFlight        int  `json:"max_in_flight,omitempty"`
+	RunningBuilds      int  `json:"running_builds"`
+	MaxInFlightReached bool `json:"max_in_flight_reached,omitempty"`
+	PendingBuilds      int  `json:"pending_builds"`
+
+	// InputsSatisfied is whether there are versions which satisfy every
+	// input together.
+	InputsSatisfied bool `json:"inputs_satisfied"`
+
+	// WouldTrigger is whether any input with trigger: true has a version
+	// which the job has not run with yet.
+	WouldTrigger bool `json:"would_trigger"`
+
+	Inputs []JobInputExplanation `json:"inputs"`
+}
+
+type JobInputExplanation struct {
+	Name     string   `json:"name"`
+	Resource string   `json:"resource"`
+	Trigger  bool     `json:"trigger"`
+	Passed   []string `json:"passed,omitempty"`
+	Every    bool     `json:"every,omitempty"`
+	Pinned   Version  `json:"pinned,omitempty"`
+
+	// Version is the version the input would be satisfied by, and New is
+	// whether the job has not run with it yet.
+	Version Version `json:"version,omitempty"`
+	New     bool    `json:"new,omitempty"`
+
+	// Candidates are the newest of the versions which could satisfy the
+	// input, out of TotalCandidates.
+	Candidates      []InputCandidateExplanation `json:"candidates"`
+	TotalCandidates int                         `json:"total_candidates"`
+}
+
+type InputCandidateExplanation struct {
+	Version Version `json:"version"`
+
+	// NotPassed lists the jobs in the input's passed constraints which have
+	// not had a successful build with the version.
+	NotPassed []string `json:"not_passed,omitempty"`
+}
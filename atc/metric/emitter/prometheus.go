@@ -25,18 +25,28 @@ type PrometheusEmitter struct {
 	buildsStarted     prometheus.Counter
 	buildsSucceeded   prometheus.Counter
 
+	credentialCacheHits   prometheus.Counter
+	credentialCacheMisses prometheus.Counter
+
 	dbConnections  *prometheus.GaugeVec
 	dbQueriesTotal prometheus.Counter
 
 	errorLogs *prometheus.CounterVec
 
+	gcCollectorDuration *prometheus.HistogramVec
+
 	httpRequestsDuration *prometheus.HistogramVec
 
-	locksHeld *prometheus.GaugeVec
+	locksHeld     *prometheus.GaugeVec
+	locksAcquired *prometheus.CounterVec
 
 	pipelineScheduled *prometheus.CounterVec
 
-	resourceChecksVec *prometheus.CounterVec
+	resourceChecksVec        *prometheus.CounterVec
+	resourceCheckFailuresVec *prometheus.CounterVec
+	resourceCheckDurationVec *prometheus.HistogramVec
+	resourceChecksQueued     prometheus.Gauge
+	resourceChecksRunning    prometheus.Gauge
 
 	schedulingFullDuration    *prometheus.CounterVec
 	schedulingLoadingDuration *prometheus.CounterVec
@@ -87,6 +97,14 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	}, []string{"type"})
 	prometheus.MustRegister(locksHeld)
 
+	locksAcquired := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "locks",
+		Name:      "acquired_total",
+		Help:      "Total number of database locks acquired",
+	}, []string{"type"})
+	prometheus.MustRegister(locksAcquired)
+
 	// build metrics
 	buildsStarted := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
@@ -155,7 +173,7 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 			Help:      "Build time in seconds",
 			Buckets:   []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000},
 		},
-		[]string{"team", "pipeline"},
+		[]string{"team", "pipeline", "job"},
 	)
 	prometheus.MustRegister(buildDurationsVec)
 
@@ -239,6 +257,22 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(pipelineScheduled)
 
+	credentialCacheHits := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "credentials",
+		Name:      "cache_hits_total",
+		Help:      "Total number of credential lookups served from the cache",
+	})
+	prometheus.MustRegister(credentialCacheHits)
+
+	credentialCacheMisses := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "credentials",
+		Name:      "cache_misses_total",
+		Help:      "Total number of credential lookups not served from the cache",
+	})
+	prometheus.MustRegister(credentialCacheMisses)
+
 	dbQueriesTotal := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
 		Subsystem: "db",
@@ -269,6 +303,57 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(resourceChecksVec)
 
+	resourceCheckFailuresVec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "check_failures_total",
+			Help:      "Counts the number of failed checks per resource",
+		},
+		[]string{"team", "pipeline", "resource"},
+	)
+	prometheus.MustRegister(resourceCheckFailuresVec)
+
+	resourceCheckDurationVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "check_duration_seconds",
+			Help:      "Resource check time in seconds",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+		[]string{"team", "pipeline", "resource"},
+	)
+	prometheus.MustRegister(resourceCheckDurationVec)
+
+	resourceChecksQueued := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "concourse",
+		Subsystem: "resource",
+		Name:      "checks_queued",
+		Help:      "Number of periodic checks waiting to be started due to the check rate limit",
+	})
+	prometheus.MustRegister(resourceChecksQueued)
+
+	resourceChecksRunning := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "concourse",
+		Subsystem: "resource",
+		Name:      "checks_running",
+		Help:      "Number of periodic checks in progress",
+	})
+	prometheus.MustRegister(resourceChecksRunning)
+
+	// gc metrics
+	gcCollectorDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "gc",
+			Name:      "collector_duration_seconds",
+			Help:      "Time taken by each garbage collector in seconds",
+		},
+		[]string{"collector"},
+	)
+	prometheus.MustRegister(gcCollectorDuration)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -286,18 +371,28 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		buildsStarted:     buildsStarted,
 		buildsSucceeded:   buildsSucceeded,
 
+		credentialCacheHits:   credentialCacheHits,
+		credentialCacheMisses: credentialCacheMisses,
+
 		dbConnections:  dbConnections,
 		dbQueriesTotal: dbQueriesTotal,
 
 		errorLogs: errorLogs,
 
+		gcCollectorDuration: gcCollectorDuration,
+
 		httpRequestsDuration: httpRequestsDuration,
 
-		locksHeld: locksHeld,
+		locksHeld:     locksHeld,
+		locksAcquired: locksAcquired,
 
 		pipelineScheduled: pipelineScheduled,
 
-		resourceChecksVec: resourceChecksVec,
+		resourceChecksVec:        resourceChecksVec,
+		resourceCheckFailuresVec: resourceCheckFailuresVec,
+		resourceCheckDurationVec: resourceCheckDurationVec,
+		resourceChecksQueued:     resourceChecksQueued,
+		resourceChecksRunning:    resourceChecksRunning,
 
 		schedulingFullDuration:    schedulingFullDuration,
 		schedulingLoadingDuration: schedulingLoadingDuration,
@@ -348,8 +443,20 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "database connections":
 		emitter.databaseMetrics(logger, event)
+	case "credential cache hits":
+		emitter.credentialCacheMetrics(logger, event)
+	case "credential cache misses":
+		emitter.credentialCacheMetrics(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "resource check duration (ms)":
+		emitter.resourceCheckDurationMetric(logger, event)
+	case "checks queued":
+		emitter.checksMetrics(logger, event)
+	case "checks running":
+		emitter.checksMetrics(logger, event)
+	case "GC collector duration (ms)":
+		emitter.gcCollectorDurationMetric(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -364,6 +471,7 @@ func (emitter *PrometheusEmitter) lock(logger lager.Logger, event metric.Event)
 
 	if event.Value == 1 {
 		emitter.locksHeld.WithLabelValues(lockType).Inc()
+		emitter.locksAcquired.WithLabelValues(lockType).Inc()
 	} else {
 		emitter.locksHeld.WithLabelValues(lockType).Dec()
 	}
@@ -434,7 +542,7 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 	}
 	// seconds are the standard prometheus base unit for time
 	duration = duration / 1000
-	emitter.buildDurationsVec.WithLabelValues(team, pipeline).Observe(duration)
+	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
 }
 
 func (emitter *PrometheusEmitter) workerContainersMetric(logger lager.Logger, event metric.Event) {
@@ -570,6 +678,38 @@ func (emitter *PrometheusEmitter) databaseMetrics(logger lager.Logger, event met
 
 }
 
+func (emitter *PrometheusEmitter) credentialCacheMetrics(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("credential-cache-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+
+	switch event.Name {
+	case "credential cache hits":
+		emitter.credentialCacheHits.Add(float64(value))
+	case "credential cache misses":
+		emitter.credentialCacheMisses.Add(float64(value))
+	default:
+	}
+}
+
+func (emitter *PrometheusEmitter) checksMetrics(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("checks-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+
+	switch event.Name {
+	case "checks queued":
+		emitter.resourceChecksQueued.Set(float64(value))
+	case "checks running":
+		emitter.resourceChecksRunning.Set(float64(value))
+	default:
+	}
+}
+
 func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metric.Event) {
 	pipeline, exists := event.Attributes["pipeline"]
 	if !exists {
@@ -583,6 +723,62 @@ func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metr
 	}
 
 	emitter.resourceChecksVec.WithLabelValues(team, pipeline).Inc()
+
+	if event.State != metric.EventStateOK {
+		resource, exists := event.Attributes["resource"]
+		if !exists {
+			logger.Error("failed-to-find-resource-in-event", fmt.Errorf("expected resource to exist in event.Attributes"))
+			return
+		}
+
+		emitter.resourceCheckFailuresVec.WithLabelValues(team, pipeline, resource).Inc()
+	}
+}
+
+func (emitter *PrometheusEmitter) resourceCheckDurationMetric(logger lager.Logger, event metric.Event) {
+	team, exists := event.Attributes["team"]
+	if !exists {
+		logger.Error("failed-to-find-team-in-event", fmt.Errorf("expected team to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+
+	resource, exists := event.Attributes["resource"]
+	if !exists {
+		logger.Error("failed-to-find-resource-in-event", fmt.Errorf("expected resource to exist in event.Attributes"))
+		return
+	}
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("resource-check-duration-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	// concourse_resource_check_duration_seconds
+	emitter.resourceCheckDurationVec.WithLabelValues(team, pipeline, resource).Observe(duration / 1000)
+}
+
+func (emitter *PrometheusEmitter) gcCollectorDurationMetric(logger lager.Logger, event metric.Event) {
+	collector, exists := event.Attributes["collector"]
+	if !exists {
+		logger.Error("failed-to-find-collector-in-event", fmt.Errorf("expected collector to exist in event.Attributes"))
+		return
+	}
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("gc-collector-duration-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	// concourse_gc_collector_duration_seconds
+	emitter.gcCollectorDuration.WithLabelValues(collector).Observe(duration / 1000)
 }
 
 // updateLastSeen tracks for each worker when it last received a metric event.
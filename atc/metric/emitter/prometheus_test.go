@@ -0,0 +1,142 @@
+package emitter_test
+
+import (
+	"net/http/httptest"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/emitter"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	prometheusEmitter     metric.Emitter
+	prometheusEmitterOnce sync.Once
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var logger *lagertest.TestLogger
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		// the emitter registers its metrics globally, so it can only be
+		// constructed once
+		prometheusEmitterOnce.Do(func() {
+			config := &emitter.PrometheusConfig{
+				BindIP:   "127.0.0.1",
+				BindPort: "0",
+			}
+
+			var err error
+			prometheusEmitter, err = config.NewEmitter()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("observes the duration of finished builds for each job", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "build finished",
+			Value: float64(90000),
+			Attributes: map[string]string{
+				"team_name":    "some-team",
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_status": "succeeded",
+			},
+		})
+
+		metrics := scrapePrometheus()
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_bucket{job="some-job",pipeline="some-pipeline",team="some-team",le="60"} 0` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_bucket{job="some-job",pipeline="some-pipeline",team="some-team",le="180"} 1` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_sum{job="some-job",pipeline="some-pipeline",team="some-team"} 90` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_count{job="some-job",pipeline="some-pipeline",team="some-team"} 1` + "\n"))
+	})
+
+	It("counts failed checks for each resource", func() {
+		for _, check := range []struct {
+			resource string
+			state    metric.EventState
+		}{
+			{"failing-resource", metric.EventStateWarning},
+			{"failing-resource", metric.EventStateWarning},
+			{"passing-resource", metric.EventStateOK},
+		} {
+			prometheusEmitter.Emit(logger, metric.Event{
+				Name:  "resource checked",
+				Value: 1,
+				State: check.state,
+				Attributes: map[string]string{
+					"team":     "some-team",
+					"pipeline": "some-pipeline",
+					"resource": check.resource,
+				},
+			})
+		}
+
+		metrics := scrapePrometheus()
+		Expect(metrics).To(ContainSubstring(`concourse_resource_check_failures_total{pipeline="some-pipeline",resource="failing-resource",team="some-team"} 2` + "\n"))
+		Expect(metrics).NotTo(ContainSubstring(`resource="passing-resource"`))
+		Expect(metrics).To(ContainSubstring(`concourse_resource_checks_total{pipeline="some-pipeline",team="some-team"} 3` + "\n"))
+	})
+
+	It("observes the duration of checks for each resource", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "resource check duration (ms)",
+			Value: float64(2500),
+			Attributes: map[string]string{
+				"team":     "some-team",
+				"pipeline": "some-pipeline",
+				"resource": "timed-resource",
+			},
+		})
+
+		metrics := scrapePrometheus()
+		Expect(metrics).To(ContainSubstring(`concourse_resource_check_duration_seconds_bucket{pipeline="some-pipeline",resource="timed-resource",team="some-team",le="1"} 0` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_resource_check_duration_seconds_bucket{pipeline="some-pipeline",resource="timed-resource",team="some-team",le="5"} 1` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_resource_check_duration_seconds_sum{pipeline="some-pipeline",resource="timed-resource",team="some-team"} 2.5` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_resource_check_duration_seconds_count{pipeline="some-pipeline",resource="timed-resource",team="some-team"} 1` + "\n"))
+	})
+
+	It("counts the locks acquired of each type", func() {
+		for _, held := range []int{1, 0, 1} {
+			prometheusEmitter.Emit(logger, metric.Event{
+				Name:  "lock held",
+				Value: held,
+				Attributes: map[string]string{
+					"type": "SomeLock",
+				},
+			})
+		}
+
+		metrics := scrapePrometheus()
+		Expect(metrics).To(ContainSubstring(`concourse_locks_acquired_total{type="SomeLock"} 2` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_locks_held{type="SomeLock"} 1` + "\n"))
+	})
+
+	It("observes the duration of each garbage collector", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "GC collector duration (ms)",
+			Value: float64(1500),
+			Attributes: map[string]string{
+				"collector": "some-collector",
+			},
+		})
+
+		metrics := scrapePrometheus()
+		Expect(metrics).To(ContainSubstring(`concourse_gc_collector_duration_seconds_sum{collector="some-collector"} 1.5` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_gc_collector_duration_seconds_count{collector="some-collector"} 1` + "\n"))
+	})
+})
+
+func scrapePrometheus() string {
+	recorder := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	Expect(recorder.Code).To(Equal(200))
+
+	return recorder.Body.String()
+}
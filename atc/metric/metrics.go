@@ -22,6 +22,12 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var CredentialCacheHits = Meter(0)
+var CredentialCacheMisses = Meter(0)
+
+var ChecksQueued = &Gauge{}
+var ChecksRunning = &Gauge{}
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -299,6 +305,25 @@ func (event GarbageCollectionContainerCollectorJobDropped) Emit(logger lager.Log
 	)
 }
 
+type GarbageCollectionCollectorDuration struct {
+	Collector string
+	Duration  time.Duration
+}
+
+func (event GarbageCollectionCollectorDuration) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("gc-collector-duration"),
+		Event{
+			Name:  "GC collector duration (ms)",
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"collector": event.Collector,
+			},
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
@@ -417,6 +442,7 @@ type ResourceCheck struct {
 	ResourceName string
 	TeamName     string
 	Success      bool
+	Duration     time.Duration
 }
 
 func (event ResourceCheck) Emit(logger lager.Logger) {
@@ -424,17 +450,30 @@ func (event ResourceCheck) Emit(logger lager.Logger) {
 	if !event.Success {
 		state = EventStateWarning
 	}
+
+	attributes := map[string]string{
+		"pipeline": event.PipelineName,
+		"resource": event.ResourceName,
+		"team":     event.TeamName,
+	}
+
 	emit(
 		logger.Session("resource-check"),
 		Event{
-			Name:  "resource checked",
-			Value: 1,
-			State: state,
-			Attributes: map[string]string{
-				"pipeline": event.PipelineName,
-				"resource": event.ResourceName,
-				"team":     event.TeamName,
-			},
+			Name:       "resource checked",
+			Value:      1,
+			State:      state,
+			Attributes: attributes,
+		},
+	)
+
+	emit(
+		logger.Session("resource-check-duration"),
+		Event{
+			Name:       "resource check duration (ms)",
+			Value:      ms(event.Duration),
+			State:      state,
+			Attributes: attributes,
 		},
 	)
 }
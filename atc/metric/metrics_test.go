@@ -0,0 +1,102 @@
+package metric_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	var emitter *metricfakes.FakeEmitter
+
+	BeforeEach(func() {
+		emitterFactory := &metricfakes.FakeEmitterFactory{}
+		emitter = &metricfakes.FakeEmitter{}
+
+		metric.RegisterEmitter(emitterFactory)
+		emitterFactory.IsConfiguredReturns(true)
+		emitterFactory.NewEmitterReturns(emitter, nil)
+		metric.Initialize(nil, "test", map[string]string{})
+	})
+
+	AfterEach(func() {
+		metric.Deinitialize(nil)
+	})
+
+	emitted := func() []metric.Event {
+		events := []metric.Event{}
+		for i := 0; i < emitter.EmitCallCount(); i++ {
+			_, event := emitter.EmitArgsForCall(i)
+			events = append(events, event)
+		}
+		return events
+	}
+
+	Describe("ResourceCheck", func() {
+		var success bool
+
+		BeforeEach(func() {
+			success = true
+		})
+
+		JustBeforeEach(func() {
+			metric.ResourceCheck{
+				PipelineName: "some-pipeline",
+				ResourceName: "some-resource",
+				TeamName:     "some-team",
+				Success:      success,
+				Duration:     1500 * time.Millisecond,
+			}.Emit(lagertest.NewTestLogger("test"))
+
+			Eventually(emitter.EmitCallCount).Should(Equal(2))
+		})
+
+		It("emits the check and its duration, labelled by resource", func() {
+			events := emitted()
+
+			Expect(events[0].Name).To(Equal("resource checked"))
+			Expect(events[0].Value).To(Equal(1))
+			Expect(events[0].State).To(Equal(metric.EventStateOK))
+			Expect(events[0].Attributes).To(HaveKeyWithValue("resource", "some-resource"))
+
+			Expect(events[1].Name).To(Equal("resource check duration (ms)"))
+			Expect(events[1].Value).To(Equal(1500.0))
+			Expect(events[1].Attributes).To(HaveKeyWithValue("team", "some-team"))
+			Expect(events[1].Attributes).To(HaveKeyWithValue("pipeline", "some-pipeline"))
+			Expect(events[1].Attributes).To(HaveKeyWithValue("resource", "some-resource"))
+		})
+
+		Context("when the check failed", func() {
+			BeforeEach(func() {
+				success = false
+			})
+
+			It("emits the events with a warning state", func() {
+				for _, event := range emitted() {
+					Expect(event.State).To(Equal(metric.EventStateWarning))
+				}
+			})
+		})
+	})
+
+	Describe("GarbageCollectionCollectorDuration", func() {
+		It("emits the duration labelled by collector", func() {
+			metric.GarbageCollectionCollectorDuration{
+				Collector: "volume",
+				Duration:  250 * time.Millisecond,
+			}.Emit(lagertest.NewTestLogger("test"))
+
+			Eventually(emitter.EmitCallCount).Should(Equal(1))
+
+			events := emitted()
+			Expect(events[0].Name).To(Equal("GC collector duration (ms)"))
+			Expect(events[0].Value).To(Equal(250.0))
+			Expect(events[0].Attributes).To(HaveKeyWithValue("collector", "volume"))
+		})
+	})
+})
@@ -107,6 +107,42 @@ func tick(logger lager.Logger) {
 		},
 	)
 
+	emit(
+		logger.Session("credential-cache-hits"),
+		Event{
+			Name:  "credential cache hits",
+			Value: CredentialCacheHits.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("credential-cache-misses"),
+		Event{
+			Name:  "credential cache misses",
+			Value: CredentialCacheMisses.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("checks-queued"),
+		Event{
+			Name:  "checks queued",
+			Value: ChecksQueued.Max(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("checks-running"),
+		Event{
+			Name:  "checks running",
+			Value: ChecksRunning.Max(),
+			State: EventStateOK,
+		},
+	)
+
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
@@ -80,4 +80,63 @@ var _ = Describe("Periodic emission of metrics", func() {
 			),
 		)
 	})
+
+	It("emits credential cache hits and misses", func() {
+		metric.CredentialCacheHits.IncDelta(3)
+		metric.CredentialCacheMisses.Inc()
+
+		Eventually(emitter.Invocations).Should(HaveKeyWithValue("Emit",
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("credential cache hits"),
+						"Value": Equal(3),
+					}),
+				),
+			),
+		))
+
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("credential cache misses"),
+						"Value": Equal(1),
+					}),
+				),
+			),
+		)
+	})
+
+	It("emits the most checks queued and running since the last emission", func() {
+		metric.ChecksQueued.Inc()
+		metric.ChecksQueued.Inc()
+		metric.ChecksQueued.Dec()
+		metric.ChecksRunning.Inc()
+
+		defer metric.ChecksQueued.Dec()
+		defer metric.ChecksRunning.Dec()
+
+		Eventually(emitter.Invocations).Should(HaveKeyWithValue("Emit",
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("checks queued"),
+						"Value": Equal(2),
+					}),
+				),
+			),
+		))
+
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("checks running"),
+						"Value": Equal(1),
+					}),
+				),
+			),
+		)
+	})
 })
@@ -32,6 +32,8 @@ type radarSchedulerFactory struct {
 	resourceCheckingInterval     time.Duration
 	engine                       engine.Engine
 	strategy                     worker.ContainerPlacementStrategy
+	checkRateLimiter             radar.RateLimiter
+	checkJitter                  time.Duration
 }
 
 func NewRadarSchedulerFactory(
@@ -42,6 +44,8 @@ func NewRadarSchedulerFactory(
 	resourceCheckingInterval time.Duration,
 	engine engine.Engine,
 	strategy worker.ContainerPlacementStrategy,
+	checkRateLimiter radar.RateLimiter,
+	checkJitter time.Duration,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		pool:                         pool,
@@ -51,6 +55,8 @@ func NewRadarSchedulerFactory(
 		resourceCheckingInterval:     resourceCheckingInterval,
 		engine:                       engine,
 		strategy:                     strategy,
+		checkRateLimiter:             checkRateLimiter,
+		checkJitter:                  checkJitter,
 	}
 }
 
@@ -67,6 +73,8 @@ func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline,
 		variables,
 		rsf.strategy,
 		notifications,
+		rsf.checkRateLimiter,
+		rsf.checkJitter,
 	)
 }
 
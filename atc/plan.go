@@ -4,19 +4,24 @@ type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
-	Aggregate *AggregatePlan `json:"aggregate,omitempty"`
-	Do        *DoPlan        `json:"do,omitempty"`
-	Get       *GetPlan       `json:"get,omitempty"`
-	Put       *PutPlan       `json:"put,omitempty"`
-	Task      *TaskPlan      `json:"task,omitempty"`
-	OnAbort   *OnAbortPlan   `json:"on_abort,omitempty"`
-	OnError   *OnErrorPlan   `json:"on_error,omitempty"`
-	Ensure    *EnsurePlan    `json:"ensure,omitempty"`
-	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
-	OnFailure *OnFailurePlan `json:"on_failure,omitempty"`
-	Try       *TryPlan       `json:"try,omitempty"`
-	Timeout   *TimeoutPlan   `json:"timeout,omitempty"`
-	Retry     *RetryPlan     `json:"retry,omitempty"`
+	Aggregate  *AggregatePlan  `json:"aggregate,omitempty"`
+	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
+	Do         *DoPlan         `json:"do,omitempty"`
+	Get        *GetPlan        `json:"get,omitempty"`
+	Put        *PutPlan        `json:"put,omitempty"`
+	Task       *TaskPlan       `json:"task,omitempty"`
+	OnAbort    *OnAbortPlan    `json:"on_abort,omitempty"`
+	OnError    *OnErrorPlan    `json:"on_error,omitempty"`
+	Ensure     *EnsurePlan     `json:"ensure,omitempty"`
+	OnSuccess  *OnSuccessPlan  `json:"on_success,omitempty"`
+	OnFailure  *OnFailurePlan  `json:"on_failure,omitempty"`
+	Try        *TryPlan        `json:"try,omitempty"`
+	Timeout    *TimeoutPlan    `json:"timeout,omitempty"`
+	Retry      *RetryPlan      `json:"retry,omitempty"`
+	Across     *AcrossPlan     `json:"across,omitempty"`
+
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -28,6 +33,79 @@ type Plan struct {
 
 type PlanID string
 
+// Each calls the given function for the plan and each of its nested plans,
+// depth-first.
+func (plan *Plan) Each(f func(*Plan)) {
+	f(plan)
+
+	if plan.Aggregate != nil {
+		for i := range *plan.Aggregate {
+			(*plan.Aggregate)[i].Each(f)
+		}
+	}
+
+	if plan.InParallel != nil {
+		for i := range plan.InParallel.Steps {
+			plan.InParallel.Steps[i].Each(f)
+		}
+	}
+
+	if plan.Do != nil {
+		for i := range *plan.Do {
+			(*plan.Do)[i].Each(f)
+		}
+	}
+
+	if plan.OnAbort != nil {
+		plan.OnAbort.Step.Each(f)
+		plan.OnAbort.Next.Each(f)
+	}
+
+	if plan.OnError != nil {
+		plan.OnError.Step.Each(f)
+		plan.OnError.Next.Each(f)
+	}
+
+	if plan.Ensure != nil {
+		plan.Ensure.Step.Each(f)
+		plan.Ensure.Next.Each(f)
+	}
+
+	if plan.OnSuccess != nil {
+		plan.OnSuccess.Step.Each(f)
+		plan.OnSuccess.Next.Each(f)
+	}
+
+	if plan.OnFailure != nil {
+		plan.OnFailure.Step.Each(f)
+		plan.OnFailure.Next.Each(f)
+	}
+
+	if plan.Try != nil {
+		plan.Try.Step.Each(f)
+	}
+
+	if plan.Timeout != nil {
+		plan.Timeout.Step.Each(f)
+	}
+
+	if plan.Retry != nil {
+		for i := range *plan.Retry {
+			(*plan.Retry)[i].Each(f)
+		}
+	}
+
+	if plan.Across != nil {
+		for i := range plan.Across.Steps {
+			plan.Across.Steps[i].Step.Each(f)
+		}
+
+		if plan.Across.Step != nil {
+			plan.Across.Step.Each(f)
+		}
+	}
+}
+
 type ArtifactInputPlan struct {
 	ArtifactID int    `json:"artifact_id"`
 	Name       string `json:"name"`
@@ -73,6 +151,39 @@ type TryPlan struct {
 
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
+// An AcrossPlan runs a step for each combination of its vars' values. If all
+// of the values are known up front, the step is planned once for each
+// combination. Otherwise, the step is planned once and is expanded at runtime
+// once the values have been loaded.
+type AcrossPlan struct {
+	Vars     []AcrossVar     `json:"vars"`
+	Steps    []VarScopedPlan `json:"steps,omitempty"`
+	Step     *Plan           `json:"step,omitempty"`
+	FailFast bool            `json:"fail_fast,omitempty"`
+}
+
+// An AcrossVar is either given a list of values or the name of a local var to
+// load them from.
+type AcrossVar struct {
+	Var         string        `json:"name"`
+	Values      []interface{} `json:"values,omitempty"`
+	ValuesFrom  string        `json:"values_from,omitempty"`
+	MaxInFlight int           `json:"max_in_flight,omitempty"`
+}
+
+// A VarScopedPlan is a step planned for one combination of an AcrossPlan's
+// values, in the same order as the vars.
+type VarScopedPlan struct {
+	Step   Plan          `json:"step"`
+	Values []interface{} `json:"values"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -120,6 +231,29 @@ type TaskPlan struct {
 
 type RetryPlan []Plan
 
+type SetPipelinePlan struct {
+	Name     string   `json:"name"`
+	File     string   `json:"file"`
+	Vars     Params   `json:"vars,omitempty"`
+	VarFiles []string `json:"var_files,omitempty"`
+}
+
+// Formats which may be given for a LoadVarPlan. If no format is given, it is
+// detected from the file extension, falling back on LoadVarFormatTrim.
+const (
+	LoadVarFormatRaw  = "raw"
+	LoadVarFormatTrim = "trim"
+	LoadVarFormatJSON = "json"
+	LoadVarFormatYAML = "yaml"
+	LoadVarFormatYML  = "yml"
+)
+
+type LoadVarPlan struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Format string `json:"format,omitempty"`
+}
+
 type DependentGetPlan struct {
 	Type     string `json:"type"`
 	Name     string `json:"name,omitempty"`
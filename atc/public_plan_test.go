@@ -321,6 +321,31 @@ var _ = Describe("Plan", func() {
 							},
 						},
 					},
+
+					atc.Plan{
+						ID: "36",
+						Across: &atc.AcrossPlan{
+							Vars: []atc.AcrossVar{
+								{Var: "v1", Values: []interface{}{"a"}, MaxInFlight: 2},
+							},
+							Steps: []atc.VarScopedPlan{
+								{
+									Step: atc.Plan{
+										ID: "37",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: map[string]string{"some": "secret"},
+											},
+										},
+									},
+									Values: []interface{}{"a"},
+								},
+							},
+							FailFast: true,
+						},
+					},
 				},
 			}
 
@@ -556,6 +581,31 @@ var _ = Describe("Plan", func() {
           }
         }
       }
+		},
+		{
+			"id": "36",
+			"across": {
+				"vars": [
+					{
+						"name": "v1",
+						"values": ["a"],
+						"max_in_flight": 2
+					}
+				],
+				"steps": [
+					{
+						"step": {
+							"id": "37",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						},
+						"values": ["a"]
+					}
+				],
+				"fail_fast": true
+			}
 		}
   ]
 }
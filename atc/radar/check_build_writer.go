@@ -0,0 +1,55 @@
+package radar
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+const checkOriginID event.OriginID = "check"
+
+func newCheckBuildWriter(build db.Build, clock clock.Clock) io.Writer {
+	return &checkBuildWriter{
+		build: build,
+		clock: clock,
+	}
+}
+
+// checkBuildWriter saves the check script's stderr as log events of the
+// check build.
+type checkBuildWriter struct {
+	build db.Build
+
+	dangling []byte
+
+	clock clock.Clock
+}
+
+func (writer *checkBuildWriter) Write(data []byte) (int, error) {
+	text := append(writer.dangling, data...)
+
+	checkEncoding, _ := utf8.DecodeLastRune(text)
+	if checkEncoding == utf8.RuneError {
+		writer.dangling = text
+		return len(data), nil
+	}
+
+	writer.dangling = nil
+
+	err := writer.build.SaveEvent(event.Log{
+		Time:    writer.clock.Now().Unix(),
+		Payload: string(text),
+		Origin: event.Origin{
+			ID:     checkOriginID,
+			Source: event.OriginSourceStderr,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
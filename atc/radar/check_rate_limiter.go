@@ -0,0 +1,144 @@
+package radar
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/time/rate"
+)
+
+//go:generate counterfeiter . RateLimiter
+
+type RateLimiter interface {
+	Wait(context.Context) error
+}
+
+// CheckRateLimiter limits the rate at which periodic checks are started.
+//
+// A negative static rate removes the limit entirely. Otherwise the rate is a
+// positive static rate, or is derived from the number of resources and
+// resource types across the cluster such that each of them can be checked
+// once per checking interval.
+//
+// The rate applies across the cluster, so it is shared between the ATCs
+// which are checking resources. Each ATC records that it is checking in the
+// database, and the rate is refreshed as ATCs, resources and resource types
+// come and go.
+type CheckRateLimiter struct {
+	atcID           string
+	staticRate      rate.Limit
+	checkInterval   time.Duration
+	resourceFactory db.ResourceFactory
+	refreshInterval time.Duration
+	clock           clock.Clock
+
+	limiter     *rate.Limiter
+	refreshedAt time.Time
+	refreshLock sync.Mutex
+}
+
+func NewCheckRateLimiter(
+	atcID string,
+	staticRate rate.Limit,
+	checkInterval time.Duration,
+	resourceFactory db.ResourceFactory,
+	refreshInterval time.Duration,
+	clock clock.Clock,
+) *CheckRateLimiter {
+	limit := staticRate
+	if staticRate < 0 {
+		limit = rate.Inf
+	}
+
+	return &CheckRateLimiter{
+		atcID:           atcID,
+		staticRate:      staticRate,
+		checkInterval:   checkInterval,
+		resourceFactory: resourceFactory,
+		refreshInterval: refreshInterval,
+		clock:           clock,
+
+		limiter: rate.NewLimiter(limit, 1),
+	}
+}
+
+// Wait blocks until a check may be started or the context is done.
+func (limiter *CheckRateLimiter) Wait(ctx context.Context) error {
+	if limiter.staticRate >= 0 {
+		err := limiter.refreshLimit()
+		if err != nil {
+			return err
+		}
+	}
+
+	return limiter.currentLimiter().Wait(ctx)
+}
+
+// Limit returns the rate, in checks per second, currently being enforced.
+func (limiter *CheckRateLimiter) Limit() rate.Limit {
+	return limiter.currentLimiter().Limit()
+}
+
+// currentLimiter returns the limiter under the lock, as the first refresh
+// replaces it. The lock isn't held while waiting on it, so that a refresh
+// isn't held up by a waiting check.
+func (limiter *CheckRateLimiter) currentLimiter() *rate.Limiter {
+	limiter.refreshLock.Lock()
+	defer limiter.refreshLock.Unlock()
+
+	return limiter.limiter
+}
+
+func (limiter *CheckRateLimiter) refreshLimit() error {
+	limiter.refreshLock.Lock()
+	defer limiter.refreshLock.Unlock()
+
+	now := limiter.clock.Now()
+	if !limiter.refreshedAt.IsZero() && now.Sub(limiter.refreshedAt) < limiter.refreshInterval {
+		return nil
+	}
+
+	// an ATC which is checking refreshes once per refresh interval, so allow
+	// for a few missed refreshes before it's forgotten
+	atcs, err := limiter.resourceFactory.HeartbeatChecker(limiter.atcID, 3*limiter.refreshInterval)
+	if err != nil {
+		return err
+	}
+
+	if atcs < 1 {
+		atcs = 1
+	}
+
+	limit := limiter.staticRate
+	if limit == 0 {
+		count, err := limiter.resourceFactory.CheckableCount()
+		if err != nil {
+			return err
+		}
+
+		// always allow some checks, so that the first resource to be
+		// configured isn't starved until the next refresh
+		if count == 0 {
+			count = 1
+		}
+
+		limit = rate.Limit(float64(count) / limiter.checkInterval.Seconds())
+	}
+
+	limit /= rate.Limit(atcs)
+
+	// the limiter is replaced rather than updated the first time so that it
+	// starts out able to allow a check, rather than one interval later
+	if limiter.refreshedAt.IsZero() {
+		limiter.limiter = rate.NewLimiter(limit, 1)
+	} else {
+		limiter.limiter.SetLimit(limit)
+	}
+
+	limiter.refreshedAt = now
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package radar_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/radar"
+	"golang.org/x/time/rate"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRateLimiter", func() {
+	var (
+		staticRate          rate.Limit
+		checkInterval       time.Duration
+		fakeResourceFactory *dbfakes.FakeResourceFactory
+		refreshInterval     time.Duration
+		fakeClock           *fakeclock.FakeClock
+
+		limiter *CheckRateLimiter
+	)
+
+	BeforeEach(func() {
+		staticRate = 0
+		checkInterval = time.Minute
+		fakeResourceFactory = new(dbfakes.FakeResourceFactory)
+		fakeResourceFactory.CheckableCountReturns(120, nil)
+		fakeResourceFactory.HeartbeatCheckerReturns(1, nil)
+		refreshInterval = 10 * time.Second
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+	})
+
+	JustBeforeEach(func() {
+		limiter = NewCheckRateLimiter(
+			"some-atc",
+			staticRate,
+			checkInterval,
+			fakeResourceFactory,
+			refreshInterval,
+			fakeClock,
+		)
+	})
+
+	Context("when no static rate is configured", func() {
+		It("derives the rate from the number of checkables and the interval", func() {
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Expect(limiter.Limit()).To(Equal(rate.Limit(2)))
+		})
+
+		It("records that the ATC is checking", func() {
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Expect(fakeResourceFactory.HeartbeatCheckerCallCount()).To(Equal(1))
+
+			atcID, ttl := fakeResourceFactory.HeartbeatCheckerArgsForCall(0)
+			Expect(atcID).To(Equal("some-atc"))
+			Expect(ttl).To(Equal(3 * refreshInterval))
+		})
+
+		Context("when other ATCs are checking", func() {
+			BeforeEach(func() {
+				fakeResourceFactory.HeartbeatCheckerReturns(4, nil)
+			})
+
+			It("shares the rate between them", func() {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+				Expect(limiter.Limit()).To(Equal(rate.Limit(0.5)))
+			})
+		})
+
+		Context("when recording that the ATC is checking fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeResourceFactory.HeartbeatCheckerReturns(0, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(limiter.Wait(context.Background())).To(Equal(disaster))
+			})
+		})
+
+		It("only counts the checkables once per refresh interval", func() {
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Expect(fakeResourceFactory.CheckableCountCallCount()).To(Equal(1))
+
+			fakeResourceFactory.CheckableCountReturns(240, nil)
+
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Expect(fakeResourceFactory.CheckableCountCallCount()).To(Equal(1))
+			Expect(limiter.Limit()).To(Equal(rate.Limit(2)))
+
+			fakeClock.Increment(refreshInterval)
+
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Expect(fakeResourceFactory.CheckableCountCallCount()).To(Equal(2))
+			Expect(limiter.Limit()).To(Equal(rate.Limit(4)))
+		})
+
+		Context("when there is nothing to check", func() {
+			BeforeEach(func() {
+				fakeResourceFactory.CheckableCountReturns(0, nil)
+			})
+
+			It("still allows a check per interval", func() {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+				Expect(limiter.Limit()).To(Equal(rate.Limit(1.0 / 60)))
+			})
+		})
+
+		Context("when counting the checkables fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeResourceFactory.CheckableCountReturns(0, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(limiter.Wait(context.Background())).To(Equal(disaster))
+			})
+		})
+
+		Context("when the context is done", func() {
+			It("returns an error rather than waiting", func() {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				Expect(limiter.Wait(ctx)).ToNot(Succeed())
+			})
+		})
+
+		It("can have its rate read while it is first refreshed", func() {
+			read := make(chan rate.Limit)
+			go func() {
+				defer GinkgoRecover()
+				read <- limiter.Limit()
+			}()
+
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Eventually(read).Should(Receive())
+			Expect(limiter.Limit()).To(Equal(rate.Limit(2)))
+		})
+	})
+
+	Context("when a static rate is configured", func() {
+		BeforeEach(func() {
+			staticRate = 5
+		})
+
+		It("uses it without counting the checkables", func() {
+			Expect(limiter.Wait(context.Background())).To(Succeed())
+			Expect(limiter.Limit()).To(Equal(rate.Limit(5)))
+			Expect(fakeResourceFactory.CheckableCountCallCount()).To(BeZero())
+		})
+
+		Context("when other ATCs are checking", func() {
+			BeforeEach(func() {
+				fakeResourceFactory.HeartbeatCheckerReturns(2, nil)
+			})
+
+			It("shares the rate between them", func() {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+				Expect(limiter.Limit()).To(Equal(rate.Limit(2.5)))
+			})
+		})
+	})
+
+	Context("when the static rate is negative", func() {
+		BeforeEach(func() {
+			staticRate = -1
+		})
+
+		It("does not limit checks", func() {
+			for i := 0; i < 100; i++ {
+				Expect(limiter.Wait(context.Background())).To(Succeed())
+			}
+
+			Expect(limiter.Limit()).To(Equal(rate.Inf))
+			Expect(fakeResourceFactory.CheckableCountCallCount()).To(BeZero())
+			Expect(fakeResourceFactory.HeartbeatCheckerCallCount()).To(BeZero())
+		})
+	})
+})
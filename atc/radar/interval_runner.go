@@ -3,10 +3,12 @@ package radar
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
 )
 
 //go:generate counterfeiter . IntervalRunner
@@ -20,6 +22,8 @@ type intervalRunner struct {
 	id            int
 	scanner       Scanner
 	notifications Notifications
+	limiter       RateLimiter
+	maxJitter     time.Duration
 }
 
 func NewIntervalRunner(
@@ -28,6 +32,8 @@ func NewIntervalRunner(
 	id int,
 	scanner Scanner,
 	notifications Notifications,
+	limiter RateLimiter,
+	maxJitter time.Duration,
 ) IntervalRunner {
 	return &intervalRunner{
 		logger:        logger,
@@ -35,11 +41,12 @@ func NewIntervalRunner(
 		id:            id,
 		scanner:       scanner,
 		notifications: notifications,
+		limiter:       limiter,
+		maxJitter:     maxJitter,
 	}
 }
 
 func (r *intervalRunner) Run(ctx context.Context) error {
-
 	interval := time.Duration(0)
 	channel := fmt.Sprintf("resource_scan_%d", r.id)
 
@@ -51,7 +58,7 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 	defer r.notifications.Unlisten(channel, notifier)
 
 	for {
-		timer := r.clock.NewTimer(interval)
+		timer := r.clock.NewTimer(interval + r.jitter())
 
 		select {
 		case <-ctx.Done():
@@ -65,7 +72,13 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 				return err
 			}
 		case <-timer.C():
+			if !r.waitForLimiter(ctx) {
+				return nil
+			}
+
+			metric.ChecksRunning.Inc()
 			interval, err = r.scanner.Run(r.logger, r.id)
+			metric.ChecksRunning.Dec()
 			if err != nil {
 				if err == ErrFailedToAcquireLock {
 					break
@@ -75,3 +88,33 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 		}
 	}
 }
+
+// jitter randomly delays periodic checks, including the first, so that
+// resources which share an interval, e.g. after the ATC restarts, spread out
+// rather than all being checked at once.
+func (r *intervalRunner) jitter() time.Duration {
+	if r.maxJitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(r.maxJitter)))
+}
+
+// waitForLimiter returns false if the context is done before a check may be
+// started. Failing to determine the rate is logged rather than preventing
+// checks.
+func (r *intervalRunner) waitForLimiter(ctx context.Context) bool {
+	metric.ChecksQueued.Inc()
+	defer metric.ChecksQueued.Dec()
+
+	err := r.limiter.Wait(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		r.logger.Error("failed-to-wait-for-check-rate-limiter", err)
+	}
+
+	return true
+}
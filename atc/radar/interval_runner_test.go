@@ -29,6 +29,8 @@ var _ = Describe("IntervalRunner", func() {
 		intervalRunner    IntervalRunner
 		fakeScanner       *radarfakes.FakeScanner
 		fakeNotifications *radarfakes.FakeNotifications
+		fakeLimiter       *radarfakes.FakeRateLimiter
+		maxJitter         time.Duration
 
 		ctx    context.Context
 		cancel context.CancelFunc
@@ -41,15 +43,19 @@ var _ = Describe("IntervalRunner", func() {
 
 		fakeScanner = new(radarfakes.FakeScanner)
 		fakeNotifications = new(radarfakes.FakeNotifications)
+		fakeLimiter = new(radarfakes.FakeRateLimiter)
+		maxJitter = 0
 
 		runTimes = make(chan time.Time, 100)
 		scanTimes = make(chan time.Time, 100)
 		interval = 1 * time.Minute
 
 		ctx, cancel = context.WithCancel(context.Background())
+	})
 
+	JustBeforeEach(func() {
 		logger := lagertest.NewTestLogger("test")
-		intervalRunner = NewIntervalRunner(logger, fakeClock, 12, fakeScanner, fakeNotifications)
+		intervalRunner = NewIntervalRunner(logger, fakeClock, 12, fakeScanner, fakeNotifications, fakeLimiter, maxJitter)
 	})
 
 	Describe("RunFunc", func() {
@@ -157,6 +163,87 @@ var _ = Describe("IntervalRunner", func() {
 					})
 				})
 
+				It("waits for the rate limiter before each run", func() {
+					Expect(<-runTimes).To(Equal(runAt))
+					Expect(fakeLimiter.WaitCallCount()).To(Equal(1))
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+					Expect(<-runTimes).To(Equal(runAt.Add(interval)))
+					Expect(fakeLimiter.WaitCallCount()).To(Equal(2))
+				})
+
+				Context("when it receives a notification", func() {
+					It("does not wait for the rate limiter", func() {
+						Expect(<-runTimes).To(Equal(runAt))
+
+						notify <- true
+						Expect(<-scanTimes).To(Equal(scanAt))
+						Expect(fakeLimiter.WaitCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the rate limiter fails", func() {
+					BeforeEach(func() {
+						fakeLimiter.WaitReturns(errors.New("nope"))
+					})
+
+					It("runs the scan anyway", func() {
+						Expect(<-runTimes).To(Equal(runAt))
+					})
+				})
+
+				Context("when the context is done while waiting for the rate limiter", func() {
+					BeforeEach(func() {
+						fakeLimiter.WaitStub = func(ctx context.Context) error {
+							cancel()
+							<-ctx.Done()
+							return ctx.Err()
+						}
+					})
+
+					It("returns without running a scan", func() {
+						Consistently(runTimes).ShouldNot(Receive())
+					})
+				})
+
+				Context("when jitter is configured", func() {
+					BeforeEach(func() {
+						maxJitter = 10 * time.Second
+					})
+
+					// the clock is advanced a second at a time until the scan
+					// runs, as the jitter is random
+					nextRunTime := func() time.Time {
+						for i := 0; i <= 10; i++ {
+							select {
+							case runTime := <-runTimes:
+								return runTime
+							case <-time.After(100 * time.Millisecond):
+								fakeClock.Increment(time.Second)
+							}
+						}
+
+						Fail("scan did not run within the jitter")
+						return time.Time{}
+					}
+
+					It("delays the first scan by up to the jitter", func() {
+						firstRunAt := nextRunTime()
+						Expect(firstRunAt).To(BeTemporally(">=", runAt))
+						Expect(firstRunAt).To(BeTemporally("<=", runAt.Add(maxJitter)))
+					})
+
+					It("delays subsequent scans by up to the jitter", func() {
+						firstRunAt := nextRunTime()
+
+						fakeClock.WaitForWatcherAndIncrement(interval)
+
+						nextRunAt := nextRunTime()
+						Expect(nextRunAt).To(BeTemporally(">=", firstRunAt.Add(interval)))
+						Expect(nextRunAt).To(BeTemporally("<=", firstRunAt.Add(interval+maxJitter+time.Second)))
+					})
+				})
+
 				Context("when Run takes a while", func() {
 					BeforeEach(func() {
 						fakeScanner.RunStub = func(lager.Logger, int) (time.Duration, error) {
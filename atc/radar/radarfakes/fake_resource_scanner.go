@@ -0,0 +1,352 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package radarfakes
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/radar"
+)
+
+type FakeResourceScanner struct {
+	RunStub        func(lager.Logger, int) (time.Duration, error)
+	runMutex       sync.RWMutex
+	runArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+	}
+	runReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	runReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
+	ScanStub        func(lager.Logger, int) error
+	scanMutex       sync.RWMutex
+	scanArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+	}
+	scanReturns struct {
+		result1 error
+	}
+	scanReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScanFromVersionStub        func(lager.Logger, int, atc.Version) error
+	scanFromVersionMutex       sync.RWMutex
+	scanFromVersionArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.Version
+	}
+	scanFromVersionReturns struct {
+		result1 error
+	}
+	scanFromVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScanFromVersionInBuildStub        func(lager.Logger, db.Build, int, atc.Version) error
+	scanFromVersionInBuildMutex       sync.RWMutex
+	scanFromVersionInBuildArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Build
+		arg3 int
+		arg4 atc.Version
+	}
+	scanFromVersionInBuildReturns struct {
+		result1 error
+	}
+	scanFromVersionInBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResourceScanner) Run(arg1 lager.Logger, arg2 int) (time.Duration, error) {
+	fake.runMutex.Lock()
+	ret, specificReturn := fake.runReturnsOnCall[len(fake.runArgsForCall)]
+	fake.runArgsForCall = append(fake.runArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("Run", []interface{}{arg1, arg2})
+	fake.runMutex.Unlock()
+	if fake.RunStub != nil {
+		return fake.RunStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.runReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceScanner) RunCallCount() int {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return len(fake.runArgsForCall)
+}
+
+func (fake *FakeResourceScanner) RunCalls(stub func(lager.Logger, int) (time.Duration, error)) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = stub
+}
+
+func (fake *FakeResourceScanner) RunArgsForCall(i int) (lager.Logger, int) {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	argsForCall := fake.runArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceScanner) RunReturns(result1 time.Duration, result2 error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = nil
+	fake.runReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceScanner) RunReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = nil
+	if fake.runReturnsOnCall == nil {
+		fake.runReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.runReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceScanner) Scan(arg1 lager.Logger, arg2 int) error {
+	fake.scanMutex.Lock()
+	ret, specificReturn := fake.scanReturnsOnCall[len(fake.scanArgsForCall)]
+	fake.scanArgsForCall = append(fake.scanArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("Scan", []interface{}{arg1, arg2})
+	fake.scanMutex.Unlock()
+	if fake.ScanStub != nil {
+		return fake.ScanStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scanReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceScanner) ScanCallCount() int {
+	fake.scanMutex.RLock()
+	defer fake.scanMutex.RUnlock()
+	return len(fake.scanArgsForCall)
+}
+
+func (fake *FakeResourceScanner) ScanCalls(stub func(lager.Logger, int) error) {
+	fake.scanMutex.Lock()
+	defer fake.scanMutex.Unlock()
+	fake.ScanStub = stub
+}
+
+func (fake *FakeResourceScanner) ScanArgsForCall(i int) (lager.Logger, int) {
+	fake.scanMutex.RLock()
+	defer fake.scanMutex.RUnlock()
+	argsForCall := fake.scanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceScanner) ScanReturns(result1 error) {
+	fake.scanMutex.Lock()
+	defer fake.scanMutex.Unlock()
+	fake.ScanStub = nil
+	fake.scanReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceScanner) ScanReturnsOnCall(i int, result1 error) {
+	fake.scanMutex.Lock()
+	defer fake.scanMutex.Unlock()
+	fake.ScanStub = nil
+	if fake.scanReturnsOnCall == nil {
+		fake.scanReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceScanner) ScanFromVersion(arg1 lager.Logger, arg2 int, arg3 atc.Version) error {
+	fake.scanFromVersionMutex.Lock()
+	ret, specificReturn := fake.scanFromVersionReturnsOnCall[len(fake.scanFromVersionArgsForCall)]
+	fake.scanFromVersionArgsForCall = append(fake.scanFromVersionArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ScanFromVersion", []interface{}{arg1, arg2, arg3})
+	fake.scanFromVersionMutex.Unlock()
+	if fake.ScanFromVersionStub != nil {
+		return fake.ScanFromVersionStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scanFromVersionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionCallCount() int {
+	fake.scanFromVersionMutex.RLock()
+	defer fake.scanFromVersionMutex.RUnlock()
+	return len(fake.scanFromVersionArgsForCall)
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionCalls(stub func(lager.Logger, int, atc.Version) error) {
+	fake.scanFromVersionMutex.Lock()
+	defer fake.scanFromVersionMutex.Unlock()
+	fake.ScanFromVersionStub = stub
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionArgsForCall(i int) (lager.Logger, int, atc.Version) {
+	fake.scanFromVersionMutex.RLock()
+	defer fake.scanFromVersionMutex.RUnlock()
+	argsForCall := fake.scanFromVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionReturns(result1 error) {
+	fake.scanFromVersionMutex.Lock()
+	defer fake.scanFromVersionMutex.Unlock()
+	fake.ScanFromVersionStub = nil
+	fake.scanFromVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionReturnsOnCall(i int, result1 error) {
+	fake.scanFromVersionMutex.Lock()
+	defer fake.scanFromVersionMutex.Unlock()
+	fake.ScanFromVersionStub = nil
+	if fake.scanFromVersionReturnsOnCall == nil {
+		fake.scanFromVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanFromVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionInBuild(arg1 lager.Logger, arg2 db.Build, arg3 int, arg4 atc.Version) error {
+	fake.scanFromVersionInBuildMutex.Lock()
+	ret, specificReturn := fake.scanFromVersionInBuildReturnsOnCall[len(fake.scanFromVersionInBuildArgsForCall)]
+	fake.scanFromVersionInBuildArgsForCall = append(fake.scanFromVersionInBuildArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Build
+		arg3 int
+		arg4 atc.Version
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ScanFromVersionInBuild", []interface{}{arg1, arg2, arg3, arg4})
+	fake.scanFromVersionInBuildMutex.Unlock()
+	if fake.ScanFromVersionInBuildStub != nil {
+		return fake.ScanFromVersionInBuildStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scanFromVersionInBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionInBuildCallCount() int {
+	fake.scanFromVersionInBuildMutex.RLock()
+	defer fake.scanFromVersionInBuildMutex.RUnlock()
+	return len(fake.scanFromVersionInBuildArgsForCall)
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionInBuildCalls(stub func(lager.Logger, db.Build, int, atc.Version) error) {
+	fake.scanFromVersionInBuildMutex.Lock()
+	defer fake.scanFromVersionInBuildMutex.Unlock()
+	fake.ScanFromVersionInBuildStub = stub
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionInBuildArgsForCall(i int) (lager.Logger, db.Build, int, atc.Version) {
+	fake.scanFromVersionInBuildMutex.RLock()
+	defer fake.scanFromVersionInBuildMutex.RUnlock()
+	argsForCall := fake.scanFromVersionInBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionInBuildReturns(result1 error) {
+	fake.scanFromVersionInBuildMutex.Lock()
+	defer fake.scanFromVersionInBuildMutex.Unlock()
+	fake.ScanFromVersionInBuildStub = nil
+	fake.scanFromVersionInBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceScanner) ScanFromVersionInBuildReturnsOnCall(i int, result1 error) {
+	fake.scanFromVersionInBuildMutex.Lock()
+	defer fake.scanFromVersionInBuildMutex.Unlock()
+	fake.ScanFromVersionInBuildStub = nil
+	if fake.scanFromVersionInBuildReturnsOnCall == nil {
+		fake.scanFromVersionInBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanFromVersionInBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceScanner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	fake.scanMutex.RLock()
+	defer fake.scanMutex.RUnlock()
+	fake.scanFromVersionMutex.RLock()
+	defer fake.scanFromVersionMutex.RUnlock()
+	fake.scanFromVersionInBuildMutex.RLock()
+	defer fake.scanFromVersionInBuildMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeResourceScanner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ radar.ResourceScanner = new(FakeResourceScanner)
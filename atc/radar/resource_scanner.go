@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
@@ -19,6 +20,11 @@ import (
 
 var GlobalResourceCheckTimeout time.Duration
 
+// ResourceCheckMaxBackoff caps the interval between periodic checks of a
+// resource which keeps failing to check, which otherwise doubles with each
+// consecutive failure. Checks are not backed off if it is zero.
+var ResourceCheckMaxBackoff time.Duration
+
 type resourceScanner struct {
 	clock                 clock.Clock
 	pool                  worker.Pool
@@ -41,7 +47,7 @@ func NewResourceScanner(
 	externalURL string,
 	variables creds.Variables,
 	strategy worker.ContainerPlacementStrategy,
-) Scanner {
+) ResourceScanner {
 	return &resourceScanner{
 		clock:                 clock,
 		pool:                  pool,
@@ -60,7 +66,7 @@ var ErrResourceTypeNotFound = errors.New("resource type not found")
 var ErrResourceTypeCheckError = errors.New("resource type failed to check")
 
 func (scanner *resourceScanner) Run(logger lager.Logger, resourceID int) (time.Duration, error) {
-	interval, err := scanner.scan(logger.Session("tick"), resourceID, nil, false, false)
+	interval, err := scanner.scan(logger.Session("tick"), nil, resourceID, nil, false, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
@@ -68,20 +74,28 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceID int) (time.D
 }
 
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceID int, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceID, fromVersion, true, true)
+	_, err := scanner.scan(logger, nil, resourceID, fromVersion, true, true)
+
+	return err
+}
+
+func (scanner *resourceScanner) ScanFromVersionInBuild(logger lager.Logger, checkBuild db.Build, resourceID int, fromVersion atc.Version) error {
+	_, err := scanner.scan(logger, checkBuild, resourceID, fromVersion, true, true)
+
+	scanner.finishCheckBuild(logger, checkBuild, err)
 
 	return err
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceID int) error {
-	_, err := scanner.scan(logger, resourceID, nil, true, false)
+	_, err := scanner.scan(logger, nil, resourceID, nil, true, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
 	return err
 }
 
-func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVersion atc.Version, mustComplete bool, saveGiven bool) (time.Duration, error) {
+func (scanner *resourceScanner) scan(logger lager.Logger, checkBuild db.Build, resourceID int, fromVersion atc.Version, mustComplete bool, saveGiven bool) (time.Duration, error) {
 	savedResource, found, err := scanner.dbPipeline.ResourceByID(resourceID)
 	if err != nil {
 		return 0, err
@@ -180,6 +194,19 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 	// Clear out check error on the resource
 	scanner.setResourceCheckError(logger, savedResource, nil)
 
+	// checks requested through the API aren't made to wait out the backoff
+	// from previous failures, and start counting them afresh
+	if checkBuild != nil && resourceConfigScope.CheckFailures() > 0 {
+		err = resourceConfigScope.ResetCheckFailures()
+		if err != nil {
+			logger.Error("failed-to-reset-check-failures", err)
+			return 0, err
+		}
+	}
+
+	baseInterval := interval
+	interval = backOff(baseInterval, resourceConfigScope.CheckFailures())
+
 	currentVersion := savedResource.CurrentPinnedVersion()
 	if currentVersion != nil {
 		_, found, err := resourceConfigScope.FindVersion(currentVersion)
@@ -248,8 +275,9 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		}
 	}
 
-	return interval, scanner.check(
+	err = scanner.check(
 		logger,
+		checkBuild,
 		savedResource,
 		resourceConfigScope,
 		fromVersion,
@@ -258,10 +286,29 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		saveGiven,
 		timeout,
 	)
+	if err == nil {
+		return baseInterval, nil
+	}
+
+	if err != errPipelineRemoved && ResourceCheckMaxBackoff > 0 {
+		interval = backOff(baseInterval, resourceConfigScope.CheckFailures()+1)
+
+		if interval != baseInterval {
+			logger.Info("backing-off", lager.Data{"interval": interval.String()})
+
+			nextErr := resourceConfigScope.UpdateNextCheckTime(interval)
+			if nextErr != nil {
+				logger.Error("failed-to-update-next-check-time", nextErr)
+			}
+		}
+	}
+
+	return interval, err
 }
 
 func (scanner *resourceScanner) check(
 	logger lager.Logger,
+	checkBuild db.Build,
 	savedResource db.Resource,
 	resourceConfigScope db.ResourceConfigScope,
 	fromVersion atc.Version,
@@ -269,7 +316,7 @@ func (scanner *resourceScanner) check(
 	source atc.Source,
 	saveGiven bool,
 	timeout time.Duration,
-) error {
+) (err error) {
 	pipelinePaused, err := scanner.dbPipeline.CheckPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
@@ -291,6 +338,21 @@ func (scanner *resourceScanner) check(
 		return errPipelineRemoved
 	}
 
+	if checkBuild == nil {
+		var trackingLock lock.Lock
+		checkBuild, trackingLock, err = savedResource.CreateCheckBuild(logger)
+		if err != nil {
+			logger.Error("failed-to-create-check-build", err)
+			return err
+		}
+
+		defer trackingLock.Release()
+
+		defer func() {
+			scanner.finishCheckBuild(logger, checkBuild, err)
+		}()
+	}
+
 	metadata := resource.TrackerMetadata{
 		ResourceName: savedResource.Name(),
 		PipelineName: savedResource.PipelineName(),
@@ -357,7 +419,12 @@ func (scanner *resourceScanner) check(
 	defer cancel()
 
 	res := scanner.resourceFactory.NewResourceForContainer(container)
-	newVersions, err := res.Check(ctx, source, fromVersion)
+
+	checkStart := scanner.clock.Now()
+	newVersions, err := res.Check(ctx, resource.IOConfig{
+		Stderr: newCheckBuildWriter(checkBuild, scanner.clock),
+	}, source, fromVersion)
+	checkDuration := scanner.clock.Since(checkStart)
 	if err == context.DeadlineExceeded {
 		err = fmt.Errorf("Timed out after %v while checking for new versions - perhaps increase your resource check timeout?", timeout)
 	}
@@ -368,6 +435,7 @@ func (scanner *resourceScanner) check(
 		ResourceName: savedResource.Name(),
 		TeamName:     scanner.dbPipeline.TeamName(),
 		Success:      err == nil,
+		Duration:     checkDuration,
 	}.Emit(logger)
 
 	if err != nil {
@@ -410,6 +478,36 @@ func (scanner *resourceScanner) check(
 	return nil
 }
 
+func (scanner *resourceScanner) finishCheckBuild(logger lager.Logger, checkBuild db.Build, checkErr error) {
+	var err error
+	switch checkErr.(type) {
+	case nil:
+		err = checkBuild.Finish(db.BuildStatusSucceeded)
+	case resource.ErrResourceScriptFailed:
+		err = checkBuild.Finish(db.BuildStatusFailed)
+	default:
+		err = checkBuild.FinishWithError(checkErr)
+	}
+
+	if err != nil {
+		logger.Error("failed-to-finish-check-build", err, lager.Data{"build": checkBuild.ID()})
+	}
+}
+
+// backOff doubles the interval for each consecutive failure, up to
+// ResourceCheckMaxBackoff. Intervals which are already longer are left alone.
+func backOff(interval time.Duration, failures int) time.Duration {
+	for i := 0; i < failures && interval < ResourceCheckMaxBackoff; i++ {
+		interval *= 2
+
+		if interval > ResourceCheckMaxBackoff {
+			interval = ResourceCheckMaxBackoff
+		}
+	}
+
+	return interval
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
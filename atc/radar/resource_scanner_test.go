@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -45,10 +46,12 @@ var _ = Describe("ResourceScanner", func() {
 		fakeResourceType      *dbfakes.FakeResourceType
 		versionedResourceType atc.VersionedResourceType
 
-		scanner Scanner
+		scanner ResourceScanner
 
 		resourceConfig          atc.ResourceConfig
 		fakeDBResource          *dbfakes.FakeResource
+		fakeCheckBuild          *dbfakes.FakeBuild
+		fakeCheckBuildLock      *lockfakes.FakeLock
 		fakeResourceConfig      *dbfakes.FakeResourceConfig
 		fakeResourceConfigScope *dbfakes.FakeResourceConfigScope
 
@@ -119,6 +122,11 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBResource.TagsReturns(atc.Tags{"some-tag"})
 		fakeDBResource.SetResourceConfigReturns(fakeResourceConfigScope, nil)
 
+		fakeCheckBuild = new(dbfakes.FakeBuild)
+		fakeCheckBuild.IDReturns(111)
+		fakeCheckBuildLock = new(lockfakes.FakeLock)
+		fakeDBResource.CreateCheckBuildReturns(fakeCheckBuild, fakeCheckBuildLock, nil)
+
 		fakeDBPipeline.ResourceByIDReturns(fakeDBResource, true, nil)
 
 		scanner = NewResourceScanner(
@@ -236,6 +244,54 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(fakeResource.CheckCallCount()).To(Equal(1))
 				})
 
+				It("records the check as a build", func() {
+					Expect(fakeDBResource.CreateCheckBuildCallCount()).To(Equal(1))
+
+					Expect(fakeCheckBuild.FinishCallCount()).To(Equal(1))
+					Expect(fakeCheckBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+				})
+
+				It("releases the check build's tracking lock once it is finished", func() {
+					Expect(fakeCheckBuildLock.ReleaseCallCount()).To(Equal(1))
+				})
+
+				Context("when the check writes to stderr", func() {
+					BeforeEach(func() {
+						fakeResource.CheckStub = func(ctx context.Context, ioConfig resource.IOConfig, source atc.Source, from atc.Version) ([]atc.Version, error) {
+							_, err := ioConfig.Stderr.Write([]byte("some-stderr"))
+							return nil, err
+						}
+					})
+
+					It("saves it as the check build's log", func() {
+						Expect(fakeCheckBuild.SaveEventCallCount()).To(Equal(1))
+						Expect(fakeCheckBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+							Time:    epoch.Unix(),
+							Payload: "some-stderr",
+							Origin: event.Origin{
+								ID:     "check",
+								Source: event.OriginSourceStderr,
+							},
+						}))
+					})
+				})
+
+				Context("when creating the check build fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeDBResource.CreateCheckBuildReturns(nil, nil, disaster)
+					})
+
+					It("does not check", func() {
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+
+					It("returns the error", func() {
+						Expect(runErr).To(Equal(disaster))
+					})
+				})
+
 				It("constructs the resource of the correct type", func() {
 					Expect(fakeDBResource.SetResourceConfigCallCount()).To(Equal(1))
 					_, resourceSource, resourceTypes := fakeDBResource.SetResourceConfigArgsForCall(0)
@@ -430,7 +486,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				Context("when there is no current version", func() {
 					It("checks from nil", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(BeNil())
 					})
 				})
@@ -445,7 +501,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("checks from it", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "1"}))
 					})
 				})
@@ -469,7 +525,7 @@ var _ = Describe("ResourceScanner", func() {
 						}
 
 						check := 0
-						fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+						fakeResource.CheckStub = func(ctx context.Context, ioConfig resource.IOConfig, source atc.Source, from atc.Version) ([]atc.Version, error) {
 							defer GinkgoRecover()
 
 							Expect(source).To(Equal(resourceConfig.Source))
@@ -515,6 +571,11 @@ var _ = Describe("ResourceScanner", func() {
 						Expect(runErr).To(HaveOccurred())
 						Expect(runErr).To(Equal(disaster))
 					})
+
+					It("errors the check build", func() {
+						Expect(fakeCheckBuild.FinishWithErrorCallCount()).To(Equal(1))
+						Expect(fakeCheckBuild.FinishWithErrorArgsForCall(0)).To(Equal(disaster))
+					})
 				})
 
 				Context("when checking fails with ErrResourceScriptFailed", func() {
@@ -527,6 +588,74 @@ var _ = Describe("ResourceScanner", func() {
 					It("returns no error", func() {
 						Expect(runErr).NotTo(HaveOccurred())
 					})
+
+					It("fails the check build", func() {
+						Expect(fakeCheckBuild.FinishCallCount()).To(Equal(1))
+						Expect(fakeCheckBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusFailed))
+					})
+				})
+
+				Context("when checks are backed off", func() {
+					BeforeEach(func() {
+						ResourceCheckMaxBackoff = 10 * time.Minute
+					})
+
+					AfterEach(func() {
+						ResourceCheckMaxBackoff = 0
+					})
+
+					Context("when the resource has been failing to check", func() {
+						BeforeEach(func() {
+							fakeResourceConfigScope.CheckFailuresReturns(2)
+						})
+
+						It("only checks once the backed off interval has elapsed", func() {
+							Expect(fakeResourceConfigScope.UpdateLastCheckStartTimeCallCount()).To(Equal(1))
+							leaseInterval, immediate := fakeResourceConfigScope.UpdateLastCheckStartTimeArgsForCall(0)
+							Expect(leaseInterval).To(Equal(4 * interval))
+							Expect(immediate).To(BeFalse())
+						})
+
+						It("returns the configured interval once the check succeeds", func() {
+							Expect(actualInterval).To(Equal(interval))
+							Expect(fakeResourceConfigScope.UpdateNextCheckTimeCallCount()).To(BeZero())
+						})
+
+						Context("when the check fails again", func() {
+							BeforeEach(func() {
+								fakeResource.CheckReturns(nil, resource.ErrResourceScriptFailed{})
+							})
+
+							It("doubles the interval, up to the maximum", func() {
+								Expect(actualInterval).To(Equal(8 * interval))
+
+								Expect(fakeResourceConfigScope.UpdateNextCheckTimeCallCount()).To(Equal(1))
+								Expect(fakeResourceConfigScope.UpdateNextCheckTimeArgsForCall(0)).To(Equal(8 * interval))
+							})
+						})
+
+						Context("when the backoff has reached the maximum", func() {
+							BeforeEach(func() {
+								fakeResourceConfigScope.CheckFailuresReturns(5)
+								fakeResource.CheckReturns(nil, resource.ErrResourceScriptFailed{})
+							})
+
+							It("caps the interval", func() {
+								Expect(actualInterval).To(Equal(10 * time.Minute))
+							})
+						})
+					})
+
+					Context("when the check fails for the first time", func() {
+						BeforeEach(func() {
+							fakeResource.CheckReturns(nil, resource.ErrResourceScriptFailed{})
+						})
+
+						It("backs off the next check", func() {
+							Expect(actualInterval).To(Equal(2 * interval))
+							Expect(fakeResourceConfigScope.UpdateNextCheckTimeArgsForCall(0)).To(Equal(2 * interval))
+						})
+					})
 				})
 
 				Context("when the pipeline is paused", func() {
@@ -538,6 +667,10 @@ var _ = Describe("ResourceScanner", func() {
 						Expect(fakeResource.CheckCallCount()).To(BeZero())
 					})
 
+					It("does not record a check build", func() {
+						Expect(fakeDBResource.CreateCheckBuildCallCount()).To(BeZero())
+					})
+
 					It("returns the default interval", func() {
 						Expect(actualInterval).To(Equal(interval))
 					})
@@ -819,7 +952,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				It("times out after the specified timeout", func() {
 					now := time.Now()
-					ctx, _, _ := fakeResource.CheckArgsForCall(0)
+					ctx, _, _, _ := fakeResource.CheckArgsForCall(0)
 					deadline, _ := ctx.Deadline()
 					Expect(deadline).Should(BeTemporally("~", now.Add(10*time.Second), time.Second))
 				})
@@ -869,7 +1002,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("checks from the pinned version", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "1"}))
 					})
 				})
@@ -901,7 +1034,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -934,7 +1067,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -968,7 +1101,7 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, ioConfig resource.IOConfig, source atc.Source, from atc.Version) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -1009,7 +1142,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when the check does not return any new versions", func() {
 				BeforeEach(func() {
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, ioConfig resource.IOConfig, source atc.Source, from atc.Version) ([]atc.Version, error) {
 						return []atc.Version{}, nil
 					}
 				})
@@ -1092,7 +1225,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -1105,7 +1238,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -1146,4 +1279,106 @@ var _ = Describe("ResourceScanner", func() {
 			})
 		})
 	})
+
+	Describe("ScanFromVersionInBuild", func() {
+		var (
+			fakeResource   *rfakes.FakeResource
+			fakeGivenBuild *dbfakes.FakeBuild
+
+			scanErr error
+		)
+
+		BeforeEach(func() {
+			fakeWorker.NameReturns("some-worker")
+			fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+
+			fakeContainer.HandleReturns("some-handle")
+			fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+
+			fakeResource = new(rfakes.FakeResource)
+			fakeResourceFactory.NewResourceForContainerReturns(fakeResource)
+
+			fakeGivenBuild = new(dbfakes.FakeBuild)
+
+			fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
+			fakeResourceConfigScope.UpdateLastCheckStartTimeReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			scanErr = scanner.ScanFromVersionInBuild(lagertest.NewTestLogger("test"), fakeGivenBuild, 39, atc.Version{"version": "1"})
+		})
+
+		It("records the check in the given build", func() {
+			Expect(scanErr).NotTo(HaveOccurred())
+			Expect(fakeDBResource.CreateCheckBuildCallCount()).To(BeZero())
+
+			Expect(fakeGivenBuild.FinishCallCount()).To(Equal(1))
+			Expect(fakeGivenBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+		})
+
+		Context("when checking fails with ErrResourceScriptFailed", func() {
+			scriptFail := resource.ErrResourceScriptFailed{}
+
+			BeforeEach(func() {
+				fakeResource.CheckReturns(nil, scriptFail)
+			})
+
+			It("fails the given build", func() {
+				Expect(scanErr).To(Equal(scriptFail))
+
+				Expect(fakeGivenBuild.FinishCallCount()).To(Equal(1))
+				Expect(fakeGivenBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusFailed))
+			})
+		})
+
+		Context("when the resource has been failing to check", func() {
+			BeforeEach(func() {
+				ResourceCheckMaxBackoff = 10 * time.Minute
+				fakeResourceConfigScope.CheckFailuresReturns(3)
+				fakeResourceConfigScope.ResetCheckFailuresStub = func() error {
+					fakeResourceConfigScope.CheckFailuresReturns(0)
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				ResourceCheckMaxBackoff = 0
+			})
+
+			It("resets the failures rather than waiting out the backoff", func() {
+				Expect(scanErr).NotTo(HaveOccurred())
+				Expect(fakeResourceConfigScope.ResetCheckFailuresCallCount()).To(Equal(1))
+
+				leaseInterval, immediate := fakeResourceConfigScope.UpdateLastCheckStartTimeArgsForCall(0)
+				Expect(leaseInterval).To(Equal(interval))
+				Expect(immediate).To(BeTrue())
+			})
+
+			Context("when resetting the failures fails", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.ResetCheckFailuresStub = nil
+					fakeResourceConfigScope.ResetCheckFailuresReturns(errors.New("nope"))
+				})
+
+				It("errors the given build without checking", func() {
+					Expect(scanErr).To(Equal(errors.New("nope")))
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+					Expect(fakeGivenBuild.FinishWithErrorCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the scan fails before checking", func() {
+			BeforeEach(func() {
+				fakeDBPipeline.ResourceByIDReturns(nil, false, nil)
+			})
+
+			It("errors the given build", func() {
+				Expect(scanErr).To(HaveOccurred())
+
+				Expect(fakeGivenBuild.FinishWithErrorCallCount()).To(Equal(1))
+				Expect(fakeGivenBuild.FinishWithErrorArgsForCall(0)).To(Equal(scanErr))
+			})
+		})
+	})
 })
@@ -284,7 +284,7 @@ func (scanner *resourceTypeScanner) check(
 	}
 
 	res := scanner.resourceFactory.NewResourceForContainer(container)
-	newVersions, err := res.Check(context.TODO(), source, fromVersion)
+	newVersions, err := res.Check(context.TODO(), resource.IOConfig{}, source, fromVersion)
 	resourceConfigScope.SetCheckError(err)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
@@ -24,6 +24,8 @@ type scanRunnerFactory struct {
 	resourceScanner     Scanner
 	resourceTypeScanner Scanner
 	notifications       Notifications
+	checkRateLimiter    RateLimiter
+	checkJitter         time.Duration
 }
 
 func NewScanRunnerFactory(
@@ -38,6 +40,8 @@ func NewScanRunnerFactory(
 	variables creds.Variables,
 	strategy worker.ContainerPlacementStrategy,
 	notifications Notifications,
+	checkRateLimiter RateLimiter,
+	checkJitter time.Duration,
 ) ScanRunnerFactory {
 	resourceTypeScanner := NewResourceTypeScanner(
 		clock,
@@ -67,6 +71,8 @@ func NewScanRunnerFactory(
 		resourceScanner:     resourceScanner,
 		resourceTypeScanner: resourceTypeScanner,
 		notifications:       notifications,
+		checkRateLimiter:    checkRateLimiter,
+		checkJitter:         checkJitter,
 	}
 }
 
@@ -77,6 +83,8 @@ func (sf *scanRunnerFactory) ScanResourceRunner(logger lager.Logger, resource db
 		resource.ID(),
 		sf.resourceScanner,
 		sf.notifications,
+		sf.checkRateLimiter,
+		sf.checkJitter,
 	)
 }
 
@@ -87,5 +95,7 @@ func (sf *scanRunnerFactory) ScanResourceTypeRunner(logger lager.Logger, resourc
 		resourceType.ID(),
 		sf.resourceTypeScanner,
 		sf.notifications,
+		sf.checkRateLimiter,
+		sf.checkJitter,
 	)
 }
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 )
 
 //go:generate counterfeiter . Scanner
@@ -14,3 +15,15 @@ type Scanner interface {
 	Scan(lager.Logger, int) error
 	ScanFromVersion(lager.Logger, int, atc.Version) error
 }
+
+//go:generate counterfeiter . ResourceScanner
+
+// ResourceScanner records each check of a resource as a build. A check build
+// may be created up front, e.g. so that its events can be streamed by
+// whoever requested the check, in which case it is finished once the scan is
+// complete. Whoever created it holds on to its tracking lock until then.
+type ResourceScanner interface {
+	Scanner
+
+	ScanFromVersionInBuild(lager.Logger, db.Build, int, atc.Version) error
+}
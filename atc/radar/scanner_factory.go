@@ -15,7 +15,7 @@ import (
 
 // go:generate counterfeiter . ScannerFactory
 type ScannerFactory interface {
-	NewResourceScanner(dbPipeline db.Pipeline) Scanner
+	NewResourceScanner(dbPipeline db.Pipeline) ResourceScanner
 	NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
 }
 
@@ -58,7 +58,7 @@ func NewScannerFactory(
 	}
 }
 
-func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
+func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) ResourceScanner {
 	variables := f.variablesFactory.NewVariables(dbPipeline.TeamName(), dbPipeline.Name())
 
 	return NewResourceScanner(
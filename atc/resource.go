@@ -12,6 +12,10 @@ type Resource struct {
 	CheckSetupError string `json:"check_setup_error,omitempty"`
 	CheckError      string `json:"check_error,omitempty"`
 
+	// set while checks are being backed off after consecutive failures
+	CheckFailures int   `json:"check_failures,omitempty"`
+	NextCheck     int64 `json:"next_check,omitempty"`
+
 	PinnedVersion  Version `json:"pinned_version,omitempty"`
 	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
 	PinComment     string  `json:"pin_comment,omitempty"`
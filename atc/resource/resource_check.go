@@ -1,9 +1,12 @@
 package resource
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/tracing"
 )
 
 type checkRequest struct {
@@ -11,19 +14,38 @@ type checkRequest struct {
 	Version atc.Version `json:"version"`
 }
 
-func (resource *resource) Check(ctx context.Context, source atc.Source, fromVersion atc.Version) ([]atc.Version, error) {
+func (resource *resource) Check(ctx context.Context, ioConfig IOConfig, source atc.Source, fromVersion atc.Version) ([]atc.Version, error) {
+	ctx, span := tracing.StartSpan(ctx, "resource.check", tracing.Attrs{
+		"container": resource.container.Handle(),
+	})
+	defer span.End()
+
 	var versions []atc.Version
 
+	// stderr is streamed to the given writer, if any, but is also still
+	// returned with a failure as the check's error is shown on the resource
+	var logDest io.Writer
+	stderr := new(bytes.Buffer)
+	if ioConfig.Stderr != nil {
+		logDest = io.MultiWriter(ioConfig.Stderr, stderr)
+	}
+
 	err := resource.runScript(
 		ctx,
 		"/opt/resource/check",
 		nil,
 		checkRequest{source, fromVersion},
 		&versions,
-		nil,
+		logDest,
 		false,
 	)
 	if err != nil {
+		if scriptErr, ok := err.(ErrResourceScriptFailed); ok && logDest != nil {
+			scriptErr.Stderr = stderr.String()
+			err = scriptErr
+		}
+
+		span.RecordError(err)
 		return nil, err
 	}
 
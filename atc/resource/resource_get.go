@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/tracing"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -21,6 +22,11 @@ func (resource *resource) Get(
 	params atc.Params,
 	version atc.Version,
 ) (VersionedSource, error) {
+	ctx, span := tracing.StartSpan(ctx, "resource.get", tracing.Attrs{
+		"container": resource.container.Handle(),
+	})
+	defer span.End()
+
 	var vr versionResult
 
 	err := resource.runScript(
@@ -33,6 +39,7 @@ func (resource *resource) Get(
 		true,
 	)
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/tracing"
 )
 
 type putRequest struct {
@@ -17,6 +18,11 @@ func (resource *resource) Put(
 	source atc.Source,
 	params atc.Params,
 ) (VersionedSource, error) {
+	ctx, span := tracing.StartSpan(ctx, "resource.put", tracing.Attrs{
+		"container": resource.container.Handle(),
+	})
+	defer span.End()
+
 	resourceDir := ResourcesDir("put")
 
 	vs := &putVersionedSource{
@@ -37,6 +43,7 @@ func (resource *resource) Put(
 		true,
 	)
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
 
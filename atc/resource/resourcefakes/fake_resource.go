@@ -11,12 +11,13 @@ import (
 )
 
 type FakeResource struct {
-	CheckStub        func(context.Context, atc.Source, atc.Version) ([]atc.Version, error)
+	CheckStub        func(context.Context, resource.IOConfig, atc.Source, atc.Version) ([]atc.Version, error)
 	checkMutex       sync.RWMutex
 	checkArgsForCall []struct {
 		arg1 context.Context
-		arg2 atc.Source
-		arg3 atc.Version
+		arg2 resource.IOConfig
+		arg3 atc.Source
+		arg4 atc.Version
 	}
 	checkReturns struct {
 		result1 []atc.Version
@@ -64,18 +65,19 @@ type FakeResource struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeResource) Check(arg1 context.Context, arg2 atc.Source, arg3 atc.Version) ([]atc.Version, error) {
+func (fake *FakeResource) Check(arg1 context.Context, arg2 resource.IOConfig, arg3 atc.Source, arg4 atc.Version) ([]atc.Version, error) {
 	fake.checkMutex.Lock()
 	ret, specificReturn := fake.checkReturnsOnCall[len(fake.checkArgsForCall)]
 	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
 		arg1 context.Context
-		arg2 atc.Source
-		arg3 atc.Version
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3})
+		arg2 resource.IOConfig
+		arg3 atc.Source
+		arg4 atc.Version
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3, arg4})
 	fake.checkMutex.Unlock()
 	if fake.CheckStub != nil {
-		return fake.CheckStub(arg1, arg2, arg3)
+		return fake.CheckStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -90,17 +92,17 @@ func (fake *FakeResource) CheckCallCount() int {
 	return len(fake.checkArgsForCall)
 }
 
-func (fake *FakeResource) CheckCalls(stub func(context.Context, atc.Source, atc.Version) ([]atc.Version, error)) {
+func (fake *FakeResource) CheckCalls(stub func(context.Context, resource.IOConfig, atc.Source, atc.Version) ([]atc.Version, error)) {
 	fake.checkMutex.Lock()
 	defer fake.checkMutex.Unlock()
 	fake.CheckStub = stub
 }
 
-func (fake *FakeResource) CheckArgsForCall(i int) (context.Context, atc.Source, atc.Version) {
+func (fake *FakeResource) CheckArgsForCall(i int) (context.Context, resource.IOConfig, atc.Source, atc.Version) {
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
 	argsForCall := fake.checkArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeResource) CheckReturns(result1 []atc.Version, result2 error) {
@@ -12,3 +12,17 @@ type SaveConfigResponse struct {
 type ConfigResponse struct {
 	Config Config `json:"config"`
 }
+
+type CheckCredsResponse struct {
+	Checked  int                      `json:"checked"`
+	Failures []CredentialCheckFailure `json:"failures,omitempty"`
+}
+
+// CredentialCheckFailure is a var referred to within a pipeline's config which
+// could not be resolved. Its value is never included.
+type CredentialCheckFailure struct {
+	Location string `json:"location"`
+	Var      string `json:"var"`
+	Missing  bool   `json:"missing,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	atctemplate "github.com/concourse/concourse/atc/template"
 )
 
 var ErrResourceNotFound = errors.New("resource not found")
@@ -99,6 +100,10 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -131,6 +136,88 @@ func (factory *buildFactory) constructPlanFromConfig(
 	})
 }
 
+// across plans the step once for each combination of the vars' values. If
+// any of the values are loaded from a local var, the step is instead planned
+// once to be expanded at runtime.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	acrossPlan := atc.AcrossPlan{
+		FailFast: planConfig.FailFast,
+	}
+
+	static := true
+	for _, v := range planConfig.Across {
+		acrossVar := atc.AcrossVar{
+			Var:         v.Var,
+			MaxInFlight: v.MaxInFlight,
+		}
+
+		switch values := v.Values.(type) {
+		case []interface{}:
+			acrossVar.Values = values
+		case string:
+			acrossVar.ValuesFrom, _ = atctemplate.ParseLocalVarReference(values)
+			static = false
+		}
+
+		acrossPlan.Vars = append(acrossPlan.Vars, acrossVar)
+	}
+
+	stepConfig := planConfig
+	stepConfig.Across = nil
+	stepConfig.FailFast = false
+
+	if !static {
+		step, err := factory.constructPlanFromConfig(stepConfig, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		acrossPlan.Step = &step
+
+		return factory.planFactory.NewPlan(acrossPlan), nil
+	}
+
+	for _, values := range acrossCombinations(acrossPlan.Vars) {
+		step, err := factory.constructPlanFromConfig(stepConfig, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		acrossPlan.Steps = append(acrossPlan.Steps, atc.VarScopedPlan{
+			Step:   step,
+			Values: values,
+		})
+	}
+
+	return factory.planFactory.NewPlan(acrossPlan), nil
+}
+
+// acrossCombinations returns every combination of the vars' values, varying
+// the last var the fastest.
+func acrossCombinations(vars []atc.AcrossVar) [][]interface{} {
+	combinations := [][]interface{}{{}}
+
+	for _, v := range vars {
+		var next [][]interface{}
+		for _, combination := range combinations {
+			for _, value := range v.Values {
+				values := make([]interface{}, len(combination), len(combination)+1)
+				copy(values, combination)
+				next = append(next, append(values, value))
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
+}
+
 func (factory *buildFactory) constructUnhookedPlan(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -244,6 +331,22 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 			VersionedResourceTypes: resourceTypes,
 		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:     planConfig.SetPipeline,
+			File:     planConfig.TaskConfigPath,
+			Vars:     planConfig.TaskVars,
+			VarFiles: planConfig.VarFiles,
+		})
+
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:   planConfig.LoadVar,
+			File:   planConfig.TaskConfigPath,
+			Format: planConfig.Format,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -277,6 +380,29 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		var steps []atc.Plan
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			steps = append(steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(atc.InParallelPlan{
+			Steps:    steps,
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		})
 	}
 
 	if planConfig.Timeout != "" {
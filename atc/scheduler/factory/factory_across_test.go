@@ -0,0 +1,121 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.VersionedResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.VersionedResourceTypes{}
+	})
+
+	Context("when a step has across with a list of values", func() {
+		It("plans the step once for each combination of values", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "some-task",
+						TaskConfigPath: "some-resource/task.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 2},
+							{Var: "db", Values: []interface{}{"postgres", "mysql"}},
+						},
+						FailFast: true,
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskPlan := atc.TaskPlan{
+				Name:                   "some-task",
+				ConfigPath:             "some-resource/task.yml",
+				VersionedResourceTypes: resourceTypes,
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 2},
+					{Var: "db", Values: []interface{}{"postgres", "mysql"}},
+				},
+				Steps: []atc.VarScopedPlan{
+					{
+						Step:   expectedPlanFactory.NewPlan(taskPlan),
+						Values: []interface{}{"1.12", "postgres"},
+					},
+					{
+						Step:   expectedPlanFactory.NewPlan(taskPlan),
+						Values: []interface{}{"1.12", "mysql"},
+					},
+					{
+						Step:   expectedPlanFactory.NewPlan(taskPlan),
+						Values: []interface{}{"1.13", "postgres"},
+					},
+					{
+						Step:   expectedPlanFactory.NewPlan(taskPlan),
+						Values: []interface{}{"1.13", "mysql"},
+					},
+				},
+				FailFast: true,
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when a step has across with values loaded from a local var", func() {
+		It("plans the step once, to be expanded at runtime", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "some-task",
+						TaskConfigPath: "some-resource/task.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go", Values: "((.:versions))"},
+							{Var: "db", Values: []interface{}{"postgres"}},
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			step := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:                   "some-task",
+				ConfigPath:             "some-resource/task.yml",
+				VersionedResourceTypes: resourceTypes,
+			})
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{Var: "go", ValuesFrom: "versions"},
+					{Var: "db", Values: []interface{}{"postgres"}},
+				},
+				Step: &step,
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -17,6 +17,28 @@ type InputMapper interface {
 		job db.Job,
 		resources db.Resources,
 	) (algorithm.InputMapping, error)
+
+	ExplainNextInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		resources db.Resources,
+	) (Explanation, error)
+}
+
+// Explanation describes how the next input mapping for a job is determined.
+type Explanation struct {
+	// Inputs explains the candidates for each input, by name. Inputs pinned
+	// to a version which cannot be found are left out.
+	Inputs map[string]algorithm.InputExplanation
+
+	// IndependentMapping has a version for each input which can be satisfied
+	// on its own.
+	IndependentMapping algorithm.InputMapping
+
+	// Mapping has a version for every input, and is nil if no versions
+	// satisfy all of them together.
+	Mapping algorithm.InputMapping
 }
 
 func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
@@ -38,32 +60,12 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	inputConfigs := job.Config().Inputs()
 
-	for i, inputConfig := range inputConfigs {
-		resource, found := resources.Lookup(inputConfig.Resource)
-
-		if !found {
-			logger.Debug("failed-to-find-resource")
-			continue
-		}
-
-		if resource.CurrentPinnedVersion() != nil {
-			inputConfigs[i].Version = &atc.VersionConfig{Pinned: resource.CurrentPinnedVersion()}
-		}
-	}
-
-	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
+	algorithmInputConfigs, err := i.algorithmInputConfigs(logger, versions, job, inputConfigs, resources)
 	if err != nil {
-		logger.Error("failed-to-get-algorithm-input-configs", err)
 		return nil, err
 	}
 
-	independentMapping := algorithm.InputMapping{}
-	for _, inputConfig := range algorithmInputConfigs {
-		singletonMapping, ok := algorithm.InputConfigs{inputConfig}.Resolve(versions)
-		if ok {
-			independentMapping[inputConfig.Name] = singletonMapping[inputConfig.Name]
-		}
-	}
+	independentMapping := resolveIndependently(versions, algorithmInputConfigs)
 
 	err = job.SaveIndependentInputMapping(independentMapping)
 	if err != nil {
@@ -99,3 +101,82 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+// ExplainNextInputMapping determines the next input mapping for a job the
+// same way as SaveNextInputMapping, but saves nothing.
+func (i *inputMapper) ExplainNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	resources db.Resources,
+) (Explanation, error) {
+	logger = logger.Session("explain-next-input-mapping")
+
+	inputConfigs := job.Config().Inputs()
+
+	algorithmInputConfigs, err := i.algorithmInputConfigs(logger, versions, job, inputConfigs, resources)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	explanation := Explanation{
+		Inputs:             map[string]algorithm.InputExplanation{},
+		IndependentMapping: resolveIndependently(versions, algorithmInputConfigs),
+	}
+
+	for _, inputConfig := range algorithmInputConfigs {
+		explanation.Inputs[inputConfig.Name] = inputConfig.Explain(versions)
+	}
+
+	if len(explanation.IndependentMapping) < len(inputConfigs) {
+		return explanation, nil
+	}
+
+	resolvedMapping, ok := algorithmInputConfigs.Resolve(versions)
+	if ok {
+		explanation.Mapping = resolvedMapping
+	}
+
+	return explanation, nil
+}
+
+func (i *inputMapper) algorithmInputConfigs(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	inputConfigs []atc.JobInput,
+	resources db.Resources,
+) (algorithm.InputConfigs, error) {
+	for i, inputConfig := range inputConfigs {
+		resource, found := resources.Lookup(inputConfig.Resource)
+
+		if !found {
+			logger.Debug("failed-to-find-resource")
+			continue
+		}
+
+		if resource.CurrentPinnedVersion() != nil {
+			inputConfigs[i].Version = &atc.VersionConfig{Pinned: resource.CurrentPinnedVersion()}
+		}
+	}
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, err
+	}
+
+	return algorithmInputConfigs, nil
+}
+
+func resolveIndependently(versions *algorithm.VersionsDB, inputConfigs algorithm.InputConfigs) algorithm.InputMapping {
+	independentMapping := algorithm.InputMapping{}
+	for _, inputConfig := range inputConfigs {
+		singletonMapping, ok := algorithm.InputConfigs{inputConfig}.Resolve(versions)
+		if ok {
+			independentMapping[inputConfig.Name] = singletonMapping[inputConfig.Name]
+		}
+	}
+
+	return independentMapping
+}
@@ -467,4 +467,147 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("ExplainNextInputMapping", func() {
+		var (
+			versionsDB   *algorithm.VersionsDB
+			fakeJob      *dbfakes.FakeJob
+			resources    db.Resources
+			explanation  inputmapper.Explanation
+			explainErr   error
+			inputConfigs algorithm.InputConfigs
+			transformErr error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+					{VersionID: 3, ResourceID: 12, CheckOrder: 2},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+						BuildID:         99,
+						JobID:           2,
+					},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "a"},
+					{Get: "b", Passed: []string{"upstream"}},
+				},
+			})
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "a",
+					ResourceID: 11,
+					Passed:     algorithm.JobSet{},
+					JobID:      1,
+				},
+				{
+					Name:       "b",
+					ResourceID: 12,
+					Passed:     algorithm.JobSet{2: struct{}{}},
+					JobID:      1,
+				},
+			}
+			transformErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeTransformer.TransformInputConfigsReturns(inputConfigs, transformErr)
+
+			explanation, explainErr = inputMapper.ExplainNextInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				resources,
+			)
+		})
+
+		It("explains each input", func() {
+			Expect(explainErr).NotTo(HaveOccurred())
+			Expect(explanation.Inputs).To(Equal(map[string]algorithm.InputExplanation{
+				"a": {
+					Name:       "a",
+					Candidates: []algorithm.CandidateExplanation{{VersionID: 1}},
+				},
+				"b": {
+					Name: "b",
+					Candidates: []algorithm.CandidateExplanation{
+						{VersionID: 3, NotPassed: []int{2}},
+						{VersionID: 2},
+					},
+				},
+			}))
+		})
+
+		It("resolves the mapping", func() {
+			Expect(explanation.IndependentMapping).To(Equal(algorithm.InputMapping{
+				"a": algorithm.InputVersion{VersionID: 1, ResourceID: 11, FirstOccurrence: true},
+				"b": algorithm.InputVersion{VersionID: 2, ResourceID: 12, FirstOccurrence: true},
+			}))
+			Expect(explanation.Mapping).To(Equal(explanation.IndependentMapping))
+		})
+
+		It("does not save anything", func() {
+			Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
+		})
+
+		Context("when an input can't be satisfied", func() {
+			BeforeEach(func() {
+				versionsDB.BuildOutputs = nil
+			})
+
+			It("has no mapping", func() {
+				Expect(explainErr).NotTo(HaveOccurred())
+				Expect(explanation.IndependentMapping).To(Equal(algorithm.InputMapping{
+					"a": algorithm.InputVersion{VersionID: 1, ResourceID: 11, FirstOccurrence: true},
+				}))
+				Expect(explanation.Mapping).To(BeNil())
+			})
+		})
+
+		Context("when a resource has a pinned version from the API", func() {
+			BeforeEach(func() {
+				fakeResource := new(dbfakes.FakeResource)
+				fakeResource.NameReturns("a")
+				fakeResource.CurrentPinnedVersionReturns(atc.Version{"version": "v1"})
+
+				resources = db.Resources{fakeResource}
+			})
+
+			It("transforms the inputs with the pinned version", func() {
+				Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+				_, _, actualJobInputs := fakeTransformer.TransformInputConfigsArgsForCall(0)
+				Expect(actualJobInputs).To(ContainElement(atc.JobInput{
+					Name:     "a",
+					Resource: "a",
+					Version:  &atc.VersionConfig{Pinned: atc.Version{"version": "v1"}},
+				}))
+			})
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				inputConfigs = nil
+				transformErr = disaster
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+	})
 })
@@ -4,6 +4,53 @@ type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// CredentialManager replaces the team's credential manager when the team
+	// is set. If it is not given, the team's credential manager is left as-is
+	// unless RemoveCredentialManager is true.
+	CredentialManager       *TeamCredentialManager `json:"credential_manager,omitempty"`
+	RemoveCredentialManager bool                   `json:"remove_credential_manager,omitempty"`
 }
 
 type TeamAuth map[string]map[string][]string
+
+// TeamCredentialManager configures a credential manager used for a single
+// team in place of those configured for the whole ATC.
+//
+// Config holds the manager's options, named as its flags without their
+// prefix, e.g. "url" for --vault-url.
+type TeamCredentialManager struct {
+	Type   string                 `json:"type" yaml:"type"`
+	Config map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// UnmarshalYAML converts any maps within the config to have string keys, as
+// required to encode it as JSON.
+func (manager *TeamCredentialManager) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw struct {
+		Type   string                 `yaml:"type"`
+		Config map[string]interface{} `yaml:"config"`
+	}
+
+	err := unmarshal(&raw)
+	if err != nil {
+		return err
+	}
+
+	manager.Type = raw.Type
+	manager.Config = nil
+
+	if raw.Config != nil {
+		manager.Config = map[string]interface{}{}
+		for name, value := range raw.Config {
+			sanitized, err := sanitize(value)
+			if err != nil {
+				return err
+			}
+
+			manager.Config[name] = sanitized
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("TeamCredentialManager", func() {
+	Describe("UnmarshalYAML", func() {
+		It("converts maps within the config to have string keys", func() {
+			var manager atc.TeamCredentialManager
+			err := yaml.Unmarshal([]byte(`
+type: vault
+config:
+  url: https://vault.example.com
+  auth-backend: approle
+  auth-param:
+    role_id: some-role
+  insecure-skip-verify: true
+`), &manager)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manager).To(Equal(atc.TeamCredentialManager{
+				Type: "vault",
+				Config: map[string]interface{}{
+					"url":                  "https://vault.example.com",
+					"auth-backend":         "approle",
+					"auth-param":           map[string]interface{}{"role_id": "some-role"},
+					"insecure-skip-verify": true,
+				},
+			}))
+		})
+	})
+})
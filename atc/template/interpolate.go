@@ -0,0 +1,265 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"gopkg.in/yaml.v2"
+)
+
+// interpolationRegex matches every kind of var reference: plain vars, e.g.
+// ((some-var.some-field)), local vars, e.g. ((.:some-var)), vars from a named
+// source, e.g. ((vault:some/path)), and vars pinned to a version, e.g.
+// ((some/path?version=2)).
+//
+// As in bosh-cli, a var may be marked with a leading '!', e.g. ((!some-var)),
+// which is ignored when resolving it.
+//
+// The interpolator in bosh-cli does not allow ':' or '?' in variable names,
+// so all of them are resolved here instead.
+var (
+	interpolationRegex         = regexp.MustCompile(`\(\(!?(?:(\.|[-\w\pL]+):)?([-/\.\w\pL]+)(\?version=\d+)?\)\)`)
+	interpolationAnchoredRegex = regexp.MustCompile("\\A" + interpolationRegex.String() + "\\z")
+)
+
+type InterpolateOpts struct {
+	// ExpectAllKeys makes referring to a var which is not found an error.
+	// Otherwise the reference is left in place.
+	ExpectAllKeys bool
+
+	// LocalVars resolves local vars. They only exist within a build, so
+	// elsewhere they are left in place.
+	LocalVars bool
+}
+
+// Interpolate resolves every var referred to in the given YAML (or JSON)
+// payload, in both keys and values.
+//
+// Each string is scanned for references once, and the values they are
+// replaced with are never scanned again. A value which itself looks like a
+// reference, e.g. a secret or a local var set from the contents of a file,
+// is left as-is rather than being resolved in turn.
+//
+// A reference which fills an entire value is replaced with the var's value
+// as-is, preserving its type. Otherwise the value must be a string or an
+// integer.
+//
+// Plain vars which are not found are reported together, so that everything
+// missing from e.g. a pipeline can be fixed at once.
+func Interpolate(payload []byte, vars boshtemplate.Variables, opts InterpolateOpts) ([]byte, error) {
+	var node interface{}
+	err := yaml.Unmarshal(payload, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	interpolator := interpolator{
+		vars:    vars,
+		opts:    opts,
+		missing: map[string]bool{},
+	}
+
+	node, err = interpolator.interpolate(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ExpectAllKeys && len(interpolator.missing) > 0 {
+		names := []string{}
+		for name := range interpolator.missing {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("Expected to find variables: %s", strings.Join(names, "\n"))
+	}
+
+	return yaml.Marshal(node)
+}
+
+type interpolator struct {
+	vars boshtemplate.Variables
+	opts InterpolateOpts
+
+	missing map[string]bool
+}
+
+func (i interpolator) interpolate(node interface{}) (interface{}, error) {
+	switch typedNode := node.(type) {
+	case map[interface{}]interface{}:
+		evaluatedNode := make(map[interface{}]interface{}, len(typedNode))
+		for k, v := range typedNode {
+			evaluatedKey, err := i.interpolate(k)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluatedValue, err := i.interpolate(v)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluatedNode[evaluatedKey] = evaluatedValue
+		}
+
+		return evaluatedNode, nil
+
+	case []interface{}:
+		for idx, v := range typedNode {
+			evaluated, err := i.interpolate(v)
+			if err != nil {
+				return nil, err
+			}
+
+			typedNode[idx] = evaluated
+		}
+
+	case string:
+		return i.interpolateString(typedNode)
+	}
+
+	return node, nil
+}
+
+// interpolateString builds the result from the segments of the original
+// string, so that the values of vars are not scanned for references.
+func (i interpolator) interpolateString(str string) (interface{}, error) {
+	matches := interpolationRegex.FindAllStringSubmatchIndex(str, -1)
+	if len(matches) == 0 {
+		return str, nil
+	}
+
+	var result strings.Builder
+
+	last := 0
+	for _, loc := range matches {
+		match := make([]string, len(loc)/2)
+		for g := range match {
+			if loc[2*g] != -1 {
+				match[g] = str[loc[2*g]:loc[2*g+1]]
+			}
+		}
+
+		result.WriteString(str[last:loc[0]])
+		last = loc[1]
+
+		ref := varReference{
+			source:  match[1],
+			path:    match[2],
+			version: match[3],
+		}
+
+		val, found, err := i.lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			result.WriteString(match[0])
+			continue
+		}
+
+		// ensure that value type is preserved when replacing the entire field
+		if interpolationAnchoredRegex.MatchString(str) {
+			return val, nil
+		}
+
+		switch val.(type) {
+		case string, int, int16, int32, int64, uint, uint16, uint32, uint64:
+			result.WriteString(fmt.Sprintf("%v", val))
+		default:
+			return nil, fmt.Errorf("%s '%s' has type '%T', which cannot be interpolated within a string", ref.kind(), ref.name(), val)
+		}
+	}
+
+	result.WriteString(str[last:])
+
+	return result.String(), nil
+}
+
+func (i interpolator) lookup(ref varReference) (interface{}, bool, error) {
+	switch {
+	case ref.isLocal():
+		if !i.opts.LocalVars {
+			return nil, false, nil
+		}
+
+		val, found, err := LookupLocalVar(i.vars, ref.path)
+		if err == nil && !found && i.opts.ExpectAllKeys {
+			return nil, false, fmt.Errorf("undefined local var: %s", ref.name())
+		}
+
+		return val, found, err
+
+	case ref.source != "" || ref.version != "":
+		prefix := ""
+		if ref.source != "" {
+			prefix = ref.source + VarSourceSeparator
+		}
+
+		val, found, err := lookupVar(i.vars, prefix, ref.path, ref.version)
+		if err == nil && !found && i.opts.ExpectAllKeys {
+			return nil, false, fmt.Errorf("undefined var: %s", ref.name())
+		}
+
+		return val, found, err
+
+	default:
+		val, found, err := lookupVar(i.vars, "", ref.path, "")
+		if err == nil && !found {
+			i.missing[strings.Split(ref.path, ".")[0]] = true
+		}
+
+		return val, found, err
+	}
+}
+
+type varReference struct {
+	source  string
+	path    string
+	version string
+}
+
+func (ref varReference) isLocal() bool {
+	return ref.source == "."
+}
+
+func (ref varReference) kind() string {
+	if ref.isLocal() {
+		return "local var"
+	}
+
+	return "var"
+}
+
+func (ref varReference) name() string {
+	if ref.isLocal() || ref.source == "" {
+		return ref.path + ref.version
+	}
+
+	return ref.source + VarSourceSeparator + ref.path + ref.version
+}
+
+// lookupFields walks the fields which follow a var's name in a reference,
+// e.g. some-var.some-field, within the var's value.
+func lookupFields(val interface{}, kind string, name string, fields []string) (interface{}, error) {
+	for _, field := range fields {
+		var found bool
+		switch typedVal := val.(type) {
+		case map[interface{}]interface{}:
+			val, found = typedVal[field]
+		case map[string]interface{}:
+			val, found = typedVal[field]
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%s '%s' has no field '%s'", kind, name, field)
+		}
+	}
+
+	return val, nil
+}
@@ -0,0 +1,94 @@
+package template_test
+
+import (
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/template"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolate", func() {
+	var (
+		staticVars boshtemplate.StaticVariables
+		localVars  *creds.LocalVariables
+		vars       creds.Variables
+	)
+
+	BeforeEach(func() {
+		staticVars = boshtemplate.StaticVariables{
+			"env":          "prod",
+			"vault:secret": "some-secret",
+			"ssm:/token":   "some-token",
+		}
+
+		localVars = creds.NewLocalVariables()
+		vars = creds.NewBuildVariables(staticVars, localVars, creds.NewBuildCredentials())
+	})
+
+	It("interpolates every kind of var together", func() {
+		localVars.Set("version", "1.2.3")
+
+		result, err := template.Interpolate([]byte(`
+((env))-key: ((env))/((.:version))/((vault:secret))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`prod-key: prod/1.2.3/some-secret`))
+	})
+
+	It("ignores a leading '!' on any kind of var, as bosh-cli does", func() {
+		localVars.Set("version", "1.2.3")
+
+		result, err := template.Interpolate([]byte(`
+((!env))-key: ((!env))/((!.:version))/((!vault:secret))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`prod-key: prod/1.2.3/some-secret`))
+	})
+
+	It("does not resolve references within the value of a local var", func() {
+		localVars.Set("contents", "((vault:secret)) ((env)) ((ssm:/token?version=1))")
+
+		result, err := template.Interpolate([]byte(`
+entire: ((.:contents))
+partial: file:((.:contents))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`
+entire: ((vault:secret)) ((env)) ((ssm:/token?version=1))
+partial: file:((vault:secret)) ((env)) ((ssm:/token?version=1))
+`))
+	})
+
+	It("does not resolve references within the value of a var", func() {
+		staticVars["vault:secret"] = "((env))"
+		staticVars["env"] = "((.:version))"
+
+		result, err := template.Interpolate([]byte(`
+secret: ((vault:secret))
+env: v-((env))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`
+secret: ((env))
+env: v-((.:version))
+`))
+	})
+
+	Context("when plain vars are not defined", func() {
+		It("reports all of them if expectAllKeys = true", func() {
+			_, err := template.Interpolate([]byte(`
+a: ((bogus-b.field))
+b: ((bogus-a))
+c: ((bogus-b))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+			Expect(err).To(MatchError("Expected to find variables: bogus-a\nbogus-b"))
+		})
+
+		It("leaves them in place if expectAllKeys = false", func() {
+			result, err := template.Interpolate([]byte(`a: ((bogus))-((env))`), vars, template.InterpolateOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(MatchYAML(`a: ((bogus))-prod`))
+		})
+	})
+})
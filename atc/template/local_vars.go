@@ -0,0 +1,52 @@
+package template
+
+import (
+	"regexp"
+	"strings"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// LocalVarPrefix is the prefix of variables local to a build, e.g.
+// ((.:some-var)). These are set by steps such as load_var.
+const LocalVarPrefix = ".:"
+
+var (
+	localVarRegex         = regexp.MustCompile(`\(\(\.:([-/\.\w\pL]+)\)\)`)
+	localVarAnchoredRegex = regexp.MustCompile("\\A" + localVarRegex.String() + "\\z")
+)
+
+// ParseLocalVarReference returns the name of the local var referred to by the
+// given string, if the string consists of a single reference, e.g.
+// ((.:some-var)).
+func ParseLocalVarReference(ref string) (string, bool) {
+	match := localVarAnchoredRegex.FindStringSubmatch(ref)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// PresentLocalVars returns true if the content refers to any local vars.
+func PresentLocalVars(content []byte) bool {
+	return localVarRegex.Match(content)
+}
+
+// LookupLocalVar returns the value of a local var, which may refer to a field
+// within the var, e.g. some-var.some-field.
+func LookupLocalVar(vars boshtemplate.Variables, ref string) (interface{}, bool, error) {
+	segs := strings.Split(ref, ".")
+
+	val, found, err := vars.Get(boshtemplate.VariableDefinition{Name: LocalVarPrefix + segs[0]})
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	val, err = lookupFields(val, "local var", segs[0], segs[1:])
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
@@ -0,0 +1,78 @@
+package template_test
+
+import (
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/template"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolate with local vars", func() {
+	var vars creds.Variables
+
+	BeforeEach(func() {
+		localVars := creds.NewLocalVariables()
+		localVars.Set("version", "1.2.3")
+		localVars.Set("number", 42)
+		localVars.Set("metadata", map[interface{}]interface{}{
+			"ref":  "abcdef",
+			"tags": []interface{}{"a", "b"},
+		})
+
+		vars = creds.NewBuildVariables(boshtemplate.StaticVariables{}, localVars, creds.NewBuildCredentials())
+	})
+
+	It("leaves local vars in place unless they are enabled", func() {
+		result, err := template.Interpolate([]byte(`version: ((.:version))`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`version: ((.:version))`))
+	})
+
+	It("interpolates local vars, preserving the type of entire values", func() {
+		result, err := template.Interpolate([]byte(`
+version: ((.:version))
+number: ((.:number))
+tags: ((.:metadata.tags))
+tag: v((.:version))-((.:metadata.ref))-((.:number))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`
+version: "1.2.3"
+number: 42
+tags: [a, b]
+tag: v1.2.3-abcdef-42
+`))
+	})
+
+	It("fails to interpolate non-scalar values within a string", func() {
+		_, err := template.Interpolate([]byte(`tags: some-((.:metadata.tags))`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cannot be interpolated within a string"))
+	})
+
+	Context("when a local var is not defined", func() {
+		It("fails if expectAllKeys = true", func() {
+			_, err := template.Interpolate([]byte(`version: ((.:bogus))`), vars, template.InterpolateOpts{ExpectAllKeys: true, LocalVars: true})
+			Expect(err).To(MatchError("undefined local var: bogus"))
+		})
+
+		It("leaves it in place if expectAllKeys = false", func() {
+			result, err := template.Interpolate([]byte(`version: ((.:bogus))`), vars, template.InterpolateOpts{ExpectAllKeys: false, LocalVars: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(MatchYAML(`version: ((.:bogus))`))
+		})
+	})
+
+	It("is applied by the TemplateResolver", func() {
+		result, err := template.NewTemplateResolver([]byte(`
+version: ((.:version))
+env: ((env))
+`), []boshtemplate.Variables{boshtemplate.StaticVariables{"env": "prod"}, vars}).Resolve(true, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`
+version: "1.2.3"
+env: prod
+`))
+	})
+})
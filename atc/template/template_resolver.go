@@ -44,12 +44,10 @@ func (resolver TemplateResolver) Resolve(expectAllKeys bool, allowEmptyInOldStyl
 }
 
 func (resolver TemplateResolver) resolve(expectAllKeys bool) ([]byte, error) {
-	tpl := boshtemplate.NewTemplate(resolver.configPayload)
-	bytes, err := tpl.Evaluate(boshtemplate.NewMultiVars(resolver.params), nil, boshtemplate.EvaluateOpts{ExpectAllKeys: expectAllKeys})
-	if err != nil {
-		return nil, err
-	}
-	return bytes, nil
+	return Interpolate(resolver.configPayload, boshtemplate.NewMultiVars(resolver.params), InterpolateOpts{
+		ExpectAllKeys: expectAllKeys,
+		LocalVars:     true,
+	})
 }
 
 func (resolver TemplateResolver) ResolveDeprecated(allowEmpty bool) ([]byte, error) {
@@ -0,0 +1,122 @@
+package template
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarSourceSeparator separates the name of a var source, i.e. a credential
+// manager, from the path of a var within it, e.g. ((vault:some/path.field)).
+const VarSourceSeparator = ":"
+
+// local vars, e.g. ((.:some-var)), are matched by localVarRegex instead, as
+// '.' is not allowed in source names
+var (
+	sourceVarRegex = regexp.MustCompile(`\(\(([-\w\pL]+):([-/\.\w\pL]+)(\?version=\d+)?\)\)`)
+
+	varRefRegex = regexp.MustCompile(`\(\((([-\w\pL]+:)?[-/\.\w\pL]+(\?version=\d+)?)\)\)`)
+)
+
+// PresentSourceVars returns true if the content refers to any vars from a
+// named source.
+func PresentSourceVars(content []byte) bool {
+	return sourceVarRegex.Match(content)
+}
+
+// SourceVarNames returns the names of the sources referred to in the content,
+// in sorted order.
+func SourceVarNames(content []byte) []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, match := range sourceVarRegex.FindAllSubmatch(content, -1) {
+		name := string(match[1])
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// VarRefs returns the distinct vars referred to in the content, in the order
+// they first appear, e.g. "some-var.some-field" or "vault:some/path?version=2".
+// Local vars are ignored, as they are only set while a build is running.
+func VarRefs(content []byte) []string {
+	seen := map[string]bool{}
+	refs := []string{}
+
+	for _, match := range varRefRegex.FindAllSubmatch(content, -1) {
+		ref := string(match[1])
+		if seen[ref] {
+			continue
+		}
+
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// LookupVarRef returns the value of a var referred to as returned by VarRefs,
+// resolving it the same way as when the content is interpolated.
+func LookupVarRef(vars boshtemplate.Variables, ref string) (interface{}, bool, error) {
+	version := ""
+	if i := strings.Index(ref, "?"); i != -1 {
+		ref, version = ref[:i], ref[i:]
+	}
+
+	prefix := ""
+	if source, path, ok := ParseSourceVarName(ref); ok {
+		prefix, ref = source+VarSourceSeparator, path
+	}
+
+	return lookupVar(vars, prefix, ref, version)
+}
+
+// LookupSourceVar returns the value of a var from the named source, which may
+// refer to a field within the var, e.g. some/path.some-field.
+func LookupSourceVar(vars boshtemplate.Variables, source string, ref string) (interface{}, bool, error) {
+	return lookupVar(vars, source+VarSourceSeparator, ref, "")
+}
+
+func lookupVar(vars boshtemplate.Variables, prefix string, ref string, version string) (interface{}, bool, error) {
+	segs := strings.Split(ref, ".")
+	name := prefix + segs[0] + version
+
+	val, found, err := vars.Get(boshtemplate.VariableDefinition{Name: name})
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	val, err = lookupFields(val, "var", name, segs[1:])
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
+
+// ParseSourceVarName splits the name of a var looked up from a named source
+// into the name of the source and the path of the var within it.
+func ParseSourceVarName(name string) (string, string, bool) {
+	if strings.HasPrefix(name, LocalVarPrefix) {
+		return "", "", false
+	}
+
+	segs := strings.SplitN(name, VarSourceSeparator, 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		return "", "", false
+	}
+
+	return segs[0], segs[1], true
+}
@@ -0,0 +1,175 @@
+package template_test
+
+import (
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/template"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolate with source vars", func() {
+	var vars boshtemplate.StaticVariables
+
+	BeforeEach(func() {
+		vars = boshtemplate.StaticVariables{
+			"vault:team/db": map[interface{}]interface{}{
+				"username": "some-user",
+				"port":     5432,
+			},
+			"ssm:/prod/token": "some-token",
+		}
+	})
+
+	It("interpolates source vars, preserving the type of entire values", func() {
+		result, err := template.Interpolate([]byte(`
+db: ((vault:team/db))
+port: ((vault:team/db.port))
+token: ((ssm:/prod/token))
+uri: ((vault:team/db.username))@db:((vault:team/db.port))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`
+db:
+  username: some-user
+  port: 5432
+port: 5432
+token: some-token
+uri: some-user@db:5432
+`))
+	})
+
+	It("fails when a field is missing", func() {
+		_, err := template.Interpolate([]byte(`password: ((vault:team/db.password))`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+		Expect(err).To(MatchError("var 'vault:team/db' has no field 'password'"))
+	})
+
+	Context("when a source var is not defined", func() {
+		It("fails if expectAllKeys = true", func() {
+			_, err := template.Interpolate([]byte(`token: ((vault:bogus))`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+			Expect(err).To(MatchError("undefined var: vault:bogus"))
+		})
+
+		It("leaves it in place if expectAllKeys = false", func() {
+			result, err := template.Interpolate([]byte(`token: ((vault:bogus))`), vars, template.InterpolateOpts{ExpectAllKeys: false})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(MatchYAML(`token: ((vault:bogus))`))
+		})
+	})
+
+	Context("when vars are pinned to a version", func() {
+		BeforeEach(func() {
+			vars["vault:team/db?version=2"] = map[interface{}]interface{}{
+				"username": "old-user",
+			}
+			vars["team/token?version=3"] = "old-token"
+		})
+
+		It("looks them up with the version as part of the name", func() {
+			result, err := template.Interpolate([]byte(`
+username: ((vault:team/db.username?version=2))
+token: ((team/token?version=3))
+`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(MatchYAML(`
+username: old-user
+token: old-token
+`))
+		})
+
+		It("fails if the version is not defined", func() {
+			_, err := template.Interpolate([]byte(`token: ((team/token?version=4))`), vars, template.InterpolateOpts{ExpectAllKeys: true})
+			Expect(err).To(MatchError("undefined var: team/token?version=4"))
+		})
+	})
+
+	It("is applied by the TemplateResolver", func() {
+		result, err := template.NewTemplateResolver([]byte(`
+token: ((ssm:/prod/token))
+env: ((env))
+`), []boshtemplate.Variables{boshtemplate.StaticVariables{"env": "prod"}, vars}).Resolve(true, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchYAML(`
+token: some-token
+env: prod
+`))
+	})
+})
+
+var _ = Describe("SourceVarNames", func() {
+	It("returns the distinct sources referred to, ignoring local vars", func() {
+		Expect(template.SourceVarNames([]byte(`
+a: ((vault:a))
+b: ((ssm:/b)) and ((vault:b.field))
+c: ((.:local))
+d: ((plain))
+`))).To(Equal([]string{"ssm", "vault"}))
+	})
+})
+
+var _ = Describe("ParseSourceVarName", func() {
+	It("splits the source from the path", func() {
+		source, path, ok := template.ParseSourceVarName("ssm:/prod/db")
+		Expect(ok).To(BeTrue())
+		Expect(source).To(Equal("ssm"))
+		Expect(path).To(Equal("/prod/db"))
+	})
+
+	It("does not parse plain or local var names", func() {
+		_, _, ok := template.ParseSourceVarName("plain")
+		Expect(ok).To(BeFalse())
+
+		_, _, ok = template.ParseSourceVarName(".:local")
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("VarRefs", func() {
+	It("returns the distinct vars referred to in order, ignoring local vars", func() {
+		Expect(template.VarRefs([]byte(`
+a: ((plain.field))
+b: ((vault:b/path.field)) and ((plain.field))
+c: ((.:local))
+d: ((some/path?version=2))
+`))).To(Equal([]string{"plain.field", "vault:b/path.field", "some/path?version=2"}))
+	})
+})
+
+var _ = Describe("LookupVarRef", func() {
+	var vars boshtemplate.StaticVariables
+
+	BeforeEach(func() {
+		vars = boshtemplate.StaticVariables{
+			"plain":               map[interface{}]interface{}{"field": "plain-value"},
+			"vault:team/db":       map[interface{}]interface{}{"username": "some-user"},
+			"some/path?version=2": "versioned-value",
+		}
+	})
+
+	It("looks up plain, source and versioned vars", func() {
+		value, found, err := template.LookupVarRef(vars, "plain.field")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("plain-value"))
+
+		value, found, err = template.LookupVarRef(vars, "vault:team/db.username")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-user"))
+
+		value, found, err = template.LookupVarRef(vars, "some/path?version=2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("versioned-value"))
+	})
+
+	It("reports vars which are not found", func() {
+		_, found, err := template.LookupVarRef(vars, "vault:missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("fails when a field is missing", func() {
+		_, _, err := template.LookupVarRef(vars, "plain.bogus")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+type Config struct {
+	ServiceName string `long:"service-name" default:"concourse-web" description:"Service name to attach to exported traces."`
+
+	Jaeger JaegerConfig
+	OTLP   OTLPConfig
+}
+
+type JaegerConfig struct {
+	Endpoint string `long:"jaeger-endpoint" description:"URL of a Jaeger collector's OTLP/HTTP endpoint to send spans to, e.g. http://jaeger:4318."`
+}
+
+type OTLPConfig struct {
+	Address string            `long:"otlp-address" description:"URL of an OpenTelemetry collector to send spans to over OTLP/HTTP, e.g. http://otel-collector:4318."`
+	Headers map[string]string `long:"otlp-header"  description:"A header to send along with each batch of spans. Can be specified multiple times." value-name:"NAME:VALUE"`
+}
+
+// IsConfigured is true if an exporter has been configured.
+func (config Config) IsConfigured() bool {
+	return config.Jaeger.Endpoint != "" || config.OTLP.Address != ""
+}
+
+// TraceProvider constructs a TracerProvider which exports spans in batches
+// through the configured exporter. It must be shut down to flush any spans
+// which are still buffered.
+func (config Config) TraceProvider() (*sdktrace.TracerProvider, error) {
+	exporter, err := config.Exporter()
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(config.ServiceName),
+		)),
+	), nil
+}
+
+// Exporter constructs the configured exporter. Only one may be configured.
+func (config Config) Exporter() (sdktrace.SpanExporter, error) {
+	if config.Jaeger.Endpoint != "" && config.OTLP.Address != "" {
+		return nil, errors.New("only one of --tracing-jaeger-endpoint and --tracing-otlp-address may be configured")
+	}
+
+	// Jaeger collectors receive spans over OTLP
+	if config.Jaeger.Endpoint != "" {
+		return otlpExporter(config.Jaeger.Endpoint, nil)
+	}
+
+	if config.OTLP.Address != "" {
+		return otlpExporter(config.OTLP.Address, config.OTLP.Headers)
+	}
+
+	return nil, errors.New("no tracing exporter configured")
+}
+
+func otlpExporter(address string, headers map[string]string) (sdktrace.SpanExporter, error) {
+	endpoint, err := url.Parse(address)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid tracing endpoint: %q", address)
+	}
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpoint.Host),
+		otlptracehttp.WithURLPath(strings.TrimRight(endpoint.Path, "/") + "/v1/traces"),
+		otlptracehttp.WithHeaders(headers),
+		otlptracehttp.WithTimeout(time.Minute),
+	}
+
+	if endpoint.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	return otlptracehttp.New(context.Background(), options...)
+}
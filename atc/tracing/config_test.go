@@ -0,0 +1,67 @@
+package tracing_test
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	var config tracing.Config
+
+	BeforeEach(func() {
+		config = tracing.Config{ServiceName: "concourse-web"}
+	})
+
+	It("is not configured without an exporter", func() {
+		Expect(config.IsConfigured()).To(BeFalse())
+
+		_, err := config.TraceProvider()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("constructs an OTLP exporter for Jaeger", func() {
+		config.Jaeger.Endpoint = "http://jaeger:4318"
+		Expect(config.IsConfigured()).To(BeTrue())
+
+		exporter, err := config.Exporter()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exporter).To(BeAssignableToTypeOf(&otlptrace.Exporter{}))
+	})
+
+	It("constructs an OTLP exporter", func() {
+		config.OTLP.Address = "http://otel-collector:4318"
+		Expect(config.IsConfigured()).To(BeTrue())
+
+		exporter, err := config.Exporter()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exporter).To(BeAssignableToTypeOf(&otlptrace.Exporter{}))
+	})
+
+	It("errors when the endpoint is not a URL", func() {
+		config.OTLP.Address = "otel-collector:4318"
+
+		_, err := config.Exporter()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("constructs a trace provider", func() {
+		config.OTLP.Address = "http://otel-collector:4318"
+
+		provider, err := config.TraceProvider()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(provider.Shutdown(context.Background())).To(Succeed())
+	})
+
+	It("errors when both are configured", func() {
+		config.Jaeger.Endpoint = "http://jaeger:4318"
+		config.OTLP.Address = "http://otel-collector:4318"
+
+		_, err := config.Exporter()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/felixge/httpsnoop"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type tracingHandler struct {
+	route   string
+	handler http.Handler
+}
+
+// WrapHandler serves each request within a span named after the route,
+// continuing any trace propagated through the request's traceparent header.
+func WrapHandler(route string, handler http.Handler) http.Handler {
+	return tracingHandler{
+		route:   route,
+		handler: handler,
+	}
+}
+
+func (h tracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	ctx, span := StartSpan(ctx, h.route, Attrs{
+		"http.method": r.Method,
+		"http.target": r.URL.Path,
+	})
+	defer span.End()
+
+	metrics := httpsnoop.CaptureMetrics(h.handler, w, r.WithContext(ctx))
+
+	span.SetAttributes(attribute.Int("http.status_code", metrics.Code))
+}
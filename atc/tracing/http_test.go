@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WrapHandler", func() {
+	var (
+		recorder *tracetest.SpanRecorder
+
+		handlerSpan trace.SpanContext
+		handler     http.Handler
+	)
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		tracing.ConfigureTraceProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+		handler = tracing.WrapHandler("SomeRoute", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerSpan = trace.SpanContextFromContext(r.Context())
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	})
+
+	AfterEach(func() {
+		tracing.ConfigureTraceProvider(nil)
+	})
+
+	It("serves the request within a span for the route", func() {
+		req := httptest.NewRequest("PUT", "/some/path", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, req)
+		Expect(response.Code).To(Equal(http.StatusTeapot))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("SomeRoute"))
+		Expect(spans[0].SpanContext()).To(Equal(handlerSpan))
+		Expect(spans[0].SpanContext().TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+		Expect(spans[0].Parent().SpanID().String()).To(Equal("00f067aa0ba902b7"))
+		Expect(spans[0].Attributes()).To(ConsistOf(
+			attribute.String("http.method", "PUT"),
+			attribute.String("http.target", "/some/path"),
+			attribute.Int("http.status_code", http.StatusTeapot),
+		))
+	})
+})
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceParentEnv is the environment variable through which the trace context
+// is propagated into containers, in the W3C Trace Context format.
+const TraceParentEnv = "TRACEPARENT"
+
+const instrumentationName = "github.com/concourse/concourse/atc/tracing"
+
+// Attrs are attributes attached to a span, e.g. the team and pipeline of a
+// build.
+type Attrs map[string]string
+
+func (attrs Attrs) keyValues() []attribute.KeyValue {
+	keyValues := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		keyValues = append(keyValues, attribute.String(k, v))
+	}
+
+	return keyValues
+}
+
+var (
+	tracer trace.Tracer
+
+	// Configured is true once a TracerProvider has been configured, and spans
+	// are being recorded.
+	Configured bool
+
+	// Propagator carries the trace context across process boundaries, through
+	// traceparent headers and the TRACEPARENT env var.
+	Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+)
+
+// ConfigureTraceProvider sets the TracerProvider through which spans are
+// recorded. Until it is called, or after it is called with nil, StartSpan
+// returns spans which are discarded.
+func ConfigureTraceProvider(provider trace.TracerProvider) {
+	Configured = provider != nil
+	if Configured {
+		tracer = provider.Tracer(instrumentationName)
+	}
+}
+
+// StartSpan starts a span as a child of the span in the given context, if
+// any, and returns a context containing the new span. The span must be ended
+// by the caller.
+func StartSpan(ctx context.Context, name string, attrs Attrs) (context.Context, trace.Span) {
+	if !Configured {
+		// the span of an empty context is a no-op
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs.keyValues()...))
+}
+
+// Env returns the environment variables which propagate the span in the given
+// context into a process, or nil if there is no span.
+func Env(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	Propagator.Inject(ctx, carrier)
+
+	traceParent, found := carrier["traceparent"]
+	if !found {
+		return nil
+	}
+
+	return []string{TraceParentEnv + "=" + traceParent}
+}
@@ -0,0 +1,97 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing", func() {
+	Context("when not configured", func() {
+		BeforeEach(func() {
+			tracing.ConfigureTraceProvider(nil)
+		})
+
+		It("returns spans which are not recorded", func() {
+			ctx, span := tracing.StartSpan(context.Background(), "some-span", nil)
+			Expect(span.IsRecording()).To(BeFalse())
+			Expect(span.SpanContext().IsValid()).To(BeFalse())
+			Expect(tracing.Env(ctx)).To(BeEmpty())
+
+			span.End()
+		})
+	})
+
+	Context("when configured", func() {
+		var recorder *tracetest.SpanRecorder
+
+		BeforeEach(func() {
+			recorder = tracetest.NewSpanRecorder()
+			tracing.ConfigureTraceProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		})
+
+		AfterEach(func() {
+			tracing.ConfigureTraceProvider(nil)
+		})
+
+		It("records ended spans with their parents and attributes", func() {
+			ctx, parent := tracing.StartSpan(context.Background(), "parent", tracing.Attrs{"team": "main"})
+			_, child := tracing.StartSpan(ctx, "child", nil)
+
+			child.SetAttributes(attribute.String("succeeded", "false"))
+			child.RecordError(errors.New("nope"))
+			child.End()
+			parent.End()
+
+			spans := recorder.Ended()
+			Expect(spans).To(HaveLen(2))
+
+			Expect(spans[0].Name()).To(Equal("child"))
+			Expect(spans[0].SpanContext().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+			Expect(spans[0].Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			Expect(spans[0].Attributes()).To(ConsistOf(attribute.String("succeeded", "false")))
+			Expect(spans[0].Events()).To(HaveLen(1))
+			Expect(spans[0].Events()[0].Attributes).To(ContainElement(attribute.String("exception.message", "nope")))
+
+			Expect(spans[1].Name()).To(Equal("parent"))
+			Expect(spans[1].Parent().IsValid()).To(BeFalse())
+			Expect(spans[1].Attributes()).To(ConsistOf(attribute.String("team", "main")))
+		})
+
+		It("continues traces propagated from elsewhere", func() {
+			ctx := tracing.Propagator.Extract(context.Background(), propagation.MapCarrier{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			})
+
+			_, span := tracing.StartSpan(ctx, "some-span", nil)
+			Expect(span.SpanContext().TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			span.End()
+
+			spans := recorder.Ended()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Parent().SpanID().String()).To(Equal("00f067aa0ba902b7"))
+		})
+
+		It("propagates the span into a process's env", func() {
+			ctx, span := tracing.StartSpan(context.Background(), "some-span", nil)
+
+			sc := span.SpanContext()
+			Expect(tracing.Env(ctx)).To(Equal([]string{
+				"TRACEPARENT=00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01",
+			}))
+		})
+
+		It("does not propagate anything without a span", func() {
+			Expect(tracing.Env(trace.ContextWithSpanContext(context.Background(), trace.SpanContext{}))).To(BeNil())
+		})
+	})
+})
@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	atctemplate "github.com/concourse/concourse/atc/template"
 )
 
 func formatErr(groupName string, err error) string {
@@ -120,6 +122,10 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.Webhook != nil {
+			errorMessages = append(errorMessages, validateWebhook(identifier, *resource.Webhook)...)
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -127,6 +133,47 @@ func validateResources(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateWebhook(identifier string, webhook WebhookConfig) []string {
+	errorMessages := []string{}
+
+	switch webhook.Provider {
+	case "":
+		if webhook.Secret != "" {
+			errorMessages = append(errorMessages, identifier+".webhook has a secret but no provider")
+		}
+	case WebhookProviderGitHub, WebhookProviderGitLab, WebhookProviderBitbucket:
+		if webhook.Secret == "" {
+			errorMessages = append(errorMessages, identifier+".webhook has no secret")
+		}
+	default:
+		errorMessages = append(errorMessages,
+			fmt.Sprintf("%s.webhook has an unknown provider ('%s')", identifier, webhook.Provider))
+	}
+
+	// versions are only read from payloads which have been verified, so that
+	// no one else can choose the version a check runs from
+	if len(webhook.Version) > 0 && webhook.Provider == "" {
+		errorMessages = append(errorMessages, identifier+".webhook has a version but no provider")
+	}
+
+	fields := []string{}
+	for field := range webhook.Version {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		pointer := webhook.Version[field]
+		if pointer != "" && !strings.HasPrefix(pointer, "/") {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf("%s.webhook.version.%s is not a JSON pointer ('%s')", identifier, field, pointer))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateResourceTypes(c Config) error {
 	errorMessages := []string{}
 
@@ -334,10 +381,22 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
 	if valid, message := foundTypes.IsValid(); !valid {
 		return []ConfigWarning{}, []string{message}
 	}
@@ -362,6 +421,21 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		if plan.InParallel.Limit < 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s.in_parallel.limit must be a positive integer", identifier),
+			)
+		}
+
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
@@ -502,6 +576,40 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any pipeline configuration file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if strings.Contains(plan.LoadVar, ".") {
+			errorMessages = append(errorMessages, identifier+" has an invalid name; local var names may not contain '.'")
+		}
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any file")
+		}
+
+		switch plan.Format {
+		case "", LoadVarFormatRaw, LoadVarFormatTrim, LoadVarFormatJSON, LoadVarFormatYAML, LoadVarFormatYML:
+		default:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has an unknown format '%s'", identifier, plan.Format))
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -557,9 +665,53 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if len(plan.Across) > 0 {
+		errorMessages = append(errorMessages, validateAcross(identifier, plan.Across)...)
+	} else if plan.FailFast {
+		errorMessages = append(errorMessages, identifier+" specifies fail_fast, which is only applicable to steps with across")
+	}
+
 	return warnings, errorMessages
 }
 
+func validateAcross(identifier string, vars []AcrossVarConfig) []string {
+	errorMessages := []string{}
+	seen := map[string]bool{}
+
+	for i, v := range vars {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		switch {
+		case v.Var == "":
+			errorMessages = append(errorMessages, subIdentifier+" does not specify a var")
+		case strings.Contains(v.Var, "."):
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has an invalid var '%s'; local var names may not contain '.'", subIdentifier, v.Var))
+		case seen[v.Var]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s repeats the var '%s'", subIdentifier, v.Var))
+		}
+
+		seen[v.Var] = true
+
+		switch values := v.Values.(type) {
+		case []interface{}:
+		case string:
+			if _, ok := atctemplate.ParseLocalVarReference(values); !ok {
+				errorMessages = append(errorMessages, subIdentifier+" has invalid values; must be a list or a local var, e.g. ((.:some-var))")
+			}
+		case nil:
+			errorMessages = append(errorMessages, subIdentifier+" does not specify any values")
+		default:
+			errorMessages = append(errorMessages, subIdentifier+" has invalid values; must be a list or a local var, e.g. ((.:some-var))")
+		}
+
+		if v.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, subIdentifier+".max_in_flight must be a positive integer")
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
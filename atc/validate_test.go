@@ -205,6 +205,91 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("resource webhooks", func() {
+		Context("when a resource's webhook is verified", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Provider: WebhookProviderGitHub,
+					Secret:   "((webhook-secret))",
+					Version:  map[string]string{"ref": "/after"},
+				}
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when the provider is unknown", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Provider: "svn",
+					Secret:   "some-secret",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has an unknown provider ('svn')"))
+			})
+		})
+
+		Context("when there is a provider but no secret", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Provider: WebhookProviderGitLab,
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has no secret"))
+			})
+		})
+
+		Context("when there is a secret but no provider", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Secret: "some-secret",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has a secret but no provider"))
+			})
+		})
+
+		Context("when a version field is not a JSON pointer", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Provider: WebhookProviderGitHub,
+					Secret:   "((webhook-secret))",
+					Version:  map[string]string{"ref": "after"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook.version.ref is not a JSON pointer ('after')"))
+			})
+		})
+
+		Context("when a version is read from a webhook without a provider", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Version: map[string]string{"ref": "/after"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has a version but no provider"))
+			})
+		})
+	})
+
 	Describe("unused resources", func() {
 		BeforeEach(func() {
 			config = Config{
@@ -552,6 +637,269 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has duplicate inputs via in_parallel", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Get: "some-resource",
+				})
+				job.Plan = append(job.Plan, PlanConfig{
+					InParallel: &InParallelConfig{
+						Steps: PlanSequence{
+							{
+								Get: "some-resource",
+							},
+						},
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns a single error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(strings.Count(errorMessages[0], "has get steps with the same name: some-resource")).To(Equal(1))
+			})
+		})
+
+		Context("when an in_parallel step has a negative limit", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					InParallel: &InParallelConfig{
+						Steps: PlanSequence{
+							{
+								Get: "some-resource",
+							},
+						},
+						Limit: -1,
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit must be a positive integer"))
+			})
+		})
+
+		Context("when an in_parallel step contains an invalid step", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					InParallel: &InParallelConfig{
+						Steps: PlanSequence{
+							{
+								Get: "some-nonexistent-resource",
+							},
+						},
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[0].get.some-nonexistent-resource refers to a resource that does not exist"))
+			})
+		})
+
+		Context("when a set_pipeline step does not specify a file", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					SetPipeline: "some-pipeline",
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify any pipeline configuration file"))
+			})
+		})
+
+		Context("when a set_pipeline step specifies a file", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					SetPipeline:    "some-pipeline",
+					TaskConfigPath: "some-resource/pipeline.yml",
+					VarFiles:       []string{"some-resource/vars.yml"},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a set_pipeline step specifies inapplicable fields", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					SetPipeline:    "some-pipeline",
+					TaskConfigPath: "some-resource/pipeline.yml",
+					Privileged:     true,
+					Trigger:        true,
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (trigger, privileged)"))
+			})
+		})
+
+		Context("when a load_var step does not specify a file", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					LoadVar: "some-var",
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify any file"))
+			})
+		})
+
+		Context("when a load_var step has a name containing '.'", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					LoadVar:        "some.var",
+					TaskConfigPath: "some-resource/version",
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some.var has an invalid name; local var names may not contain '.'"))
+			})
+		})
+
+		Context("when a load_var step has an unknown format", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					LoadVar:        "some-var",
+					TaskConfigPath: "some-resource/version",
+					Format:         "toml",
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has an unknown format 'toml'"))
+			})
+		})
+
+		Context("when a load_var step specifies a file and format", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					LoadVar:        "some-var",
+					TaskConfigPath: "some-resource/version.json",
+					Format:         "json",
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a load_var step specifies inapplicable fields", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					LoadVar:        "some-var",
+					TaskConfigPath: "some-resource/version",
+					Trigger:        true,
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has invalid fields specified (trigger)"))
+			})
+		})
+
+		Context("when a step runs across a list of values", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Task:           "some-task",
+					TaskConfigPath: "some-resource/task.yml",
+					Across: []AcrossVarConfig{
+						{Var: "go", Values: []interface{}{"1.12", "1.13"}, MaxInFlight: 2},
+						{Var: "db", Values: "((.:databases))"},
+					},
+					FailFast: true,
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a step runs across invalid vars", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Task:           "some-task",
+					TaskConfigPath: "some-resource/task.yml",
+					Across: []AcrossVarConfig{
+						{Values: []interface{}{"a"}},
+						{Var: "some.var", Values: []interface{}{"a"}},
+						{Var: "some-var", Values: "((not-local))"},
+						{Var: "some-var", MaxInFlight: -1},
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[0] does not specify a var"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] has an invalid var 'some.var'; local var names may not contain '.'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[2] has invalid values; must be a list or a local var, e.g. ((.:some-var))"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[3] repeats the var 'some-var'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[3] does not specify any values"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[3].max_in_flight must be a positive integer"))
+			})
+		})
+
+		Context("when a step specifies fail_fast without across", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Task:           "some-task",
+					TaskConfigPath: "some-resource/task.yml",
+					FailFast:       true,
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task specifies fail_fast, which is only applicable to steps with across"))
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -13,6 +13,8 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const creatingContainerRetryDelay = 1 * time.Second
@@ -103,6 +105,11 @@ func (p *containerProvider) FindOrCreateContainer(
 		err               error
 	)
 
+	ctx, span := tracing.StartSpan(ctx, "find-or-create-container", tracing.Attrs{
+		"worker": p.worker.Name(),
+	})
+	defer span.End()
+
 	for {
 		creatingContainer, createdContainer, err = p.worker.FindContainerOnWorker(
 			owner,
@@ -133,12 +140,11 @@ func (p *containerProvider) FindOrCreateContainer(
 		if creatingContainer == nil {
 			logger.Debug("creating-container-in-db")
 
-			creatingContainer, err = p.worker.CreateContainer(
-				owner,
-				metadata,
-			)
+			creatingContainer, err = p.createContainerInDB(owner, metadata, containerSpec)
 			if err != nil {
-				logger.Error("failed-to-create-container-in-db", err)
+				if err != ErrAllWorkersBusy {
+					logger.Error("failed-to-create-container-in-db", err)
+				}
 				return nil, err
 			}
 
@@ -174,16 +180,20 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("fetching-image")
 
+			span.SetAttributes(attribute.String("container", creatingContainer.Handle()))
+
 			fetchedImage, err := image.FetchForContainer(ctx, logger, creatingContainer)
 			if err != nil {
 				creatingContainer.Failed()
 				logger.Error("failed-to-fetch-image-for-container", err)
+				span.RecordError(err)
 				return nil, err
 			}
 
 			logger.Debug("creating-container-in-garden")
 
 			gardenContainer, err = p.createGardenContainer(
+				ctx,
 				logger,
 				creatingContainer,
 				containerSpec,
@@ -197,6 +207,7 @@ func (p *containerProvider) FindOrCreateContainer(
 				metric.FailedContainers.Inc()
 
 				logger.Error("failed-to-create-container-in-garden", err)
+				span.RecordError(err)
 				return nil, err
 			}
 
@@ -226,6 +237,31 @@ func (p *containerProvider) FindOrCreateContainer(
 	}
 }
 
+func (p *containerProvider) createContainerInDB(
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec ContainerSpec,
+) (db.CreatingContainer, error) {
+	if !containerSpec.CountActiveTasks {
+		return p.worker.CreateContainer(owner, metadata)
+	}
+
+	creatingContainer, created, err := p.worker.CreateTaskContainer(
+		owner,
+		metadata,
+		containerSpec.MaxActiveTasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return nil, ErrAllWorkersBusy
+	}
+
+	return creatingContainer, nil
+}
+
 func (p *containerProvider) FindCreatedContainerByHandle(
 	logger lager.Logger,
 	handle string,
@@ -298,6 +334,7 @@ func (p *containerProvider) constructGardenWorkerContainer(
 }
 
 func (p *containerProvider) createGardenContainer(
+	ctx context.Context,
 	logger lager.Logger,
 	creatingContainer db.CreatingContainer,
 	spec ContainerSpec,
@@ -306,6 +343,11 @@ func (p *containerProvider) createGardenContainer(
 	var volumeMounts []VolumeMount
 	var ioVolumeMounts []VolumeMount
 
+	_, volumesSpan := tracing.StartSpan(ctx, "create-volumes", tracing.Attrs{
+		"container": creatingContainer.Handle(),
+	})
+	defer volumesSpan.End()
+
 	scratchVolume, err := p.volumeClient.FindOrCreateVolumeForContainer(
 		logger,
 		VolumeSpec{
@@ -460,6 +502,8 @@ func (p *containerProvider) createGardenContainer(
 	sort.Sort(byMountPath(ioVolumeMounts))
 	volumeMounts = append(volumeMounts, ioVolumeMounts...)
 
+	volumesSpan.End()
+
 	for _, mount := range volumeMounts {
 		bindMounts = append(bindMounts, garden.BindMount{
 			SrcPath: mount.Volume.Path(),
@@ -490,6 +534,11 @@ func (p *containerProvider) createGardenContainer(
 		env = append(env, fmt.Sprintf("no_proxy=%s", p.noProxy))
 	}
 
+	_, span := tracing.StartSpan(ctx, "create-garden-container", tracing.Attrs{
+		"container": creatingContainer.Handle(),
+	})
+	defer span.End()
+
 	return p.gardenClient.Create(garden.ContainerSpec{
 		Handle:     creatingContainer.Handle(),
 		RootFSPath: fetchedImage.URL,
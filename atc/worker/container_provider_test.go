@@ -394,6 +394,55 @@ var _ = Describe("ContainerProvider", func() {
 
 			It("creates container in database", func() {
 				Expect(fakeDBWorker.CreateContainerCallCount()).To(Equal(1))
+				Expect(fakeDBWorker.CreateTaskContainerCallCount()).To(BeZero())
+			})
+
+			Context("when the container counts as an active task", func() {
+				BeforeEach(func() {
+					containerSpec.CountActiveTasks = true
+					containerSpec.MaxActiveTasks = 2
+
+					fakeDBWorker.CreateTaskContainerReturns(fakeCreatingContainer, true, nil)
+				})
+
+				It("creates the container in the database within the worker's limit", func() {
+					Expect(fakeDBWorker.CreateContainerCallCount()).To(BeZero())
+					Expect(fakeDBWorker.CreateTaskContainerCallCount()).To(Equal(1))
+
+					owner, metadata, maxActiveTasks := fakeDBWorker.CreateTaskContainerArgsForCall(0)
+					Expect(owner).To(Equal(fakeContainerOwner))
+					Expect(metadata).To(Equal(containerMetadata))
+					Expect(maxActiveTasks).To(Equal(2))
+				})
+
+				It("creates the container in garden", func() {
+					Expect(findOrCreateErr).ToNot(HaveOccurred())
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+				})
+
+				Context("when the worker has reached its limit", func() {
+					BeforeEach(func() {
+						fakeDBWorker.CreateTaskContainerReturns(nil, false, nil)
+					})
+
+					It("returns ErrAllWorkersBusy", func() {
+						Expect(findOrCreateErr).To(Equal(ErrAllWorkersBusy))
+					})
+
+					It("does not create the container in garden", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when creating the container in the database fails", func() {
+					BeforeEach(func() {
+						fakeDBWorker.CreateTaskContainerReturns(nil, false, disasterErr)
+					})
+
+					It("returns the error", func() {
+						Expect(findOrCreateErr).To(Equal(disasterErr))
+					})
+				})
 			})
 
 			It("acquires lock", func() {
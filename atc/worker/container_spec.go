@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
 )
 
 type WorkerSpec struct {
@@ -24,6 +25,16 @@ type ContainerSpec struct {
 	ImageSpec ImageSpec
 	Env       []string
 
+	// The type of step the container is for, taken into account by some
+	// placement strategies.
+	Type db.ContainerType
+
+	// Whether the container is counted as an active task on the worker. It is
+	// only created if the worker has fewer than MaxActiveTasks active tasks (0
+	// meaning no limit), otherwise ErrAllWorkersBusy is returned.
+	CountActiveTasks bool
+	MaxActiveTasks   int
+
 	// Working directory for processes run in the container.
 	Dir string
 
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/tracing"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -112,12 +113,19 @@ func (i *imageResourceFetcher) Fetch(
 	container db.CreatingContainer,
 	privileged bool,
 ) (worker.Volume, io.ReadCloser, atc.Version, error) {
+	ctx, span := tracing.StartSpan(ctx, "fetch-image", tracing.Attrs{
+		"type":      i.imageResource.Type,
+		"container": container.Handle(),
+	})
+	defer span.End()
+
 	version := i.version
 	if version == nil {
 		var err error
 		version, err = i.getLatestVersion(ctx, logger, container)
 		if err != nil {
 			logger.Error("failed-to-get-latest-image-version", err)
+			span.RecordError(err)
 			return nil, nil, nil, err
 		}
 	}
@@ -188,6 +196,7 @@ func (i *imageResourceFetcher) Fetch(
 	)
 	if err != nil {
 		logger.Error("failed-to-fetch-image", err)
+		span.RecordError(err)
 		return nil, nil, nil, err
 	}
 
@@ -258,7 +267,7 @@ func (i *imageResourceFetcher) ensureVersionOfType(
 	}
 
 	checkResourceType := i.resourceFactory.NewResourceForContainer(resourceTypeContainer)
-	versions, err := checkResourceType.Check(context.TODO(), source, nil)
+	versions, err := checkResourceType.Check(context.TODO(), resource.IOConfig{}, source, nil)
 	if err != nil {
 		return err
 	}
@@ -319,7 +328,7 @@ func (i *imageResourceFetcher) getLatestVersion(
 	}
 
 	checkingResource := i.resourceFactory.NewResourceForContainer(imageContainer)
-	versions, err := checkingResource.Check(context.TODO(), source, nil)
+	versions, err := checkingResource.Check(context.TODO(), resource.IOConfig{}, source, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,16 +1,33 @@
 package worker
 
 import (
+	"errors"
 	"math/rand"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
 )
 
+// ErrAllWorkersBusy is returned by a ContainerPlacementStrategy when every
+// worker has reached its limit and the container must wait to be placed, and
+// when creating a container on a worker which has reached its limit since it
+// was chosen.
+var ErrAllWorkersBusy = errors.New("all workers have reached the maximum number of active tasks")
+
 type ContainerPlacementStrategy interface {
 	//TODO: Don't pass around container metadata since it's not guaranteed to be deterministic.
 	// Change this after check containers stop being reused
 	Choose(lager.Logger, []Worker, ContainerSpec) (Worker, error)
+
+	// ModifiesActiveTasks is true if the strategy takes the number of active
+	// tasks on each worker into account, in which case task steps must keep
+	// the count up to date.
+	ModifiesActiveTasks() bool
+
+	// MaxActiveTasks is the number of active tasks a worker may have before
+	// task containers can no longer be placed on it. 0 means no limit.
+	MaxActiveTasks() int
 }
 
 type VolumeLocalityPlacementStrategy struct {
@@ -52,6 +69,14 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, wor
 	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
 }
 
+func (strategy *VolumeLocalityPlacementStrategy) ModifiesActiveTasks() bool {
+	return false
+}
+
+func (strategy *VolumeLocalityPlacementStrategy) MaxActiveTasks() int {
+	return 0
+}
+
 type FewestBuildContainersPlacementStrategy struct {
 	rand *rand.Rand
 }
@@ -78,6 +103,14 @@ func (strategy *FewestBuildContainersPlacementStrategy) Choose(logger lager.Logg
 	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
 }
 
+func (strategy *FewestBuildContainersPlacementStrategy) ModifiesActiveTasks() bool {
+	return false
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) MaxActiveTasks() int {
+	return 0
+}
+
 type RandomPlacementStrategy struct {
 	rand *rand.Rand
 }
@@ -91,3 +124,65 @@ func NewRandomPlacementStrategy() ContainerPlacementStrategy {
 func (strategy *RandomPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
+
+func (strategy *RandomPlacementStrategy) ModifiesActiveTasks() bool {
+	return false
+}
+
+func (strategy *RandomPlacementStrategy) MaxActiveTasks() int {
+	return 0
+}
+
+type LimitActiveTasksPlacementStrategy struct {
+	maxTasks int
+	rand     *rand.Rand
+}
+
+// NewLimitActiveTasksPlacementStrategy constructs a strategy which places
+// containers on the workers with the fewest active tasks, refusing to place
+// task containers on workers which already have maxTasks active tasks. A
+// maxTasks of 0 means no limit.
+func NewLimitActiveTasksPlacementStrategy(maxTasks int) ContainerPlacementStrategy {
+	return &LimitActiveTasksPlacementStrategy{
+		maxTasks: maxTasks,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *LimitActiveTasksPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	workersByWork := map[int][]Worker{}
+	minActiveTasks := -1
+
+	for _, w := range workers {
+		activeTasks, err := w.ActiveTasks()
+		if err != nil {
+			logger.Error("failed-to-get-active-tasks", err, lager.Data{"worker": w.Name()})
+			continue
+		}
+
+		// only task containers count towards (and are held back by) the limit
+		if strategy.maxTasks > 0 && spec.Type == db.ContainerTypeTask && activeTasks >= strategy.maxTasks {
+			continue
+		}
+
+		workersByWork[activeTasks] = append(workersByWork[activeTasks], w)
+		if minActiveTasks == -1 || activeTasks < minActiveTasks {
+			minActiveTasks = activeTasks
+		}
+	}
+
+	if minActiveTasks == -1 {
+		return nil, ErrAllWorkersBusy
+	}
+
+	leastBusyWorkers := workersByWork[minActiveTasks]
+	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
+}
+
+func (strategy *LimitActiveTasksPlacementStrategy) ModifiesActiveTasks() bool {
+	return true
+}
+
+func (strategy *LimitActiveTasksPlacementStrategy) MaxActiveTasks() int {
+	return strategy.maxTasks
+}
@@ -1,6 +1,8 @@
 package worker_test
 
 import (
+	"errors"
+
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc/db"
@@ -305,3 +307,162 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("LimitActiveTasksPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var compatibleWorker1 *workerfakes.FakeWorker
+		var compatibleWorker2 *workerfakes.FakeWorker
+		var compatibleWorker3 *workerfakes.FakeWorker
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("active-tasks-equal-placement-test")
+			strategy = NewLimitActiveTasksPlacementStrategy(0)
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+
+			spec = ContainerSpec{
+				ImageSpec: ImageSpec{ResourceType: "some-type"},
+
+				Type: db.ContainerTypeTask,
+
+				TeamID: 4567,
+
+				Inputs: []InputSource{},
+			}
+		})
+
+		It("modifies active tasks", func() {
+			Expect(strategy.ModifiesActiveTasks()).To(BeTrue())
+		})
+
+		It("has no limit on active tasks", func() {
+			Expect(strategy.MaxActiveTasks()).To(BeZero())
+		})
+
+		Context("when there is only one worker", func() {
+			BeforeEach(func() {
+				workers = []Worker{compatibleWorker1}
+				compatibleWorker1.ActiveTasksReturns(20, nil)
+			})
+
+			It("picks that worker", func() {
+				chosenWorker, chooseErr = strategy.Choose(
+					logger,
+					workers,
+					spec,
+				)
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker1))
+			})
+		})
+
+		Context("when there are multiple workers", func() {
+			BeforeEach(func() {
+				workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+
+				compatibleWorker1.ActiveTasksReturns(3, nil)
+				compatibleWorker2.ActiveTasksReturns(2, nil)
+				compatibleWorker3.ActiveTasksReturns(1, nil)
+			})
+
+			It("picks the one with the fewest active tasks", func() {
+				Consistently(func() Worker {
+					chosenWorker, chooseErr = strategy.Choose(
+						logger,
+						workers,
+						spec,
+					)
+					Expect(chooseErr).ToNot(HaveOccurred())
+					return chosenWorker
+				}).Should(Equal(compatibleWorker3))
+			})
+
+			Context("when there is more than one worker with the same number of active tasks", func() {
+				BeforeEach(func() {
+					compatibleWorker1.ActiveTasksReturns(1, nil)
+				})
+
+				It("picks any of them", func() {
+					Consistently(func() Worker {
+						chosenWorker, chooseErr = strategy.Choose(
+							logger,
+							workers,
+							spec,
+						)
+						Expect(chooseErr).ToNot(HaveOccurred())
+						return chosenWorker
+					}).Should(Or(Equal(compatibleWorker1), Equal(compatibleWorker3)))
+				})
+			})
+
+			Context("when getting the active tasks of a worker fails", func() {
+				BeforeEach(func() {
+					compatibleWorker3.ActiveTasksReturns(0, errors.New("nope"))
+				})
+
+				It("skips that worker", func() {
+					chosenWorker, chooseErr = strategy.Choose(
+						logger,
+						workers,
+						spec,
+					)
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker2))
+				})
+			})
+
+			Context("when a limit is configured", func() {
+				BeforeEach(func() {
+					strategy = NewLimitActiveTasksPlacementStrategy(2)
+				})
+
+				It("has the limit as its max active tasks", func() {
+					Expect(strategy.MaxActiveTasks()).To(Equal(2))
+				})
+
+				It("picks a worker below the limit", func() {
+					chosenWorker, chooseErr = strategy.Choose(
+						logger,
+						workers,
+						spec,
+					)
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker3))
+				})
+
+				Context("when every worker has reached the limit", func() {
+					BeforeEach(func() {
+						compatibleWorker3.ActiveTasksReturns(2, nil)
+					})
+
+					It("returns ErrAllWorkersBusy", func() {
+						chosenWorker, chooseErr = strategy.Choose(
+							logger,
+							workers,
+							spec,
+						)
+						Expect(chooseErr).To(Equal(ErrAllWorkersBusy))
+						Expect(chosenWorker).To(BeNil())
+					})
+
+					Context("when the container is not for a task", func() {
+						BeforeEach(func() {
+							spec.Type = db.ContainerTypeGet
+						})
+
+						It("ignores the limit", func() {
+							chosenWorker, chooseErr = strategy.Choose(
+								logger,
+								workers,
+								spec,
+							)
+							Expect(chooseErr).ToNot(HaveOccurred())
+							Expect(chosenWorker).To(Or(Equal(compatibleWorker2), Equal(compatibleWorker3)))
+						})
+					})
+				})
+			})
+		})
+	})
+})
@@ -23,6 +23,9 @@ type Worker interface {
 	ActiveVolumes() int
 	BuildContainers() int
 
+	ActiveTasks() (int, error)
+	DecreaseActiveTasks() error
+
 	Description() string
 	Name() string
 	ResourceTypes() []atc.WorkerResourceType
@@ -220,6 +223,14 @@ func (worker *gardenWorker) BuildContainers() int {
 	return worker.buildContainers
 }
 
+func (worker *gardenWorker) ActiveTasks() (int, error) {
+	return worker.dbWorker.ActiveTasks()
+}
+
+func (worker *gardenWorker) DecreaseActiveTasks() error {
+	return worker.dbWorker.DecreaseActiveTasks()
+}
+
 func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool {
 	workerTeamID := worker.dbWorker.TeamID()
 	workerResourceTypes := worker.dbWorker.ResourceTypes()
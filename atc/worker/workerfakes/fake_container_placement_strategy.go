@@ -24,6 +24,26 @@ type FakeContainerPlacementStrategy struct {
 		result1 worker.Worker
 		result2 error
 	}
+	MaxActiveTasksStub        func() int
+	maxActiveTasksMutex       sync.RWMutex
+	maxActiveTasksArgsForCall []struct {
+	}
+	maxActiveTasksReturns struct {
+		result1 int
+	}
+	maxActiveTasksReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ModifiesActiveTasksStub        func() bool
+	modifiesActiveTasksMutex       sync.RWMutex
+	modifiesActiveTasksArgsForCall []struct {
+	}
+	modifiesActiveTasksReturns struct {
+		result1 bool
+	}
+	modifiesActiveTasksReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -98,11 +118,119 @@ func (fake *FakeContainerPlacementStrategy) ChooseReturnsOnCall(i int, result1 w
 	}{result1, result2}
 }
 
+func (fake *FakeContainerPlacementStrategy) MaxActiveTasks() int {
+	fake.maxActiveTasksMutex.Lock()
+	ret, specificReturn := fake.maxActiveTasksReturnsOnCall[len(fake.maxActiveTasksArgsForCall)]
+	fake.maxActiveTasksArgsForCall = append(fake.maxActiveTasksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MaxActiveTasks", []interface{}{})
+	fake.maxActiveTasksMutex.Unlock()
+	if fake.MaxActiveTasksStub != nil {
+		return fake.MaxActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.maxActiveTasksReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainerPlacementStrategy) MaxActiveTasksCallCount() int {
+	fake.maxActiveTasksMutex.RLock()
+	defer fake.maxActiveTasksMutex.RUnlock()
+	return len(fake.maxActiveTasksArgsForCall)
+}
+
+func (fake *FakeContainerPlacementStrategy) MaxActiveTasksCalls(stub func() int) {
+	fake.maxActiveTasksMutex.Lock()
+	defer fake.maxActiveTasksMutex.Unlock()
+	fake.MaxActiveTasksStub = stub
+}
+
+func (fake *FakeContainerPlacementStrategy) MaxActiveTasksReturns(result1 int) {
+	fake.maxActiveTasksMutex.Lock()
+	defer fake.maxActiveTasksMutex.Unlock()
+	fake.MaxActiveTasksStub = nil
+	fake.maxActiveTasksReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeContainerPlacementStrategy) MaxActiveTasksReturnsOnCall(i int, result1 int) {
+	fake.maxActiveTasksMutex.Lock()
+	defer fake.maxActiveTasksMutex.Unlock()
+	fake.MaxActiveTasksStub = nil
+	if fake.maxActiveTasksReturnsOnCall == nil {
+		fake.maxActiveTasksReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxActiveTasksReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeContainerPlacementStrategy) ModifiesActiveTasks() bool {
+	fake.modifiesActiveTasksMutex.Lock()
+	ret, specificReturn := fake.modifiesActiveTasksReturnsOnCall[len(fake.modifiesActiveTasksArgsForCall)]
+	fake.modifiesActiveTasksArgsForCall = append(fake.modifiesActiveTasksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ModifiesActiveTasks", []interface{}{})
+	fake.modifiesActiveTasksMutex.Unlock()
+	if fake.ModifiesActiveTasksStub != nil {
+		return fake.ModifiesActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.modifiesActiveTasksReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainerPlacementStrategy) ModifiesActiveTasksCallCount() int {
+	fake.modifiesActiveTasksMutex.RLock()
+	defer fake.modifiesActiveTasksMutex.RUnlock()
+	return len(fake.modifiesActiveTasksArgsForCall)
+}
+
+func (fake *FakeContainerPlacementStrategy) ModifiesActiveTasksCalls(stub func() bool) {
+	fake.modifiesActiveTasksMutex.Lock()
+	defer fake.modifiesActiveTasksMutex.Unlock()
+	fake.ModifiesActiveTasksStub = stub
+}
+
+func (fake *FakeContainerPlacementStrategy) ModifiesActiveTasksReturns(result1 bool) {
+	fake.modifiesActiveTasksMutex.Lock()
+	defer fake.modifiesActiveTasksMutex.Unlock()
+	fake.ModifiesActiveTasksStub = nil
+	fake.modifiesActiveTasksReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeContainerPlacementStrategy) ModifiesActiveTasksReturnsOnCall(i int, result1 bool) {
+	fake.modifiesActiveTasksMutex.Lock()
+	defer fake.modifiesActiveTasksMutex.Unlock()
+	fake.ModifiesActiveTasksStub = nil
+	if fake.modifiesActiveTasksReturnsOnCall == nil {
+		fake.modifiesActiveTasksReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.modifiesActiveTasksReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeContainerPlacementStrategy) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.chooseMutex.RLock()
 	defer fake.chooseMutex.RUnlock()
+	fake.maxActiveTasksMutex.RLock()
+	defer fake.maxActiveTasksMutex.RUnlock()
+	fake.modifiesActiveTasksMutex.RLock()
+	defer fake.modifiesActiveTasksMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
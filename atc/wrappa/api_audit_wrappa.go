@@ -0,0 +1,79 @@
+package wrappa
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+type APIAuditWrappa struct {
+	auditor auditor.Auditor
+}
+
+func NewAPIAuditWrappa(auditor auditor.Auditor) *APIAuditWrappa {
+	return &APIAuditWrappa{
+		auditor: auditor,
+	}
+}
+
+func (wrappa *APIAuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		if wrappa.auditor.IsEnabled(name) {
+			wrapped[name] = auditHandler{
+				handler: handler,
+				auditor: wrappa.auditor,
+				action:  name,
+			}
+		} else {
+			wrapped[name] = handler
+		}
+	}
+
+	return wrapped
+}
+
+// auditHandler records every non-GET request to its handler once it has been
+// served, whether or not it was authorized.
+type auditHandler struct {
+	handler http.Handler
+	auditor auditor.Auditor
+	action  string
+}
+
+func (h auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	h.handler.ServeHTTP(recorder, r)
+
+	h.auditor.Audit(db.AuditEvent{
+		Time:     time.Now(),
+		UserName: accessor.GetAccessor(r).UserName(),
+		TeamName: rata.Param(r, "team_name"),
+		Action:   h.action,
+		Method:   r.Method,
+		Target:   r.URL.Path,
+		Status:   recorder.status,
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,81 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIAuditWrappa", func() {
+	var (
+		fakeAuditor *auditorfakes.FakeAuditor
+		fakeAccess  *accessorfakes.FakeAccess
+
+		router http.Handler
+	)
+
+	BeforeEach(func() {
+		fakeAuditor = new(auditorfakes.FakeAuditor)
+		fakeAuditor.IsEnabledStub = func(action string) bool {
+			return action == atc.PausePipeline || action == atc.GetPipeline
+		}
+
+		fakeAccess = new(accessorfakes.FakeAccess)
+		fakeAccess.UserNameReturns("some-user")
+
+		fakeAccessFactory := new(accessorfakes.FakeAccessFactory)
+		fakeAccessFactory.CreateReturns(fakeAccess)
+
+		handlers := rata.Handlers{}
+		for _, route := range atc.Routes {
+			handlers[route.Name] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})
+		}
+
+		var err error
+		router, err = rata.NewRouter(atc.Routes, wrappa.MultiWrappa{
+			wrappa.NewAPIAuditWrappa(fakeAuditor),
+			wrappa.NewAccessorWrappa(fakeAccessFactory),
+		}.Wrap(handlers))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	serve := func(method string, path string) {
+		req := httptest.NewRequest(method, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	It("audits enabled non-GET requests once they're served", func() {
+		serve("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/pause")
+
+		Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+
+		event := fakeAuditor.AuditArgsForCall(0)
+		Expect(event.Time).NotTo(BeZero())
+		Expect(event.UserName).To(Equal("some-user"))
+		Expect(event.TeamName).To(Equal("some-team"))
+		Expect(event.Action).To(Equal(atc.PausePipeline))
+		Expect(event.Method).To(Equal("PUT"))
+		Expect(event.Target).To(Equal("/api/v1/teams/some-team/pipelines/some-pipeline/pause"))
+		Expect(event.Status).To(Equal(http.StatusTeapot))
+	})
+
+	It("does not audit GET requests", func() {
+		serve("GET", "/api/v1/teams/some-team/pipelines/some-pipeline")
+		Expect(fakeAuditor.AuditCallCount()).To(BeZero())
+	})
+
+	It("does not audit requests for actions which are not enabled", func() {
+		serve("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/unpause")
+		Expect(fakeAuditor.AuditCallCount()).To(BeZero())
+	})
+})
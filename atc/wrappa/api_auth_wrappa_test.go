@@ -207,13 +207,16 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
 
 				// authenticated and is admin
-				atc.GetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
-				atc.GetInfoCreds: authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.GetLogLevel:     authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:     authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetInfoCreds:    authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.ListAuditEvents: authenticatedAndAdmin(inputHandlers[atc.ListAuditEvents]),
 
 				// authorized (requested team matches resource team)
 				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
 				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
+				atc.ListResourceChecks:      authorized(inputHandlers[atc.ListResourceChecks]),
+				atc.CreateResourceCheck:     authorized(inputHandlers[atc.CreateResourceCheck]),
 				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
 				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
 				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
@@ -222,9 +225,11 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
 				atc.SetPinCommentOnResource: authorized(inputHandlers[atc.SetPinCommentOnResource]),
 				atc.GetConfig:               authorized(inputHandlers[atc.GetConfig]),
+				atc.CheckPipelineCreds:      authorized(inputHandlers[atc.CheckPipelineCreds]),
 				atc.GetCC:                   authorized(inputHandlers[atc.GetCC]),
 				atc.GetVersionsDB:           authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:           authorized(inputHandlers[atc.ListJobInputs]),
+				atc.ExplainJob:              authorized(inputHandlers[atc.ExplainJob]),
 				atc.OrderPipelines:          authorized(inputHandlers[atc.OrderPipelines]),
 				atc.PauseJob:                authorized(inputHandlers[atc.PauseJob]),
 				atc.PausePipeline:           authorized(inputHandlers[atc.PausePipeline]),
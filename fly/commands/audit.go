@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type AuditCommand struct {
+	Count  int    `short:"c" long:"count" default:"50" description:"Number of events you want to limit the return to"`
+	User   string `short:"u" long:"user" description:"Show events for this user"`
+	Team   string `short:"n" long:"team-name" description:"Show events for this team"`
+	Action string `short:"a" long:"action" description:"Show events for this action, e.g. PausePipeline"`
+	Since  string `long:"since" description:"Start of the range to filter events"`
+	Json   bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *AuditCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	filter := concourse.AuditFilter{
+		UserName: command.User,
+		TeamName: command.Team,
+		Action:   command.Action,
+		Limit:    command.Count,
+	}
+
+	if command.Since != "" {
+		filter.Since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	events, err := target.Client().ListAuditEvents(filter)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(events)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "user", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "target", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, e := range events {
+		userCell := ui.TableCell{Contents: e.UserName}
+		if e.UserName == "" {
+			userCell = ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+		}
+
+		teamCell := ui.TableCell{Contents: e.TeamName}
+		if e.TeamName == "" {
+			teamCell = ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+		}
+
+		statusCell := ui.TableCell{Contents: strconv.Itoa(e.Status)}
+		if e.Status >= 400 {
+			statusCell.Color = ui.FailedColor
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: time.Unix(e.Time, 0).Local().Format(timeDateLayout)},
+			userCell,
+			teamCell,
+			{Contents: e.Action},
+			{Contents: e.Method + " " + e.Target},
+			statusCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
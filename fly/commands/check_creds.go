@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type CheckCredsCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline whose credentials are checked"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CheckCredsCommand) Validate() error {
+	return command.Pipeline.Validate()
+}
+
+func (command *CheckCredsCommand) Execute(args []string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	pipelineName := string(command.Pipeline)
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	checkResponse, found, err := target.Team().CheckPipelineCreds(pipelineName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(checkResponse)
+		if err != nil {
+			return err
+		}
+	} else if len(checkResponse.Failures) > 0 {
+		table := ui.Table{
+			Headers: ui.TableRow{
+				{Contents: "location", Color: color.New(color.Bold)},
+				{Contents: "var", Color: color.New(color.Bold)},
+				{Contents: "problem", Color: color.New(color.Bold)},
+			},
+		}
+
+		for _, failure := range checkResponse.Failures {
+			problemCell := ui.TableCell{Contents: failure.Error, Color: ui.ErroredColor}
+			if failure.Missing {
+				problemCell = ui.TableCell{Contents: "not found", Color: ui.FailedColor}
+			}
+
+			table.Data = append(table.Data, []ui.TableCell{
+				{Contents: failure.Location},
+				{Contents: failure.Var},
+				problemCell,
+			})
+		}
+
+		err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(checkResponse.Failures) > 0 {
+		displayhelpers.Failf("%d of %d vars could not be resolved", len(checkResponse.Failures), checkResponse.Checked)
+	}
+
+	if !command.Json {
+		fmt.Printf("all %d vars resolved\n", checkResponse.Checked)
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
 )
 
@@ -29,7 +31,7 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 		version = *command.Version
 	}
 
-	found, err := target.Team().CheckResource(command.Resource.PipelineName, command.Resource.ResourceName, version)
+	build, found, err := target.Team().CheckResource(command.Resource.PipelineName, command.Resource.ResourceName, version)
 	if err != nil {
 		return err
 	}
@@ -38,6 +40,21 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 		return fmt.Errorf("pipeline '%s' or resource '%s' not found\n", command.Resource.PipelineName, command.Resource.ResourceName)
 	}
 
+	fmt.Printf("checking '%s' in build %d\n", command.Resource.ResourceName, build.ID)
+
+	eventSource, err := target.Client().BuildEvents(fmt.Sprintf("%d", build.ID))
+	if err != nil {
+		return err
+	}
+
+	exitCode := eventstream.Render(os.Stdout, eventSource, eventstream.RenderOptions{})
+
+	eventSource.Close()
+
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+
 	fmt.Printf("checked '%s'\n", command.Resource.ResourceName)
 	return nil
 }
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ExplainJobCommand struct {
+	Job  flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to explain"`
+	Json bool                `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ExplainJobCommand) Execute(args []string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	explanation, found, err := target.Team().ExplainJob(command.Job.PipelineName, command.Job.JobName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s/%s not found\n", command.Job.PipelineName, command.Job.JobName)
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(explanation)
+	}
+
+	reasons := blockingReasons(explanation)
+	if len(reasons) == 0 {
+		fmt.Printf("'%s' would be scheduled\n", explanation.Job)
+	} else {
+		fmt.Printf("'%s' would not be scheduled:\n", explanation.Job)
+		for _, reason := range reasons {
+			fmt.Printf("  - %s\n", reason)
+		}
+	}
+
+	if len(explanation.Inputs) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "input", Color: color.New(color.Bold)},
+			{Contents: "resource", Color: color.New(color.Bold)},
+			{Contents: "trigger", Color: color.New(color.Bold)},
+			{Contents: "passed", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, input := range explanation.Inputs {
+		triggerCell := ui.TableCell{Contents: "no"}
+		if input.Trigger {
+			triggerCell = ui.TableCell{Contents: "yes", Color: ui.OnColor}
+		}
+
+		passedCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if len(input.Passed) > 0 {
+			passedCell = ui.TableCell{Contents: strings.Join(input.Passed, ",")}
+		}
+
+		var versionCell ui.TableCell
+		switch {
+		case input.Version == nil:
+			versionCell = ui.TableCell{Contents: "none satisfied", Color: ui.FailedColor}
+		case input.New:
+			versionCell = ui.TableCell{Contents: presentVersion(input.Version) + " (new)", Color: ui.SucceededColor}
+		default:
+			versionCell = ui.TableCell{Contents: presentVersion(input.Version)}
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: input.Name},
+			{Contents: input.Resource},
+			triggerCell,
+			passedCell,
+			versionCell,
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	for _, input := range explanation.Inputs {
+		fmt.Println()
+
+		switch {
+		case input.Pinned != nil:
+			fmt.Printf("candidates for '%s' (pinned to %s):\n", input.Name, presentVersion(input.Pinned))
+		case input.TotalCandidates > len(input.Candidates):
+			fmt.Printf("candidates for '%s' (newest %d of %d):\n", input.Name, len(input.Candidates), input.TotalCandidates)
+		default:
+			fmt.Printf("candidates for '%s':\n", input.Name)
+		}
+
+		if len(input.Candidates) == 0 {
+			fmt.Println("  none; the resource has no versions")
+			continue
+		}
+
+		candidatesTable := ui.Table{
+			Headers: ui.TableRow{
+				{Contents: "version", Color: color.New(color.Bold)},
+				{Contents: "not passed", Color: color.New(color.Bold)},
+			},
+		}
+
+		for _, candidate := range input.Candidates {
+			notPassedCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+			if len(candidate.NotPassed) > 0 {
+				notPassedCell = ui.TableCell{Contents: strings.Join(candidate.NotPassed, ","), Color: ui.FailedColor}
+			}
+
+			candidatesTable.Data = append(candidatesTable.Data, []ui.TableCell{
+				{Contents: presentVersion(candidate.Version)},
+				notPassedCell,
+			})
+		}
+
+		err = candidatesTable.Render(os.Stdout, Fly.PrintTableHeaders)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func blockingReasons(explanation atc.JobExplanation) []string {
+	reasons := []string{}
+
+	if explanation.PausedPipeline {
+		reasons = append(reasons, "the pipeline is paused")
+	}
+
+	if explanation.PausedJob {
+		reasons = append(reasons, "the job is paused")
+	}
+
+	if explanation.MaxInFlightReached {
+		reasons = append(reasons, fmt.Sprintf("max in flight (%d) reached with %d running builds", explanation.MaxInFlight, explanation.RunningBuilds))
+	}
+
+	if !explanation.InputsSatisfied {
+		reasons = append(reasons, "no versions satisfy every input together")
+	} else if !explanation.WouldTrigger && explanation.PendingBuilds == 0 {
+		reasons = append(reasons, "no input with trigger: true has a new version")
+	}
+
+	return reasons
+}
+
+func presentVersion(version atc.Version) string {
+	fields := []string{}
+	for k, v := range version {
+		fields = append(fields, k+":"+v)
+	}
+
+	sort.Strings(fields)
+
+	return strings.Join(fields, ",")
+}
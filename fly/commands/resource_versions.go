@@ -2,9 +2,7 @@ package commands
 
 import (
 	"os"
-	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
@@ -72,16 +70,9 @@ func (command *ResourceVersionsCommand) Execute([]string) error {
 			enabledCell.Contents = "no"
 		}
 
-		fields := []string{}
-		for k, v := range version.Version {
-			fields = append(fields, k+":"+v)
-		}
-
-		sort.Strings(fields)
-
 		table.Data = append(table.Data, []ui.TableCell{
 			{Contents: strconv.Itoa(version.ID)},
-			{Contents: strings.Join(fields, ",")},
+			{Contents: presentVersion(version.Version)},
 			enabledCell,
 		})
 	}
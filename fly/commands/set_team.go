@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
 	"github.com/vito/go-interact/interact"
+	"gopkg.in/yaml.v2"
 )
 
 func WireTeamConnectors(command *flags.Command) {
@@ -24,9 +27,11 @@ func WireTeamConnectors(command *flags.Command) {
 }
 
 type SetTeamCommand struct {
-	TeamName        string               `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
-	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
-	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+	TeamName                string               `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
+	SkipInteractive         bool                 `long:"non-interactive" description:"Force apply configuration"`
+	CredentialManagerConfig atc.PathFlag         `long:"credential-manager-config" description:"YAML file configuring a credential manager for the team, in place of those configured for the ATC, with 'type' and 'config' keys"`
+	RemoveCredentialManager bool                 `long:"remove-credential-manager" description:"Remove the team's credential manager, so that those configured for the ATC are used"`
+	AuthFlags               skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
 func (command *SetTeamCommand) Execute([]string) error {
@@ -52,6 +57,15 @@ func (command *SetTeamCommand) Execute([]string) error {
 	}
 	sort.Strings(roles)
 
+	if command.CredentialManagerConfig != "" && command.RemoveCredentialManager {
+		return errors.New("--credential-manager-config and --remove-credential-manager cannot be used together")
+	}
+
+	credentialManager, err := command.loadCredentialManager()
+	if err != nil {
+		return err
+	}
+
 	fmt.Println("setting team:", ui.Embolden("%s", command.TeamName))
 
 	for _, role := range roles {
@@ -80,6 +94,26 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	fmt.Println()
+	fmt.Printf("credential manager:")
+	if credentialManager != nil {
+		fmt.Printf(" %s\n", ui.Embolden("%s", credentialManager.Type))
+
+		options := []string{}
+		for option := range credentialManager.Config {
+			options = append(options, option)
+		}
+		sort.Strings(options)
+
+		for _, option := range options {
+			fmt.Printf("  - %s\n", option)
+		}
+	} else if command.RemoveCredentialManager {
+		fmt.Printf(" %s\n", ui.OffColor.Sprint("none"))
+	} else {
+		fmt.Printf(" %s\n", ui.OffColor.Sprint("unchanged"))
+	}
+
 	confirm := true
 	if !command.SkipInteractive {
 		confirm = false
@@ -93,7 +127,11 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: atc.TeamAuth(authRoles)}
+	team := atc.Team{
+		Auth:                    atc.TeamAuth(authRoles),
+		CredentialManager:       credentialManager,
+		RemoveCredentialManager: command.RemoveCredentialManager,
+	}
 
 	_, created, updated, err := target.Client().Team(command.TeamName).CreateOrUpdate(team)
 	if err != nil {
@@ -109,6 +147,29 @@ func (command *SetTeamCommand) Execute([]string) error {
 	return nil
 }
 
+func (command *SetTeamCommand) loadCredentialManager() (*atc.TeamCredentialManager, error) {
+	if command.CredentialManagerConfig == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(string(command.CredentialManagerConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	var credentialManager atc.TeamCredentialManager
+	err = yaml.Unmarshal(content, &credentialManager)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential manager config: %s", err)
+	}
+
+	if credentialManager.Type == "" {
+		return nil, errors.New("invalid credential manager config: missing 'type'")
+	}
+
+	return &credentialManager, nil
+}
+
 func (command *SetTeamCommand) ErrorAuthNotConfigured(err error) {
 	switch err {
 	case skycmd.ErrAuthNotConfiguredFromFile:
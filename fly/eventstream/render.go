@@ -41,6 +41,10 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1minitializing\x1b[0m\n")
 
+		case event.WaitingForWorker:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for a worker with capacity to run the task\x1b[0m\n")
+
 		case event.StartTask:
 			buildConfig := e.TaskConfig
 
@@ -119,6 +119,18 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a WaitingForWorker event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitingForWorker{
+				Time: time.Now().Unix(),
+			}
+		})
+
+		It("prints that the task is waiting", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting for a worker with capacity to run the task\x1b[0m\n"))
+		})
+	})
+
 	Context("and a StartTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.StartTask{
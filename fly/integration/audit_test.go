@@ -0,0 +1,141 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("audit", func() {
+		var (
+			flyCmd *exec.Cmd
+			query  string
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "audit")
+			query = "limit=50"
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/audit", query),
+					ghttp.RespondWithJSONEncoded(200, []atc.AuditEvent{
+						{
+							ID:       2,
+							Time:     200,
+							UserName: "some-user",
+							TeamName: "main",
+							Action:   "PausePipeline",
+							Method:   "PUT",
+							Target:   "/api/v1/teams/main/pipelines/some-pipeline/pause",
+							Status:   200,
+						},
+						{
+							ID:     1,
+							Time:   100,
+							Action: "SetLogLevel",
+							Method: "PUT",
+							Target: "/api/v1/log-level",
+							Status: 401,
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the events", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "time", Color: color.New(color.Bold)},
+					{Contents: "user", Color: color.New(color.Bold)},
+					{Contents: "team", Color: color.New(color.Bold)},
+					{Contents: "action", Color: color.New(color.Bold)},
+					{Contents: "target", Color: color.New(color.Bold)},
+					{Contents: "status", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: time.Unix(200, 0).Local().Format("2006-01-02@15:04:05-0700")},
+						{Contents: "some-user"},
+						{Contents: "main"},
+						{Contents: "PausePipeline"},
+						{Contents: "PUT /api/v1/teams/main/pipelines/some-pipeline/pause"},
+						{Contents: "200"},
+					},
+					{
+						{Contents: time.Unix(100, 0).Local().Format("2006-01-02@15:04:05-0700")},
+						{Contents: "none", Color: color.New(color.Faint)},
+						{Contents: "none", Color: color.New(color.Faint)},
+						{Contents: "SetLogLevel"},
+						{Contents: "PUT /api/v1/log-level"},
+						{Contents: "401", Color: color.New(color.FgRed)},
+					},
+				},
+			}))
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-u", "some-user", "-n", "main", "-a", "PausePipeline", "-c", "10")
+				query = "action=PausePipeline&limit=10&team=main&user=some-user"
+			})
+
+			It("passes them to the API", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("PausePipeline"))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the events as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"id": 2,
+						"time": 200,
+						"user_name": "some-user",
+						"team_name": "main",
+						"action": "PausePipeline",
+						"method": "PUT",
+						"target": "/api/v1/teams/main/pipelines/some-pipeline/pause",
+						"status": 200
+					},
+					{
+						"id": 1,
+						"time": 100,
+						"action": "SetLogLevel",
+						"method": "PUT",
+						"target": "/api/v1/log-level",
+						"status": 401
+					}
+				]`))
+			})
+		})
+	})
+})
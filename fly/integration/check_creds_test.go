@@ -0,0 +1,125 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("check-creds", func() {
+		var (
+			path string
+			err  error
+		)
+
+		BeforeEach(func() {
+			path, err = atc.Routes.CreatePathForRoute(atc.CheckPipelineCreds, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when every var resolves", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", path),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.CheckCredsResponse{Checked: 3}),
+					),
+				)
+			})
+
+			It("says so", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "check-creds", "-p", "awesome-pipeline")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say(`all 3 vars resolved`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+		})
+
+		Context("when some vars fail to resolve", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", path),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.CheckCredsResponse{
+							Checked: 3,
+							Failures: []atc.CredentialCheckFailure{
+								{
+									Location: "resources.some-resource",
+									Var:      "some-var",
+									Missing:  true,
+								},
+								{
+									Location: "jobs.some-job.plan[0].get.some-resource",
+									Var:      "vault:other-var",
+									Error:    "permission denied",
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists them and exits 1", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "check-creds", "-p", "awesome-pipeline")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "location", Color: color.New(color.Bold)},
+						{Contents: "var", Color: color.New(color.Bold)},
+						{Contents: "problem", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "resources.some-resource"}, {Contents: "some-var"}, {Contents: "not found"}},
+						{{Contents: "jobs.some-job.plan[0].get.some-resource"}, {Contents: "vault:other-var"}, {Contents: "permission denied"}},
+					},
+				}))
+				Expect(sess.Err).To(gbytes.Say(`2 of 3 vars could not be resolved`))
+			})
+		})
+
+		Context("when the pipeline doesn't exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", path),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("prints an error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "check-creds", "-p", "awesome-pipeline")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say(`pipeline not found`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+	})
+})
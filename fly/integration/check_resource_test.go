@@ -1,12 +1,17 @@
 package integration_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os/exec"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/vito/go-sse/sse"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 	"github.com/onsi/gomega/ghttp"
@@ -15,21 +20,75 @@ import (
 var _ = Describe("CheckResource", func() {
 	var (
 		flyCmd *exec.Cmd
+
+		checkBuild  atc.Build
+		checkEvents []atc.Event
 	)
 
+	BeforeEach(func() {
+		checkBuild = atc.Build{
+			ID:           123,
+			Name:         "check",
+			Status:       "started",
+			TeamName:     "main",
+			PipelineName: "mypipeline",
+			ResourceName: "myresource",
+		}
+
+		checkEvents = []atc.Event{
+			event.Log{Payload: "checking from fake-ref\n"},
+			event.Status{Status: atc.StatusSucceeded},
+		}
+	})
+
+	streamCheckEvents := func() http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/builds/123/events"),
+			func(w http.ResponseWriter, r *http.Request) {
+				flusher := w.(http.Flusher)
+
+				w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+				w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+				w.Header().Add("Connection", "keep-alive")
+
+				w.WriteHeader(http.StatusOK)
+
+				for id, e := range checkEvents {
+					payload, err := json.Marshal(event.Message{Event: e})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = sse.Event{
+						ID:   fmt.Sprintf("%d", id),
+						Name: "event",
+						Data: payload,
+					}.Write(w)
+					Expect(err).NotTo(HaveOccurred())
+
+					flusher.Flush()
+				}
+
+				err := sse.Event{
+					Name: "end",
+				}.Write(w)
+				Expect(err).NotTo(HaveOccurred())
+			},
+		)
+	}
+
 	Context("when ATC request succeeds", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
 					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"}}`),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, checkBuild),
 				),
+				streamCheckEvents(),
 			)
 		})
 
-		It("sends check resource request to ATC", func() {
+		It("sends check resource request to ATC and streams the check's output", func() {
 			Expect(func() {
 				flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "-f", "ref:fake-ref")
 				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
@@ -37,23 +96,27 @@ var _ = Describe("CheckResource", func() {
 
 				Eventually(sess).Should(gexec.Exit(0))
 
+				Expect(sess.Out).To(gbytes.Say("checking 'myresource' in build 123"))
+				Expect(sess.Out).To(gbytes.Say("checking from fake-ref"))
+				Expect(sess.Out).To(gbytes.Say("succeeded"))
 				Expect(sess.Out).To(gbytes.Say("checked 'myresource'"))
 
 			}).To(Change(func() int {
 				return len(atcServer.ReceivedRequests())
-			}).By(2))
+			}).By(3))
 		})
 	})
 
 	Context("when version is omitted", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
 					ghttp.VerifyJSON(`{"from":null}`),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, checkBuild),
 				),
+				streamCheckEvents(),
 			)
 		})
 
@@ -69,19 +132,20 @@ var _ = Describe("CheckResource", func() {
 
 			}).To(Change(func() int {
 				return len(atcServer.ReceivedRequests())
-			}).By(2))
+			}).By(3))
 		})
 	})
 
 	Context("when specifying multiple versions", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
 					ghttp.VerifyJSON(`{"from":{"ref1":"fake-ref-1","ref2":"fake-ref-2"}}`),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, checkBuild),
 				),
+				streamCheckEvents(),
 			)
 		})
 
@@ -97,13 +161,43 @@ var _ = Describe("CheckResource", func() {
 
 			}).To(Change(func() int {
 				return len(atcServer.ReceivedRequests())
-			}).By(2))
+			}).By(3))
+		})
+	})
+
+	Context("when the check fails", func() {
+		BeforeEach(func() {
+			checkEvents = []atc.Event{
+				event.Log{Payload: "bad version\n"},
+				event.Status{Status: atc.StatusFailed},
+			}
+
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks"
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, checkBuild),
+				),
+				streamCheckEvents(),
+			)
+		})
+
+		It("outputs the check's output and exits nonzero", func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Out).To(gbytes.Say("bad version"))
+			Expect(sess.Out).To(gbytes.Say("failed"))
+			Expect(sess.Out).NotTo(gbytes.Say("checked 'myresource'"))
 		})
 	})
 
 	Context("when pipeline or resource is not found", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
@@ -125,7 +219,7 @@ var _ = Describe("CheckResource", func() {
 
 	Context("When resource check returns internal server error", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
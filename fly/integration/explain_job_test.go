@@ -0,0 +1,180 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("explain-job", func() {
+		var (
+			path string
+			err  error
+		)
+
+		BeforeEach(func() {
+			path, err = atc.Routes.CreatePathForRoute(atc.ExplainJob, rata.Params{
+				"pipeline_name": "awesome-pipeline",
+				"job_name":      "awesome-job",
+				"team_name":     "main",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the job would be scheduled", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", path),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.JobExplanation{
+							Job:             "awesome-job",
+							InputsSatisfied: true,
+							WouldTrigger:    true,
+							Inputs: []atc.JobInputExplanation{
+								{
+									Name:     "some-input",
+									Resource: "some-resource",
+									Trigger:  true,
+									Version:  atc.Version{"ref": "abc"},
+									New:      true,
+									Candidates: []atc.InputCandidateExplanation{
+										{Version: atc.Version{"ref": "abc"}},
+									},
+									TotalCandidates: 1,
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("says so and lists the inputs", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "explain-job", "-j", "awesome-pipeline/awesome-job")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out).To(gbytes.Say(`'awesome-job' would be scheduled`))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "input", Color: color.New(color.Bold)},
+						{Contents: "resource", Color: color.New(color.Bold)},
+						{Contents: "trigger", Color: color.New(color.Bold)},
+						{Contents: "passed", Color: color.New(color.Bold)},
+						{Contents: "version", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "some-input"}, {Contents: "some-resource"}, {Contents: "yes"}, {Contents: "n/a"}, {Contents: "ref:abc (new)"}},
+					},
+				}))
+			})
+		})
+
+		Context("when the job would not be scheduled", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", path),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.JobExplanation{
+							Job:                "awesome-job",
+							PausedJob:          true,
+							MaxInFlight:        1,
+							RunningBuilds:      1,
+							MaxInFlightReached: true,
+							Inputs: []atc.JobInputExplanation{
+								{
+									Name:     "some-input",
+									Resource: "some-resource",
+									Trigger:  true,
+									Passed:   []string{"unit", "lint"},
+									Candidates: []atc.InputCandidateExplanation{
+										{Version: atc.Version{"ref": "def"}, NotPassed: []string{"lint"}},
+										{Version: atc.Version{"ref": "abc"}, NotPassed: []string{"unit", "lint"}},
+									},
+									TotalCandidates: 12,
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("explains why, and which passed constraints eliminated each candidate", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "explain-job", "-j", "awesome-pipeline/awesome-job")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(sess.Out).To(gbytes.Say(`'awesome-job' would not be scheduled:`))
+				Expect(sess.Out).To(gbytes.Say(`- the job is paused`))
+				Expect(sess.Out).To(gbytes.Say(`- max in flight \(1\) reached with 1 running builds`))
+				Expect(sess.Out).To(gbytes.Say(`- no versions satisfy every input together`))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "input", Color: color.New(color.Bold)},
+						{Contents: "resource", Color: color.New(color.Bold)},
+						{Contents: "trigger", Color: color.New(color.Bold)},
+						{Contents: "passed", Color: color.New(color.Bold)},
+						{Contents: "version", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "some-input"}, {Contents: "some-resource"}, {Contents: "yes"}, {Contents: "unit,lint"}, {Contents: "none satisfied"}},
+					},
+				}))
+
+				Expect(sess.Out).To(gbytes.Say(`candidates for 'some-input' \(newest 2 of 12\):`))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "version", Color: color.New(color.Bold)},
+						{Contents: "not passed", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "ref:def"}, {Contents: "lint"}},
+						{{Contents: "ref:abc"}, {Contents: "unit,lint"}},
+					},
+				}))
+			})
+		})
+
+		Context("when the job doesn't exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", path),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("prints an error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "explain-job", "-j", "awesome-pipeline/awesome-job")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say(`awesome-pipeline/awesome-job not found`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+	})
+})
@@ -374,6 +374,111 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("sending a credential manager", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_local_auth.yml", "--credential-manager-config", "fixtures/team_credential_manager.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{"users": ["local:some-owner"], "groups": []},
+								"member":{"users": ["local:some-member"], "groups": []},
+								"viewer":{"users": ["local:some-viewer"], "groups": []}
+							},
+							"credential_manager": {
+								"type": "vault",
+								"config": {
+									"url": "https://vault.example.com",
+									"auth-backend": "approle",
+									"auth-param": {"role_id": "some-role", "secret_id": "some-secret"}
+								}
+							}
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows the credential manager's options without their values and sends it", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say("credential manager: vault"))
+				Eventually(sess).Should(gbytes.Say("- auth-backend"))
+				Eventually(sess).Should(gbytes.Say("- auth-param"))
+				Eventually(sess).Should(gbytes.Say("- url"))
+				Expect(sess.Out.Contents()).ToNot(ContainSubstring("some-secret"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Describe("removing the credential manager", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_local_auth.yml", "--remove-credential-manager"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{"users": ["local:some-owner"], "groups": []},
+								"member":{"users": ["local:some-member"], "groups": []},
+								"viewer":{"users": ["local:some-viewer"], "groups": []}
+							},
+							"remove_credential_manager": true
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("sends the removal", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say("credential manager: none"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when a credential manager is also configured", func() {
+				BeforeEach(func() {
+					cmdParams = append(cmdParams, "--credential-manager-config", "fixtures/team_credential_manager.yml")
+				})
+
+				It("errors", func() {
+					sess, err := gexec.Start(flyCmd, nil, nil)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("--credential-manager-config and --remove-credential-manager cannot be used together"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
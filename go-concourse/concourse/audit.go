@@ -0,0 +1,57 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+// AuditFilter narrows down the audit events returned by the ATC. Zero values
+// are not filtered on.
+type AuditFilter struct {
+	UserName string
+	TeamName string
+	Action   string
+	Since    time.Time
+	Limit    int
+}
+
+func (filter AuditFilter) QueryParams() url.Values {
+	queryParams := url.Values{}
+
+	if filter.UserName != "" {
+		queryParams.Add("user", filter.UserName)
+	}
+
+	if filter.TeamName != "" {
+		queryParams.Add("team", filter.TeamName)
+	}
+
+	if filter.Action != "" {
+		queryParams.Add("action", filter.Action)
+	}
+
+	if !filter.Since.IsZero() {
+		queryParams.Add("since", strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if filter.Limit != 0 {
+		queryParams.Add("limit", strconv.Itoa(filter.Limit))
+	}
+
+	return queryParams
+}
+
+func (client *client) ListAuditEvents(filter AuditFilter) ([]atc.AuditEvent, error) {
+	var events []atc.AuditEvent
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListAuditEvents,
+		Query:       filter.QueryParams(),
+	}, &internal.Response{
+		Result: &events,
+	})
+	return events, err
+}
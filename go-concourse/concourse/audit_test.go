@@ -0,0 +1,90 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Audit", func() {
+	Describe("ListAuditEvents", func() {
+		var expectedEvents []atc.AuditEvent
+
+		BeforeEach(func() {
+			expectedEvents = []atc.AuditEvent{
+				{
+					ID:       1,
+					Time:     123,
+					UserName: "some-user",
+					TeamName: "some-team",
+					Action:   "PausePipeline",
+					Method:   "PUT",
+					Target:   "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+					Status:   200,
+				},
+			}
+		})
+
+		Context("when no filter is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				events, err := client.ListAuditEvents(concourse.AuditFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when a filter is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit", "action=PausePipeline&limit=10&since=100&team=some-team&user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("passes it as query params", func() {
+				events, err := client.ListAuditEvents(concourse.AuditFilter{
+					UserName: "some-user",
+					TeamName: "some-team",
+					Action:   "PausePipeline",
+					Since:    time.Unix(100, 0),
+					Limit:    10,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when the request is forbidden", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit"),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.ListAuditEvents(concourse.AuditFilter{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
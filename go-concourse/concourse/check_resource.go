@@ -10,7 +10,9 @@ import (
 	"github.com/tedsuo/rata"
 )
 
-func (team *team) CheckResource(pipelineName string, resourceName string, version atc.Version) (bool, error) {
+// CheckResource starts a check of the resource and returns the build it is
+// recorded as, without waiting for it to finish.
+func (team *team) CheckResource(pipelineName string, resourceName string, version atc.Version) (atc.Build, bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineName,
 		"resource_name": resourceName,
@@ -19,45 +21,64 @@ func (team *team) CheckResource(pipelineName string, resourceName string, versio
 
 	jsonBytes, err := json.Marshal(atc.CheckRequestBody{From: version})
 	if err != nil {
-		return false, err
+		return atc.Build{}, false, err
 	}
 
-	response := internal.Response{}
+	var build atc.Build
 	err = team.connection.Send(internal.Request{
-		ReturnResponseBody: true,
-		RequestName:        atc.CheckResource,
-		Params:             params,
-		Body:               bytes.NewBuffer(jsonBytes),
-		Header:             http.Header{"Content-Type": []string{"application/json"}},
-	}, &response)
+		RequestName: atc.CreateResourceCheck,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &build,
+	})
 
 	switch e := err.(type) {
 	case nil:
-		return true, nil
+		return build, true, nil
 	case internal.ResourceNotFoundError:
-		return false, nil
+		return atc.Build{}, false, nil
 	case internal.UnexpectedResponseError:
-		switch e.StatusCode {
-		case http.StatusBadRequest:
-			var checkRes atc.CheckResponseBody
-			err = json.Unmarshal([]byte(e.Body), &checkRes)
-			if err != nil {
-				return false, err
-			}
-
-			return false, CommandFailedError{
-				Command:    "check",
-				ExitStatus: checkRes.ExitStatus,
-				Output:     checkRes.Stderr,
-			}
-		case http.StatusInternalServerError:
-			return false, GenericError{
-				e.Body,
-			}
-		default:
-			return false, err
+		if e.StatusCode == http.StatusInternalServerError {
+			return atc.Build{}, false, GenericError{e.Body}
 		}
+
+		return atc.Build{}, false, err
+	default:
+		return atc.Build{}, false, err
+	}
+}
+
+func (team *team) ResourceChecks(pipelineName string, resourceName string, page Page) ([]atc.Build, Pagination, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"resource_name": resourceName,
+		"team_name":     team.name,
+	}
+
+	var builds []atc.Build
+
+	headers := http.Header{}
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceChecks,
+		Params:      params,
+		Query:       page.QueryParams(),
+	}, &internal.Response{
+		Result:  &builds,
+		Headers: &headers,
+	})
+	switch err.(type) {
+	case nil:
+		pagination, err := paginationFromHeaders(headers)
+		if err != nil {
+			return builds, Pagination{}, false, err
+		}
+
+		return builds, pagination, true, nil
+	case internal.ResourceNotFoundError:
+		return builds, Pagination{}, false, nil
 	default:
-		return false, err
+		return builds, Pagination{}, false, err
 	}
 }
@@ -14,21 +14,33 @@ import (
 
 var _ = Describe("CheckResource", func() {
 	Context("when ATC request succeeds", func() {
+		var expectedBuild atc.Build
+
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/check"
+			expectedBuild = atc.Build{
+				ID:           123,
+				Name:         "check",
+				Status:       "started",
+				TeamName:     "some-team",
+				PipelineName: "mypipeline",
+				ResourceName: "myresource",
+			}
+
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
 					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"}}`),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
 				),
 			)
 		})
 
-		It("sends check resource request to ATC", func() {
-			found, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+		It("sends check resource request to ATC and returns the check's build", func() {
+			build, found, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
+			Expect(build).To(Equal(expectedBuild))
 
 			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
 		})
@@ -36,7 +48,7 @@ var _ = Describe("CheckResource", func() {
 
 	Context("when pipeline or resource does not exist", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/check"
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks"
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
@@ -46,61 +58,106 @@ var _ = Describe("CheckResource", func() {
 			)
 		})
 
-		It("returns a ResourceNotFoundError", func() {
-			found, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+		It("returns not found", func() {
+			_, found, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
 	})
 
-	Context("when ATC responds with an error", func() {
+	Context("when ATC responds with an internal server error", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/check"
-
-			atcResponse := atc.CheckResponseBody{
-				ExitStatus: 1,
-				Stderr:     "bad version",
-			}
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks"
 
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("POST", expectedURL),
 					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"}}`),
-					ghttp.RespondWithJSONEncoded(http.StatusBadRequest, atcResponse),
+					ghttp.RespondWith(http.StatusInternalServerError, "unknown server error"),
 				),
 			)
 		})
 
-		It("returns an error", func() {
-			_, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+		It("returns an error with body", func() {
+			_, _, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
 			Expect(err).To(HaveOccurred())
 
-			cre, ok := err.(concourse.CommandFailedError)
+			cre, ok := err.(concourse.GenericError)
 			Expect(ok).To(BeTrue())
-			Expect(cre.Error()).To(Equal("check failed with exit status '1':\nbad version\n"))
+			Expect(cre.Error()).To(Equal("unknown server error"))
 		})
 	})
+})
 
-	Context("when ATC responds with an internal server error", func() {
+var _ = Describe("ResourceChecks", func() {
+	var (
+		expectedBuilds []atc.Build
+		expectedURL    string
+	)
+
+	BeforeEach(func() {
+		expectedURL = "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks"
+
+		expectedBuilds = []atc.Build{
+			{ID: 2, Name: "check", ResourceName: "myresource"},
+			{ID: 1, Name: "check", ResourceName: "myresource"},
+		}
+	})
+
+	Context("when the checks are returned", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "limit=2"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuilds, http.Header{
+						"Link": []string{
+							`<http://some-url.com/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks?until=1&limit=2>; rel="next"`,
+						},
+					}),
+				),
+			)
+		})
+
+		It("returns the builds and pagination data", func() {
+			builds, pagination, found, err := team.ResourceChecks("mypipeline", "myresource", concourse.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(builds).To(Equal(expectedBuilds))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&concourse.Page{Until: 1, Limit: 2}))
+		})
+	})
+
+	Context("when the resource does not exist", func() {
 		BeforeEach(func() {
-			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/check"
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
 
+		It("returns not found", func() {
+			_, _, found, err := team.ResourceChecks("mypipeline", "myresource", concourse.Page{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when ATC responds with an internal server error", func() {
+		BeforeEach(func() {
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", expectedURL),
-					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"}}`),
-					ghttp.RespondWith(http.StatusInternalServerError, "unknown server error"),
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
 				),
 			)
 		})
 
-		It("returns an error with body", func() {
-			_, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+		It("returns an error", func() {
+			_, _, _, err := team.ResourceChecks("mypipeline", "myresource", concourse.Page{})
 			Expect(err).To(HaveOccurred())
-
-			cre, ok := err.(concourse.GenericError)
-			Expect(ok).To(BeTrue())
-			Expect(cre.Error()).To(Equal("unknown server error"))
 		})
 	})
 })
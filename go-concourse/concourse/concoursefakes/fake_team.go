@@ -75,7 +75,22 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	CheckResourceStub        func(string, string, atc.Version) (bool, error)
+	CheckPipelineCredsStub        func(string) (atc.CheckCredsResponse, bool, error)
+	checkPipelineCredsMutex       sync.RWMutex
+	checkPipelineCredsArgsForCall []struct {
+		arg1 string
+	}
+	checkPipelineCredsReturns struct {
+		result1 atc.CheckCredsResponse
+		result2 bool
+		result3 error
+	}
+	checkPipelineCredsReturnsOnCall map[int]struct {
+		result1 atc.CheckCredsResponse
+		result2 bool
+		result3 error
+	}
+	CheckResourceStub        func(string, string, atc.Version) (atc.Build, bool, error)
 	checkResourceMutex       sync.RWMutex
 	checkResourceArgsForCall []struct {
 		arg1 string
@@ -83,12 +98,14 @@ type FakeTeam struct {
 		arg3 atc.Version
 	}
 	checkResourceReturns struct {
-		result1 bool
-		result2 error
+		result1 atc.Build
+		result2 bool
+		result3 error
 	}
 	checkResourceReturnsOnCall map[int]struct {
-		result1 bool
-		result2 error
+		result1 atc.Build
+		result2 bool
+		result3 error
 	}
 	CheckResourceTypeStub        func(string, string, atc.Version) (bool, error)
 	checkResourceTypeMutex       sync.RWMutex
@@ -266,6 +283,22 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	ExplainJobStub        func(string, string) (atc.JobExplanation, bool, error)
+	explainJobMutex       sync.RWMutex
+	explainJobArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	explainJobReturns struct {
+		result1 atc.JobExplanation
+		result2 bool
+		result3 error
+	}
+	explainJobReturnsOnCall map[int]struct {
+		result1 atc.JobExplanation
+		result2 bool
+		result3 error
+	}
 	ExposePipelineStub        func(string) (bool, error)
 	exposePipelineMutex       sync.RWMutex
 	exposePipelineArgsForCall []struct {
@@ -575,6 +608,25 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	ResourceChecksStub        func(string, string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
+	resourceChecksMutex       sync.RWMutex
+	resourceChecksArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 concourse.Page
+	}
+	resourceChecksReturns struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}
+	resourceChecksReturnsOnCall map[int]struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}
 	ResourceVersionsStub        func(string, string, concourse.Page) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
 	resourceVersionsMutex       sync.RWMutex
 	resourceVersionsArgsForCall []struct {
@@ -909,7 +961,73 @@ func (fake *FakeTeam) BuildsWithVersionAsOutputReturnsOnCall(i int, result1 []at
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) CheckResource(arg1 string, arg2 string, arg3 atc.Version) (bool, error) {
+func (fake *FakeTeam) CheckPipelineCreds(arg1 string) (atc.CheckCredsResponse, bool, error) {
+	fake.checkPipelineCredsMutex.Lock()
+	ret, specificReturn := fake.checkPipelineCredsReturnsOnCall[len(fake.checkPipelineCredsArgsForCall)]
+	fake.checkPipelineCredsArgsForCall = append(fake.checkPipelineCredsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("CheckPipelineCreds", []interface{}{arg1})
+	fake.checkPipelineCredsMutex.Unlock()
+	if fake.CheckPipelineCredsStub != nil {
+		return fake.CheckPipelineCredsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.checkPipelineCredsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) CheckPipelineCredsCallCount() int {
+	fake.checkPipelineCredsMutex.RLock()
+	defer fake.checkPipelineCredsMutex.RUnlock()
+	return len(fake.checkPipelineCredsArgsForCall)
+}
+
+func (fake *FakeTeam) CheckPipelineCredsCalls(stub func(string) (atc.CheckCredsResponse, bool, error)) {
+	fake.checkPipelineCredsMutex.Lock()
+	defer fake.checkPipelineCredsMutex.Unlock()
+	fake.CheckPipelineCredsStub = stub
+}
+
+func (fake *FakeTeam) CheckPipelineCredsArgsForCall(i int) string {
+	fake.checkPipelineCredsMutex.RLock()
+	defer fake.checkPipelineCredsMutex.RUnlock()
+	argsForCall := fake.checkPipelineCredsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CheckPipelineCredsReturns(result1 atc.CheckCredsResponse, result2 bool, result3 error) {
+	fake.checkPipelineCredsMutex.Lock()
+	defer fake.checkPipelineCredsMutex.Unlock()
+	fake.CheckPipelineCredsStub = nil
+	fake.checkPipelineCredsReturns = struct {
+		result1 atc.CheckCredsResponse
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) CheckPipelineCredsReturnsOnCall(i int, result1 atc.CheckCredsResponse, result2 bool, result3 error) {
+	fake.checkPipelineCredsMutex.Lock()
+	defer fake.checkPipelineCredsMutex.Unlock()
+	fake.CheckPipelineCredsStub = nil
+	if fake.checkPipelineCredsReturnsOnCall == nil {
+		fake.checkPipelineCredsReturnsOnCall = make(map[int]struct {
+			result1 atc.CheckCredsResponse
+			result2 bool
+			result3 error
+		})
+	}
+	fake.checkPipelineCredsReturnsOnCall[i] = struct {
+		result1 atc.CheckCredsResponse
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) CheckResource(arg1 string, arg2 string, arg3 atc.Version) (atc.Build, bool, error) {
 	fake.checkResourceMutex.Lock()
 	ret, specificReturn := fake.checkResourceReturnsOnCall[len(fake.checkResourceArgsForCall)]
 	fake.checkResourceArgsForCall = append(fake.checkResourceArgsForCall, struct {
@@ -923,10 +1041,10 @@ func (fake *FakeTeam) CheckResource(arg1 string, arg2 string, arg3 atc.Version)
 		return fake.CheckResourceStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1, ret.result2, ret.result3
 	}
 	fakeReturns := fake.checkResourceReturns
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *FakeTeam) CheckResourceCallCount() int {
@@ -935,7 +1053,7 @@ func (fake *FakeTeam) CheckResourceCallCount() int {
 	return len(fake.checkResourceArgsForCall)
 }
 
-func (fake *FakeTeam) CheckResourceCalls(stub func(string, string, atc.Version) (bool, error)) {
+func (fake *FakeTeam) CheckResourceCalls(stub func(string, string, atc.Version) (atc.Build, bool, error)) {
 	fake.checkResourceMutex.Lock()
 	defer fake.checkResourceMutex.Unlock()
 	fake.CheckResourceStub = stub
@@ -948,30 +1066,33 @@ func (fake *FakeTeam) CheckResourceArgsForCall(i int) (string, string, atc.Versi
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeTeam) CheckResourceReturns(result1 bool, result2 error) {
+func (fake *FakeTeam) CheckResourceReturns(result1 atc.Build, result2 bool, result3 error) {
 	fake.checkResourceMutex.Lock()
 	defer fake.checkResourceMutex.Unlock()
 	fake.CheckResourceStub = nil
 	fake.checkResourceReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+		result1 atc.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) CheckResourceReturnsOnCall(i int, result1 bool, result2 error) {
+func (fake *FakeTeam) CheckResourceReturnsOnCall(i int, result1 atc.Build, result2 bool, result3 error) {
 	fake.checkResourceMutex.Lock()
 	defer fake.checkResourceMutex.Unlock()
 	fake.CheckResourceStub = nil
 	if fake.checkResourceReturnsOnCall == nil {
 		fake.checkResourceReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+			result1 atc.Build
+			result2 bool
+			result3 error
 		})
 	}
 	fake.checkResourceReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+		result1 atc.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakeTeam) CheckResourceType(arg1 string, arg2 string, arg3 atc.Version) (bool, error) {
@@ -1758,6 +1879,73 @@ func (fake *FakeTeam) EnableResourceVersionReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ExplainJob(arg1 string, arg2 string) (atc.JobExplanation, bool, error) {
+	fake.explainJobMutex.Lock()
+	ret, specificReturn := fake.explainJobReturnsOnCall[len(fake.explainJobArgsForCall)]
+	fake.explainJobArgsForCall = append(fake.explainJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ExplainJob", []interface{}{arg1, arg2})
+	fake.explainJobMutex.Unlock()
+	if fake.ExplainJobStub != nil {
+		return fake.ExplainJobStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.explainJobReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ExplainJobCallCount() int {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return len(fake.explainJobArgsForCall)
+}
+
+func (fake *FakeTeam) ExplainJobCalls(stub func(string, string) (atc.JobExplanation, bool, error)) {
+	fake.explainJobMutex.Lock()
+	defer fake.explainJobMutex.Unlock()
+	fake.ExplainJobStub = stub
+}
+
+func (fake *FakeTeam) ExplainJobArgsForCall(i int) (string, string) {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	argsForCall := fake.explainJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ExplainJobReturns(result1 atc.JobExplanation, result2 bool, result3 error) {
+	fake.explainJobMutex.Lock()
+	defer fake.explainJobMutex.Unlock()
+	fake.ExplainJobStub = nil
+	fake.explainJobReturns = struct {
+		result1 atc.JobExplanation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ExplainJobReturnsOnCall(i int, result1 atc.JobExplanation, result2 bool, result3 error) {
+	fake.explainJobMutex.Lock()
+	defer fake.explainJobMutex.Unlock()
+	fake.ExplainJobStub = nil
+	if fake.explainJobReturnsOnCall == nil {
+		fake.explainJobReturnsOnCall = make(map[int]struct {
+			result1 atc.JobExplanation
+			result2 bool
+			result3 error
+		})
+	}
+	fake.explainJobReturnsOnCall[i] = struct {
+		result1 atc.JobExplanation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ExposePipeline(arg1 string) (bool, error) {
 	fake.exposePipelineMutex.Lock()
 	ret, specificReturn := fake.exposePipelineReturnsOnCall[len(fake.exposePipelineArgsForCall)]
@@ -3159,6 +3347,77 @@ func (fake *FakeTeam) ResourceReturnsOnCall(i int, result1 atc.Resource, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ResourceChecks(arg1 string, arg2 string, arg3 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
+	fake.resourceChecksMutex.Lock()
+	ret, specificReturn := fake.resourceChecksReturnsOnCall[len(fake.resourceChecksArgsForCall)]
+	fake.resourceChecksArgsForCall = append(fake.resourceChecksArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 concourse.Page
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ResourceChecks", []interface{}{arg1, arg2, arg3})
+	fake.resourceChecksMutex.Unlock()
+	if fake.ResourceChecksStub != nil {
+		return fake.ResourceChecksStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	fakeReturns := fake.resourceChecksReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeTeam) ResourceChecksCallCount() int {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	return len(fake.resourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceChecksCalls(stub func(string, string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) ResourceChecksArgsForCall(i int) (string, string, concourse.Page) {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	argsForCall := fake.resourceChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ResourceChecksReturns(result1 []atc.Build, result2 concourse.Pagination, result3 bool, result4 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	fake.resourceChecksReturns = struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeam) ResourceChecksReturnsOnCall(i int, result1 []atc.Build, result2 concourse.Pagination, result3 bool, result4 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	if fake.resourceChecksReturnsOnCall == nil {
+		fake.resourceChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.Build
+			result2 concourse.Pagination
+			result3 bool
+			result4 error
+		})
+	}
+	fake.resourceChecksReturnsOnCall[i] = struct {
+		result1 []atc.Build
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakeTeam) ResourceVersions(arg1 string, arg2 string, arg3 concourse.Page) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
 	fake.resourceVersionsMutex.Lock()
 	ret, specificReturn := fake.resourceVersionsReturnsOnCall[len(fake.resourceVersionsArgsForCall)]
@@ -3434,6 +3693,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.buildsWithVersionAsInputMutex.RUnlock()
 	fake.buildsWithVersionAsOutputMutex.RLock()
 	defer fake.buildsWithVersionAsOutputMutex.RUnlock()
+	fake.checkPipelineCredsMutex.RLock()
+	defer fake.checkPipelineCredsMutex.RUnlock()
 	fake.checkResourceMutex.RLock()
 	defer fake.checkResourceMutex.RUnlock()
 	fake.checkResourceTypeMutex.RLock()
@@ -3460,6 +3721,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.disableResourceVersionMutex.RUnlock()
 	fake.enableResourceVersionMutex.RLock()
 	defer fake.enableResourceVersionMutex.RUnlock()
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
 	fake.exposePipelineMutex.RLock()
 	defer fake.exposePipelineMutex.RUnlock()
 	fake.getArtifactMutex.RLock()
@@ -3504,6 +3767,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renameTeamMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
@@ -119,3 +119,27 @@ func (team *team) CreateOrUpdatePipelineConfig(pipelineName string, configVersio
 
 	return response.Created, !response.Created, configResponse.Warnings, nil
 }
+
+func (team *team) CheckPipelineCreds(pipelineName string) (atc.CheckCredsResponse, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"team_name":     team.name,
+	}
+
+	var checkResponse atc.CheckCredsResponse
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.CheckPipelineCreds,
+		Params:      params,
+	}, &internal.Response{
+		Result: &checkResponse,
+	})
+
+	switch err.(type) {
+	case nil:
+		return checkResponse, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.CheckCredsResponse{}, false, nil
+	default:
+		return atc.CheckCredsResponse{}, false, err
+	}
+}
@@ -142,6 +142,74 @@ var _ = Describe("ATC Handler Configs", func() {
 		})
 	})
 
+	Describe("CheckPipelineCreds", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/check-creds"
+
+		Context("when the pipeline exists", func() {
+			var expectedResponse atc.CheckCredsResponse
+
+			BeforeEach(func() {
+				expectedResponse = atc.CheckCredsResponse{
+					Checked: 2,
+					Failures: []atc.CredentialCheckFailure{
+						{
+							Location: "resources.some-resource",
+							Var:      "some-var",
+							Missing:  true,
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResponse),
+					),
+				)
+			})
+
+			It("returns the result of the check", func() {
+				checkResponse, found, err := team.CheckPipelineCreds("mypipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checkResponse).To(Equal(expectedResponse))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.CheckPipelineCreds("mypipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the ATC returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, _, err := team.CheckPipelineCreds("mypipeline")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("CreateOrUpdatePipelineConfig", func() {
 		var (
 			expectedPipelineName string
@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ExplainJob(pipelineName string, jobName string) (atc.JobExplanation, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"job_name":      jobName,
+		"team_name":     team.name,
+	}
+
+	var explanation atc.JobExplanation
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ExplainJob,
+		Params:      params,
+	}, &internal.Response{
+		Result: &explanation,
+	})
+
+	switch err.(type) {
+	case nil:
+		return explanation, true, nil
+	case internal.ResourceNotFoundError:
+		return explanation, false, nil
+	default:
+		return explanation, false, err
+	}
+}
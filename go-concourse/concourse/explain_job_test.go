@@ -0,0 +1,98 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Explain Job", func() {
+	Describe("ExplainJob", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/explanation"
+
+		var (
+			explanation atc.JobExplanation
+			found       bool
+			clientErr   error
+		)
+
+		JustBeforeEach(func() {
+			explanation, found, clientErr = team.ExplainJob("mypipeline", "myjob")
+		})
+
+		Context("when pipeline/job exists", func() {
+			var expectedExplanation atc.JobExplanation
+
+			BeforeEach(func() {
+				expectedExplanation = atc.JobExplanation{
+					Job:             "myjob",
+					PausedJob:       true,
+					InputsSatisfied: true,
+					Inputs: []atc.JobInputExplanation{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Trigger:  true,
+							Passed:   []string{"upstream"},
+							Version:  atc.Version{"ref": "v1"},
+							Candidates: []atc.InputCandidateExplanation{
+								{Version: atc.Version{"ref": "v2"}, NotPassed: []string{"upstream"}},
+								{Version: atc.Version{"ref": "v1"}},
+							},
+							TotalCandidates: 2,
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedExplanation),
+					),
+				)
+			})
+
+			It("returns the explanation", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(explanation).To(Equal(expectedExplanation))
+			})
+		})
+
+		Context("when pipeline/job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false in the found value and no error", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the server returns a 500 error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(clientErr).To(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -27,10 +27,12 @@ type Team interface {
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineName string) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineName string, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	CheckPipelineCreds(pipelineName string) (atc.CheckCredsResponse, bool, error)
 
 	CreatePipelineBuild(pipelineName string, plan atc.Plan) (atc.Build, error)
 
 	BuildInputsForJob(pipelineName string, jobName string) ([]atc.BuildInput, bool, error)
+	ExplainJob(pipelineName string, jobName string) (atc.JobExplanation, bool, error)
 
 	Job(pipelineName, jobName string) (atc.Job, bool, error)
 	JobBuild(pipelineName, jobName, buildName string) (atc.Build, bool, error)
@@ -47,7 +49,8 @@ type Team interface {
 	ListResources(pipelineName string) ([]atc.Resource, error)
 	VersionedResourceTypes(pipelineName string) (atc.VersionedResourceTypes, bool, error)
 	ResourceVersions(pipelineName string, resourceName string, page Page) ([]atc.ResourceVersion, Pagination, bool, error)
-	CheckResource(pipelineName string, resourceName string, version atc.Version) (bool, error)
+	CheckResource(pipelineName string, resourceName string, version atc.Version) (atc.Build, bool, error)
+	ResourceChecks(pipelineName string, resourceName string, page Page) ([]atc.Build, Pagination, bool, error)
 	CheckResourceType(pipelineName string, resourceTypeName string, version atc.Version) (bool, error)
 	DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
 	EnableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
@@ -159,12 +159,46 @@ handleEvent event ( model, effects, outmsg ) =
             , outmsg
             )
 
+        Initialize origin time ->
+            ( updateStep origin.id (setInitialize time) model
+            , effects
+            , outmsg
+            )
+
+        Start origin time ->
+            ( updateStep origin.id (setStart time) model
+            , effects
+            , outmsg
+            )
+
+        Finish origin time succeeded ->
+            ( updateStep origin.id
+                (finishStep
+                    (if succeeded then
+                        0
+
+                     else
+                        1
+                    )
+                    (Just time)
+                )
+                model
+            , effects
+            , outmsg
+            )
+
         InitializeTask origin time ->
             ( updateStep origin.id (setInitialize time) model
             , effects
             , outmsg
             )
 
+        WaitingForWorker origin time ->
+            ( updateStep origin.id (appendStepLog "waiting for a worker with capacity to run the task\n" (Just time)) model
+            , effects
+            , outmsg
+            )
+
         StartTask origin time ->
             ( updateStep origin.id (setStart time) model
             , effects
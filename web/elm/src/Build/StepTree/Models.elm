@@ -44,6 +44,8 @@ type StepTree
     | Get Step
     | ArtifactOutput Step
     | Put Step
+    | SetPipeline Step
+    | LoadVar Step
     | Aggregate (Array StepTree)
     | Do (Array StepTree)
     | OnSuccess HookedStep
@@ -120,7 +122,11 @@ type alias BuildEventEnvelope =
 
 type BuildEvent
     = BuildStatus Concourse.BuildStatus Time.Posix
+    | Initialize Origin Time.Posix
+    | Start Origin Time.Posix
+    | Finish Origin Time.Posix Bool
     | InitializeTask Origin Time.Posix
+    | WaitingForWorker Origin Time.Posix
     | StartTask Origin Time.Posix
     | FinishTask Origin Int Time.Posix
     | InitializeGet Origin Time.Posix
@@ -181,6 +187,12 @@ map f tree =
         Put step ->
             Put (f step)
 
+        SetPipeline step ->
+            SetPipeline (f step)
+
+        LoadVar step ->
+            LoadVar (f step)
+
         _ ->
             tree
 
@@ -323,6 +335,12 @@ finishTree root =
         Put step ->
             Put (finishStep step)
 
+        SetPipeline step ->
+            SetPipeline (finishStep step)
+
+        LoadVar step ->
+            LoadVar (finishStep step)
+
         Aggregate trees ->
             Aggregate (Array.map finishTree trees)
 
@@ -80,6 +80,12 @@ init hl resources buildPlan =
         Concourse.BuildStepPut name ->
             initBottom hl Put buildPlan.id name
 
+        Concourse.BuildStepSetPipeline name ->
+            initBottom hl SetPipeline buildPlan.id name
+
+        Concourse.BuildStepLoadVar name ->
+            initBottom hl LoadVar buildPlan.id name
+
         Concourse.BuildStepAggregate plans ->
             initMultiStep hl resources buildPlan.id Aggregate plans
 
@@ -276,6 +282,12 @@ treeIsActive stepTree =
         Put step ->
             stepIsActive step
 
+        SetPipeline step ->
+            stepIsActive step
+
+        LoadVar step ->
+            stepIsActive step
+
 
 stepIsActive : Step -> Bool
 stepIsActive =
@@ -425,6 +437,12 @@ viewTree timeZone model tree =
         Put step ->
             viewStep model timeZone step StepHeaderPut
 
+        SetPipeline step ->
+            viewStep model timeZone step StepHeaderTask
+
+        LoadVar step ->
+            viewStep model timeZone step StepHeaderTask
+
         Try step ->
             viewTree timeZone model step
 
@@ -337,6 +337,8 @@ type BuildStep
     | BuildStepGet StepName (Maybe Version)
     | BuildStepArtifactOutput StepName
     | BuildStepPut StepName
+    | BuildStepSetPipeline StepName
+    | BuildStepLoadVar StepName
     | BuildStepAggregate (Array BuildPlan)
     | BuildStepDo (Array BuildPlan)
     | BuildStepOnSuccess HookedPlan
@@ -378,10 +380,18 @@ decodeBuildPlan_ =
                     lazy (\_ -> decodeBuildStepPut)
                 , Json.Decode.field "artifact_output" <|
                     lazy (\_ -> decodeBuildStepArtifactOutput)
+                , Json.Decode.field "set_pipeline" <|
+                    lazy (\_ -> decodeBuildStepSetPipeline)
+                , Json.Decode.field "load_var" <|
+                    lazy (\_ -> decodeBuildStepLoadVar)
                 , Json.Decode.field "dependent_get" <|
                     lazy (\_ -> decodeBuildStepGet)
                 , Json.Decode.field "aggregate" <|
                     lazy (\_ -> decodeBuildStepAggregate)
+                , Json.Decode.field "in_parallel" <|
+                    lazy (\_ -> decodeBuildStepInParallel)
+                , Json.Decode.field "across" <|
+                    lazy (\_ -> decodeBuildStepAcross)
                 , Json.Decode.field "do" <|
                     lazy (\_ -> decodeBuildStepDo)
                 , Json.Decode.field "on_success" <|
@@ -435,12 +445,44 @@ decodeBuildStepPut =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepSetPipeline : Json.Decode.Decoder BuildStep
+decodeBuildStepSetPipeline =
+    Json.Decode.succeed BuildStepSetPipeline
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepLoadVar : Json.Decode.Decoder BuildStep
+decodeBuildStepLoadVar =
+    Json.Decode.succeed BuildStepLoadVar
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepAggregate : Json.Decode.Decoder BuildStep
 decodeBuildStepAggregate =
     Json.Decode.succeed BuildStepAggregate
         |> andMap (Json.Decode.array (lazy (\_ -> decodeBuildPlan_)))
 
 
+decodeBuildStepInParallel : Json.Decode.Decoder BuildStep
+decodeBuildStepInParallel =
+    Json.Decode.succeed BuildStepAggregate
+        |> andMap (Json.Decode.field "steps" <| Json.Decode.array (lazy (\_ -> decodeBuildPlan_)))
+
+
+decodeBuildStepAcross : Json.Decode.Decoder BuildStep
+decodeBuildStepAcross =
+    Json.Decode.succeed BuildStepAggregate
+        |> andMap
+            (Json.Decode.oneOf
+                [ Json.Decode.field "steps" <|
+                    Json.Decode.array (Json.Decode.field "step" (lazy (\_ -> decodeBuildPlan_)))
+
+                -- values loaded at runtime aren't known until the build runs
+                , Json.Decode.succeed Array.empty
+                ]
+            )
+
+
 decodeBuildStepDo : Json.Decode.Decoder BuildStep
 decodeBuildStepDo =
     Json.Decode.succeed BuildStepDo
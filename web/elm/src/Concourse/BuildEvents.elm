@@ -83,6 +83,31 @@ decodeBuildEvent =
                     "error" ->
                         Json.Decode.field "data" decodeErrorEvent
 
+                    "initialize" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 Initialize
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "start" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 Start
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "finish" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 Finish
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                                (Json.Decode.field "succeeded" Json.Decode.bool)
+                            )
+
                     "initialize-task" ->
                         Json.Decode.field
                             "data"
@@ -91,6 +116,14 @@ decodeBuildEvent =
                                 (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
                             )
 
+                    "waiting-for-worker" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 WaitingForWorker
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "start-task" ->
                         Json.Decode.field
                             "data"
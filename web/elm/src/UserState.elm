@@ -26,8 +26,11 @@ isMember { teamName, userState } =
         UserStateLoggedIn user ->
             case Dict.get teamName user.teams of
                 Just roles ->
+                    -- pipeline operators may pause, pin, and check, so
+                    -- they're given the same controls as members
                     List.member "member" roles
                         || List.member "owner" roles
+                        || List.member "pipeline-operator" roles
 
                 Nothing ->
                     False
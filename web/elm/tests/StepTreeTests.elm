@@ -4,9 +4,11 @@ module StepTreeTests exposing
     , initAggregateNested
     , initEnsure
     , initGet
+    , initLoadVar
     , initOnFailure
     , initOnSuccess
     , initPut
+    , initSetPipeline
     , initTask
     , initTimeout
     , initTry
@@ -29,6 +31,8 @@ all =
         [ initTask
         , initGet
         , initPut
+        , initSetPipeline
+        , initLoadVar
         , initAggregate
         , initAggregateNested
         , initOnSuccess
@@ -148,6 +152,58 @@ initPut =
         ]
 
 
+initSetPipeline : Test
+initSetPipeline =
+    let
+        { tree, foci } =
+            StepTree.init Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = BuildStepSetPipeline "some-pipeline"
+                }
+    in
+    describe "init with SetPipeline"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.SetPipeline (someStep "some-id" "some-pipeline" Models.StepStatePending))
+                    tree
+        , test "using the focus" <|
+            \_ ->
+                assertFocus "some-id"
+                    foci
+                    tree
+                    (\s -> { s | state = Models.StepStateSucceeded })
+                    (Models.SetPipeline (someStep "some-id" "some-pipeline" Models.StepStateSucceeded))
+        ]
+
+
+initLoadVar : Test
+initLoadVar =
+    let
+        { tree, foci } =
+            StepTree.init Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = BuildStepLoadVar "some-var"
+                }
+    in
+    describe "init with LoadVar"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.LoadVar (someStep "some-id" "some-var" Models.StepStatePending))
+                    tree
+        , test "using the focus" <|
+            \_ ->
+                assertFocus "some-id"
+                    foci
+                    tree
+                    (\s -> { s | state = Models.StepStateSucceeded })
+                    (Models.LoadVar (someStep "some-id" "some-var" Models.StepStateSucceeded))
+        ]
+
+
 initAggregate : Test
 initAggregate =
     let
@@ -478,6 +534,12 @@ updateStep f tree =
         Models.Put step ->
             Models.Put (f step)
 
+        Models.SetPipeline step ->
+            Models.SetPipeline (f step)
+
+        Models.LoadVar step ->
+            Models.LoadVar (f step)
+
         _ ->
             tree
 